@@ -3,16 +3,21 @@ package api
 import (
 	db "bank/db/sqlc"
 	"bank/token"
+	"bank/util"
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/lib/pq"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type createAccountRequest struct {
 	Currency string `json:"currency" binding:"required,currency"`
+	// Joint accounts can be shared with other users, a user can hold any number of them in a currency
+	Joint bool `json:"joint"`
 }
 
 func (server *Server) createAccount(ctx *gin.Context) {
@@ -27,6 +32,7 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		Owner:    authPayload.Username,
 		Balance:  0,
 		Currency: req.Currency,
+		Joint:    req.Joint,
 	}
 	account, err := server.store.CreateAccount(ctx, args)
 	if err != nil {
@@ -65,10 +71,7 @@ func (server *Server) getAccount(ctx *gin.Context) {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if authPayload.Username != account.Owner {
-		err := errors.New("account doesn't belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errResponse(err))
+	if !server.validAccountRole(ctx, account, db.AccountRoleViewOnly) {
 		return
 	}
 
@@ -76,10 +79,18 @@ func (server *Server) getAccount(ctx *gin.Context) {
 }
 
 type listAccountRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`          //validate
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"` //validate
+	// PageID selects a page by offset, it is kept for older clients and takes precedence over the page token
+	PageID int32 `form:"page_id" binding:"omitempty,min=1"` //validate
+	pageRequest
+}
+
+type listAccountResponse struct {
+	Accounts      []db.Account `json:"accounts"`
+	NextPageToken string       `json:"next_page_token"`
 }
 
+// listAccount returns a page of accounts of the authenticated user, as a plain list when the page is
+// selected by page_id and with the token of the next page otherwise
 func (server *Server) listAccount(ctx *gin.Context) {
 	var req listAccountRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
@@ -88,15 +99,237 @@ func (server *Server) listAccount(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	arg := db.ListAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+	if req.PageID > 0 {
+		arg := db.ListAccountsParams{
+			Owner:  authPayload.Username,
+			Limit:  req.PageSize,
+			Offset: (req.PageID - 1) * req.PageSize,
+		}
+		account, err := server.store.ListAccounts(ctx, arg)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, account)
+		return
 	}
-	account, err := server.store.ListAccounts(ctx, arg)
+
+	afterID, err := req.afterID()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	accounts, err := server.store.ListAccountsAfter(ctx, db.ListAccountsAfterParams{
+		Owner:      authPayload.Username,
+		AfterID:    afterID,
+		LimitCount: req.limit(),
+	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errResponse(err))
 		return
 	}
+
+	var res listAccountResponse
+	res.Accounts, res.NextPageToken = util.NextPage(accounts, req.PageSize, func(account db.Account) int64 { return account.ID })
+	ctx.JSON(http.StatusOK, res)
+}
+
+type accountBalanceResponse struct {
+	AccountID int64  `json:"account_id"`
+	Currency  string `json:"currency"`
+	Balance   int64  `json:"balance"`
+	Held      int64  `json:"held"`
+	// Available is the balance less the funds reserved by active holds
+	Available int64 `json:"available"`
+	// the same amounts as decimals in the currency of the account
+	BalanceFormatted   string `json:"balance_formatted"`
+	HeldFormatted      string `json:"held_formatted"`
+	AvailableFormatted string `json:"available_formatted"`
+}
+
+func (server *Server) getAccountBalance(ctx *gin.Context) {
+	var req getAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, req.ID, db.AccountRoleViewOnly)
+	if !valid {
+		return
+	}
+
+	held, err := server.store.GetHeldAmount(ctx, db.GetHeldAmountParams{
+		AccountID: account.ID,
+		Now:       time.Now(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, accountBalanceResponse{
+		AccountID:          account.ID,
+		Currency:           account.Currency,
+		Balance:            account.Balance,
+		Held:               held,
+		Available:          account.Balance - held,
+		BalanceFormatted:   util.FormatCurrencyAmount(account.Balance, account.Currency),
+		HeldFormatted:      util.FormatCurrencyAmount(held, account.Currency),
+		AvailableFormatted: util.FormatCurrencyAmount(account.Balance-held, account.Currency),
+	})
+}
+
+type updateAccountStatusRequest struct {
+	// accounts are closed with closeAccount and only become dormant through inactivity
+	Status string `json:"status" binding:"required,oneof=active frozen"`
+}
+
+// updateAccountStatus lets the owner or an officer freeze an account, unfreeze it or reactivate a dormant account.
+// A frozen account can only be unfrozen by the user who froze it or an officer.
+func (server *Server) updateAccountStatus(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req updateAccountStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validStatusAccount(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	account, err := server.store.UpdateAccountStatusTx(ctx, db.UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    req.Status,
+		Username:  authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrAccountUnfreeze) {
+			ctx.JSON(http.StatusForbidden, errResponse(err))
+			return
+		}
+		transferErrResponse(ctx, err)
+		return
+	}
 	ctx.JSON(http.StatusOK, account)
 }
+
+type closeAccountRequest struct {
+	// SweepAccountID receives the remaining balance, it is only needed when the balance isn't zero
+	SweepAccountID int64 `json:"sweep_account_id" binding:"omitempty,min=1"`
+}
+
+// closeAccount sweeps the balance of an account to another account the authenticated user owns and closes it
+func (server *Server) closeAccount(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req closeAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleOwner)
+	if !valid {
+		return
+	}
+	if req.SweepAccountID != 0 {
+		if _, valid = server.validMemberAccount(ctx, req.SweepAccountID, db.AccountRoleOwner); !valid {
+			return
+		}
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.CloseAccountTx(ctx, db.CloseAccountTxParams{
+		AccountID:      account.ID,
+		SweepAccountID: req.SweepAccountID,
+		Username:       authPayload.Username,
+	})
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// validStatusAccount loads an account whose status the authenticated user can change,
+// an officer can change any account and a customer only the accounts they own
+func (server *Server) validStatusAccount(ctx *gin.Context, id int64) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return account, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return account, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	role, err := db.AccountRole(ctx, server.store, account, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return account, false
+	}
+	if db.AccountRoleGrants(role, db.AccountRoleOwner) {
+		return account, true
+	}
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return account, false
+	}
+	if user.Role == db.RoleOfficer {
+		return account, true
+	}
+
+	return account, server.validAccountRole(ctx, account, db.AccountRoleOwner)
+}
+
+// validMemberAccount loads an account and makes sure the authenticated user has at least the given role on it
+func (server *Server) validMemberAccount(ctx *gin.Context, id int64, role string) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return account, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return account, false
+	}
+
+	return account, server.validAccountRole(ctx, account, role)
+}
+
+// validAccountRole answers 401 when the authenticated user isn't a member of the account
+// and 403 when their role doesn't have the rights of the given role
+func (server *Server) validAccountRole(ctx *gin.Context, account db.Account, role string) bool {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	memberRole, err := db.AccountRole(ctx, server.store, account, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return false
+	}
+	if memberRole == "" {
+		err = errors.New("account doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errResponse(err))
+		return false
+	}
+	if !db.AccountRoleGrants(memberRole, role) {
+		err = fmt.Errorf("a %s member of account [%d] can't do this", memberRole, account.ID)
+		ctx.JSON(http.StatusForbidden, errResponse(err))
+		return false
+	}
+
+	return true
+}
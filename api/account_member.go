@@ -0,0 +1,187 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"bank/token"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"net/http"
+)
+
+type listAccountMemberResponse struct {
+	// Holder is the user the account was opened by, an owner who has no member row and can't be removed
+	Holder  string             `json:"holder"`
+	Members []db.AccountMember `json:"members"`
+}
+
+// listAccountMember shows who an account of the authenticated user is shared with, invitations included
+func (server *Server) listAccountMember(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleViewOnly)
+	if !valid {
+		return
+	}
+
+	members, err := server.store.ListAccountMembers(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, listAccountMemberResponse{
+		Holder:  account.Owner,
+		Members: members,
+	})
+}
+
+type inviteAccountMemberRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	Role     string `json:"role" binding:"required,oneof=owner can_transfer view_only"`
+}
+
+// inviteAccountMember invites a user to share a joint account, only its owners can do it.
+// The user becomes a member once they accept the invitation.
+func (server *Server) inviteAccountMember(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req inviteAccountMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleOwner)
+	if !valid {
+		return
+	}
+	if !account.Joint {
+		err := fmt.Errorf("account [%d] is not a joint account", account.ID)
+		ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+		return
+	}
+	if account.Status == db.AccountClosed {
+		err := fmt.Errorf("account [%d] is closed", account.ID)
+		ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+		return
+	}
+	if req.Username == account.Owner {
+		err := fmt.Errorf("%s already holds account [%d]", req.Username, account.ID)
+		ctx.JSON(http.StatusForbidden, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	member, err := server.store.CreateAccountMember(ctx, db.CreateAccountMemberParams{
+		AccountID: account.ID,
+		Username:  req.Username,
+		Role:      req.Role,
+		InvitedBy: authPayload.Username,
+	})
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code.Name() {
+			case "foreign_key_violation", "unique_violation":
+				ctx.JSON(http.StatusForbidden, errResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, member)
+}
+
+// acceptAccountMember makes the authenticated user a member of an account they were invited to
+func (server *Server) acceptAccountMember(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	member, err := server.store.AcceptAccountMember(ctx, db.AcceptAccountMemberParams{
+		AccountID: uri.ID,
+		Username:  authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("no pending invitation to account [%d]", uri.ID)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, member)
+}
+
+type accountMemberURI struct {
+	ID       int64  `uri:"id" binding:"required,min=1"`
+	Username string `uri:"username" binding:"required"`
+}
+
+// removeAccountMember takes a user off an account. Owners can remove any member,
+// the other members can only remove themselves, which is also how an invitation is declined.
+func (server *Server) removeAccountMember(ctx *gin.Context) {
+	var uri accountMemberURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	if uri.Username == account.Owner {
+		err = fmt.Errorf("the holder of account [%d] can't be removed", account.ID)
+		ctx.JSON(http.StatusForbidden, errResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if uri.Username != authPayload.Username && !server.validAccountRole(ctx, account, db.AccountRoleOwner) {
+		return
+	}
+
+	member, err := server.store.DeleteAccountMember(ctx, db.DeleteAccountMemberParams{
+		AccountID: account.ID,
+		Username:  uri.Username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, member)
+}
+
+// listAccountInvitation shows the invitations the authenticated user hasn't accepted yet
+func (server *Server) listAccountInvitation(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	invitations, err := server.store.ListAccountInvitations(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, invitations)
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"bank/util"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// maxBalanceSeriesDays is the longest daily balance series a single request can ask for
+const maxBalanceSeriesDays = 366
+
+type getBalanceAsOfRequest struct {
+	AsOf time.Time `form:"as_of" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type balanceAsOfResponse struct {
+	AccountID        int64     `json:"account_id"`
+	Currency         string    `json:"currency"`
+	AsOf             time.Time `json:"as_of"`
+	Balance          int64     `json:"balance"`
+	BalanceFormatted string    `json:"balance_formatted"`
+}
+
+// getBalanceAsOf returns the balance an account of the authenticated user had at a point in time
+func (server *Server) getBalanceAsOf(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req getBalanceAsOfRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleViewOnly)
+	if !valid {
+		return
+	}
+
+	balance, err := server.store.GetBalanceAsOf(ctx, db.GetBalanceAsOfParams{
+		AccountID: account.ID,
+		AsOf:      req.AsOf,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, balanceAsOfResponse{
+		AccountID:        account.ID,
+		Currency:         account.Currency,
+		AsOf:             req.AsOf,
+		Balance:          balance,
+		BalanceFormatted: util.FormatCurrencyAmount(balance, account.Currency),
+	})
+}
+
+type listDailyBalanceRequest struct {
+	// From and To are both inclusive dates
+	From time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To   time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+}
+
+type dailyBalance struct {
+	Date             string `json:"date"`
+	Balance          int64  `json:"balance"`
+	BalanceFormatted string `json:"balance_formatted"`
+}
+
+type listDailyBalanceResponse struct {
+	AccountID int64  `json:"account_id"`
+	Currency  string `json:"currency"`
+	// Balances holds the closing balance of every UTC day of the period, oldest first
+	Balances []dailyBalance `json:"balances"`
+}
+
+// listDailyBalance returns the end of day balances of an account of the authenticated user, for charts
+func (server *Server) listDailyBalance(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req listDailyBalanceRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if req.To.Before(req.From) {
+		err := errors.New("to must not be before from")
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	if req.To.Sub(req.From) >= maxBalanceSeriesDays*24*time.Hour {
+		err := fmt.Errorf("a balance series can't cover more than %d days", maxBalanceSeriesDays)
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleViewOnly)
+	if !valid {
+		return
+	}
+
+	rows, err := server.store.ListDailyBalances(ctx, db.ListDailyBalancesParams{
+		AccountID: account.ID,
+		FromDay:   req.From,
+		ToDay:     req.To,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	res := listDailyBalanceResponse{
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		Balances:  make([]dailyBalance, len(rows)),
+	}
+	for i, row := range rows {
+		res.Balances[i] = dailyBalance{
+			Date:             row.Day.Format("2006-01-02"),
+			Balance:          row.Balance,
+			BalanceFormatted: util.FormatCurrencyAmount(row.Balance, account.Currency),
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	mock "bank/db/mock"
+	db "bank/db/sqlc"
+	"bank/util"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestGetBalanceAsOfAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	account := randomAccount(user1.Username)
+	account.Currency = util.EUR
+	asOf := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		username      string
+		asOf          string
+		buildStubs    func(store *mock.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user1.Username,
+			asOf:     asOf.Format(time.RFC3339),
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetBalanceAsOf(gomock.Any(), gomock.Eq(db.GetBalanceAsOfParams{
+					AccountID: account.ID,
+					AsOf:      asOf,
+				})).Times(1).Return(int64(12345), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got balanceAsOfResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, int64(12345), got.Balance)
+				require.Equal(t, "123.45", got.BalanceFormatted)
+				require.Equal(t, util.EUR, got.Currency)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: user2.Username,
+			asOf:     asOf.Format(time.RFC3339),
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetBalanceAsOf(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidTimestamp",
+			username: user1.Username,
+			asOf:     "yesterday",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetBalanceAsOf(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			path := fmt.Sprintf("/accounts/%d/balance_as_of?as_of=%s", account.ID, url.QueryEscape(tc.asOf))
+			request, err := http.NewRequest(http.MethodGet, path, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListDailyBalanceAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Currency = util.USD
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mock.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "from=2024-03-01&to=2024-03-02",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListDailyBalances(gomock.Any(), gomock.Eq(db.ListDailyBalancesParams{
+					AccountID: account.ID,
+					FromDay:   from,
+					ToDay:     from.AddDate(0, 0, 1),
+				})).Times(1).Return([]db.ListDailyBalancesRow{
+					{Day: from, Balance: 100},
+					{Day: from.AddDate(0, 0, 1), Balance: 250},
+				}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got listDailyBalanceResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, []dailyBalance{
+					{Date: "2024-03-01", Balance: 100, BalanceFormatted: "1.00"},
+					{Date: "2024-03-02", Balance: 250, BalanceFormatted: "2.50"},
+				}, got.Balances)
+			},
+		},
+		{
+			name:  "ToBeforeFrom",
+			query: "from=2024-03-02&to=2024-03-01",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ListDailyBalances(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "PeriodTooLong",
+			query: "from=2023-01-01&to=2024-03-01",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ListDailyBalances(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			path := fmt.Sprintf("/accounts/%d/daily_balances?%s", account.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, path, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
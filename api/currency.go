@@ -0,0 +1,124 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"bank/token"
+	"bank/util"
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"net/http"
+)
+
+// listCurrency shows the whole currency registry, disabled currencies included
+func (server *Server) listCurrency(ctx *gin.Context) {
+	currencies, err := server.store.ListCurrencies(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, currencies)
+}
+
+type createCurrencyRequest struct {
+	Code string `json:"code" binding:"required,len=3,alpha,uppercase"`
+	// Exponent is the number of minor unit digits, as listed by ISO 4217
+	Exponent *int32 `json:"exponent" binding:"required,min=0,max=4"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// createCurrency adds a currency to the registry with the bank accounts fees and interest need, only admins can do it
+func (server *Server) createCurrency(ctx *gin.Context) {
+	var req createCurrencyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validAdmin(ctx, authPayload.Username); !valid {
+		return
+	}
+
+	result, err := server.store.CreateCurrencyTx(ctx, db.CreateCurrencyParams{
+		Code:     req.Code,
+		Exponent: *req.Exponent,
+		Enabled:  req.Enabled,
+	})
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	util.SetCurrency(result.Currency.Registry())
+	ctx.JSON(http.StatusOK, result.Currency)
+}
+
+type updateCurrencyURI struct {
+	Code string `uri:"code" binding:"required,len=3"`
+}
+
+type updateCurrencyRequest struct {
+	// the exponent can't change once accounts hold amounts in the currency
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// updateCurrency enables or disables a currency, only admins can do it.
+// Accounts in a disabled currency keep their balance but no new account or transfer can use it.
+func (server *Server) updateCurrency(ctx *gin.Context) {
+	var uri updateCurrencyURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req updateCurrencyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validAdmin(ctx, authPayload.Username); !valid {
+		return
+	}
+
+	currency, err := server.store.UpdateCurrency(ctx, db.UpdateCurrencyParams{
+		Enabled: *req.Enabled,
+		Code:    uri.Code,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	util.SetCurrency(currency.Registry())
+	ctx.JSON(http.StatusOK, currency)
+}
+
+// validAdmin makes sure the authenticated user is an admin
+func (server *Server) validAdmin(ctx *gin.Context, username string) (db.User, bool) {
+	user, err := server.store.GetUser(ctx, username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return user, false
+	}
+	if user.Role != db.RoleAdmin {
+		err = errors.New("only an admin can do this")
+		ctx.JSON(http.StatusForbidden, errResponse(err))
+		return user, false
+	}
+	return user, true
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	mock "bank/db/mock"
+	db "bank/db/sqlc"
+	"bank/token"
+	"bank/util"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateCurrencyAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = db.RoleAdmin
+	officer, _ := randomUser(t)
+	officer.Role = db.RoleOfficer
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mock.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"code": "XTS", "exponent": 0, "enabled": true},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().CreateCurrencyTx(gomock.Any(), gomock.Eq(db.CreateCurrencyParams{
+					Code:     "XTS",
+					Exponent: 0,
+					Enabled:  true,
+				})).Times(1).Return(db.CreateCurrencyTxResult{
+					Currency: db.Currency{Code: "XTS", Exponent: 0, Enabled: true},
+				}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				// the registry picks up the new currency at once
+				require.True(t, util.IsSupportCurrency("XTS"))
+				require.Equal(t, "100", util.FormatCurrencyAmount(100, "XTS"))
+			},
+		},
+		{
+			name: "NotAdmin",
+			body: gin.H{"code": "XTS", "exponent": 0, "enabled": true},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, officer.Username, time.Minute)
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(officer.Username)).Times(1).Return(officer, nil)
+				store.EXPECT().CreateCurrencyTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateCode",
+			body: gin.H{"code": util.USD, "exponent": 2, "enabled": true},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().CreateCurrencyTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.CreateCurrencyTxResult{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidCode",
+			body: gin.H{"code": "usd", "exponent": 2, "enabled": true},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateCurrencyTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingExponent",
+			body: gin.H{"code": "XTS", "enabled": true},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().CreateCurrencyTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/currencies", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateCurrencyAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = db.RoleAdmin
+	util.SetCurrency(util.Currency{Code: "XXA", Exponent: 2, Enabled: true})
+
+	testCases := []struct {
+		name          string
+		code          string
+		buildStubs    func(store *mock.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			code: "XXA",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().UpdateCurrency(gomock.Any(), gomock.Eq(db.UpdateCurrencyParams{
+					Enabled: false,
+					Code:    "XXA",
+				})).Times(1).Return(db.Currency{Code: "XXA", Exponent: 2, Enabled: false}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.False(t, util.IsSupportCurrency("XXA"))
+			},
+		},
+		{
+			name: "NotFound",
+			code: "XXB",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().UpdateCurrency(gomock.Any(), gomock.Any()).Times(1).Return(db.Currency{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"enabled": false})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPatch, "/currencies/"+tc.code, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestAccountBalanceFormatted(t *testing.T) {
+	util.SetCurrency(util.Currency{Code: "XXC", Exponent: 3, Enabled: true})
+	account := randomAccount(util.RandomOwner())
+	account.Currency = "XXC"
+	account.Balance = 12345
+
+	data, err := json.Marshal(account)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Equal(t, "12.345", got["balance_formatted"])
+	require.Equal(t, float64(12345), got["balance"])
+}
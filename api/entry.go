@@ -0,0 +1,66 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"bank/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// entryResponse adds the amount and the balance after of an entry as decimals in the currency of its account
+type entryResponse struct {
+	db.Entry
+	AmountFormatted       string `json:"amount_formatted"`
+	BalanceAfterFormatted string `json:"balance_after_formatted"`
+}
+
+type listEntryResponse struct {
+	Entries       []entryResponse `json:"entries"`
+	NextPageToken string          `json:"next_page_token"`
+}
+
+// listEntry returns a page of the ledger entries of an account of the authenticated user, oldest first
+func (server *Server) listEntry(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	afterID, err := req.afterID()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleViewOnly)
+	if !valid {
+		return
+	}
+
+	entries, err := server.store.ListEntriesAfter(ctx, db.ListEntriesAfterParams{
+		AccountID:  account.ID,
+		AfterID:    afterID,
+		LimitCount: req.limit(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	var res listEntryResponse
+	entries, res.NextPageToken = util.NextPage(entries, req.PageSize, func(entry db.Entry) int64 { return entry.ID })
+	res.Entries = make([]entryResponse, len(entries))
+	for i, entry := range entries {
+		res.Entries[i] = entryResponse{
+			Entry:                 entry,
+			AmountFormatted:       util.FormatCurrencyAmount(entry.Amount, account.Currency),
+			BalanceAfterFormatted: util.FormatCurrencyAmount(entry.BalanceAfter, account.Currency),
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
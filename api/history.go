@@ -0,0 +1,129 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"bank/util"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type listAccountHistoryRequest struct {
+	pageRequest
+	// From and To are both inclusive dates
+	From      time.Time `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To        time.Time `form:"to" time_format:"2006-01-02" time_utc:"1"`
+	MinAmount int64     `form:"min_amount" binding:"omitempty,gt=0"`
+	MaxAmount int64     `form:"max_amount" binding:"omitempty,gt=0"`
+	// Direction is in for money received and out for money paid
+	Direction             string `form:"direction" binding:"omitempty,oneof=in out"`
+	CounterpartyAccountID int64  `form:"counterparty_account_id" binding:"omitempty,min=1"`
+	// ClientReference finds the transfers sent with the sender's reference
+	ClientReference string `form:"client_reference" binding:"omitempty,max=64"`
+}
+
+type accountHistoryEntry struct {
+	EntryID    int64 `json:"entry_id"`
+	TransferID int64 `json:"transfer_id,omitempty"`
+	// Amount is positive for money received and negative for money paid
+	Amount          int64  `json:"amount"`
+	AmountFormatted string `json:"amount_formatted"`
+	Direction       string `json:"direction"`
+	// the counterparty is empty for entries that aren't linked to a transfer
+	CounterpartyAccountID int64     `json:"counterparty_account_id,omitempty"`
+	CounterpartyOwner     string    `json:"counterparty_owner,omitempty"`
+	CreatedAt             time.Time `json:"created_at"`
+	// BalanceAfter is the balance of the account right after the entry was booked
+	BalanceAfter          int64  `json:"balance_after"`
+	BalanceAfterFormatted string `json:"balance_after_formatted"`
+	// Memo, ClientReference and Metadata are the details the sender attached to the transfer
+	Memo            string          `json:"memo,omitempty"`
+	ClientReference string          `json:"client_reference,omitempty"`
+	Metadata        json.RawMessage `json:"metadata"`
+}
+
+type listAccountHistoryResponse struct {
+	Entries       []accountHistoryEntry `json:"entries"`
+	NextPageToken string                `json:"next_page_token"`
+}
+
+// listAccountHistory returns a page of the transactions of an account of the authenticated user, newest first
+func (server *Server) listAccountHistory(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req listAccountHistoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	if !req.From.IsZero() && !req.To.IsZero() && req.To.Before(req.From) {
+		ctx.JSON(http.StatusBadRequest, errResponse(errors.New("to must not be before from")))
+		return
+	}
+	if req.MinAmount > 0 && req.MaxAmount > 0 && req.MaxAmount < req.MinAmount {
+		ctx.JSON(http.StatusBadRequest, errResponse(errors.New("max_amount must not be less than min_amount")))
+		return
+	}
+	beforeID, err := req.afterID()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleViewOnly)
+	if !valid {
+		return
+	}
+
+	arg := db.ListAccountHistoryParams{
+		AccountID:             account.ID,
+		BeforeID:              sql.NullInt64{Int64: beforeID, Valid: beforeID > 0},
+		FromTime:              sql.NullTime{Time: req.From, Valid: !req.From.IsZero()},
+		MinAmount:             sql.NullInt64{Int64: req.MinAmount, Valid: req.MinAmount > 0},
+		MaxAmount:             sql.NullInt64{Int64: req.MaxAmount, Valid: req.MaxAmount > 0},
+		Direction:             sql.NullString{String: req.Direction, Valid: req.Direction != ""},
+		CounterpartyAccountID: sql.NullInt64{Int64: req.CounterpartyAccountID, Valid: req.CounterpartyAccountID > 0},
+		ClientReference:       sql.NullString{String: req.ClientReference, Valid: req.ClientReference != ""},
+		LimitCount:            req.limit(),
+	}
+	if !req.To.IsZero() {
+		arg.ToTime = sql.NullTime{Time: req.To.AddDate(0, 0, 1), Valid: true}
+	}
+	rows, err := server.store.ListAccountHistory(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	var res listAccountHistoryResponse
+	rows, res.NextPageToken = util.NextPage(rows, req.PageSize, func(row db.ListAccountHistoryRow) int64 { return row.ID })
+	res.Entries = make([]accountHistoryEntry, len(rows))
+	for i, row := range rows {
+		res.Entries[i] = newAccountHistoryEntry(row, account.Currency)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func newAccountHistoryEntry(row db.ListAccountHistoryRow, currency string) accountHistoryEntry {
+	return accountHistoryEntry{
+		EntryID:               row.ID,
+		TransferID:            row.TransferID.Int64,
+		Amount:                row.Amount,
+		AmountFormatted:       util.FormatCurrencyAmount(row.Amount, currency),
+		Direction:             row.Direction(),
+		CounterpartyAccountID: row.CounterpartyAccountID.Int64,
+		CounterpartyOwner:     row.CounterpartyOwner.String,
+		CreatedAt:             row.CreatedAt,
+		BalanceAfter:          row.BalanceAfter,
+		BalanceAfterFormatted: util.FormatCurrencyAmount(row.BalanceAfter, currency),
+		Memo:                  row.Memo,
+		ClientReference:       row.ClientReference.String,
+		Metadata:              row.Metadata,
+	}
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	mock "bank/db/mock"
+	db "bank/db/sqlc"
+	"bank/util"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListAccountHistoryAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	account := randomAccount(user1.Username)
+	account.Currency = util.USD
+	counterparty := randomAccount(user2.Username)
+
+	rows := []db.ListAccountHistoryRow{
+		{
+			ID:                    12,
+			AccountID:             account.ID,
+			Amount:                -250,
+			TransferID:            sql.NullInt64{Int64: 7, Valid: true},
+			CounterpartyAccountID: sql.NullInt64{Int64: counterparty.ID, Valid: true},
+			CounterpartyOwner:     sql.NullString{String: counterparty.Owner, Valid: true},
+		},
+		{ID: 11, AccountID: account.ID, Amount: 100},
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		query         string
+		buildStubs    func(store *mock.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user1.Username,
+			query: fmt.Sprintf("page_size=5&from=2024-01-01&to=2024-01-31&min_amount=100&max_amount=500&direction=out&counterparty_account_id=%d",
+				counterparty.ID),
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListAccountHistory(gomock.Any(), gomock.Eq(db.ListAccountHistoryParams{
+					AccountID:             account.ID,
+					FromTime:              sql.NullTime{Time: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+					ToTime:                sql.NullTime{Time: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+					MinAmount:             sql.NullInt64{Int64: 100, Valid: true},
+					MaxAmount:             sql.NullInt64{Int64: 500, Valid: true},
+					Direction:             sql.NullString{String: db.HistoryOut, Valid: true},
+					CounterpartyAccountID: sql.NullInt64{Int64: counterparty.ID, Valid: true},
+					LimitCount:            6,
+				})).Times(1).Return(rows[:1], nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got listAccountHistoryResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Entries, 1)
+				require.Equal(t, db.HistoryOut, got.Entries[0].Direction)
+				require.Equal(t, "-2.50", got.Entries[0].AmountFormatted)
+				require.Equal(t, counterparty.ID, got.Entries[0].CounterpartyAccountID)
+				require.Empty(t, got.NextPageToken)
+			},
+		},
+		{
+			name:     "NextPage",
+			username: user1.Username,
+			query:    "page_size=5&page_token=" + util.EncodePageToken(13),
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListAccountHistory(gomock.Any(), gomock.Eq(db.ListAccountHistoryParams{
+					AccountID:  account.ID,
+					BeforeID:   sql.NullInt64{Int64: 13, Valid: true},
+					LimitCount: 6,
+				})).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got listAccountHistoryResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Entries, 2)
+				require.Equal(t, db.HistoryIn, got.Entries[1].Direction)
+				require.Zero(t, got.Entries[1].CounterpartyAccountID)
+			},
+		},
+		{
+			name:     "ClientReference",
+			username: user1.Username,
+			query:    "page_size=5&client_reference=INV-0042",
+			buildStubs: func(store *mock.MockStore) {
+				row := rows[0]
+				row.Memo = "rent for March"
+				row.ClientReference = sql.NullString{String: "INV-0042", Valid: true}
+				row.Metadata = json.RawMessage(`{"order_id":"A1"}`)
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListAccountHistory(gomock.Any(), gomock.Eq(db.ListAccountHistoryParams{
+					AccountID:       account.ID,
+					ClientReference: sql.NullString{String: "INV-0042", Valid: true},
+					LimitCount:      6,
+				})).Times(1).Return([]db.ListAccountHistoryRow{row}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got listAccountHistoryResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Entries, 1)
+				require.Equal(t, "rent for March", got.Entries[0].Memo)
+				require.Equal(t, "INV-0042", got.Entries[0].ClientReference)
+				require.JSONEq(t, `{"order_id":"A1"}`, string(got.Entries[0].Metadata))
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: user2.Username,
+			query:    "page_size=5",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListAccountHistory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidDirection",
+			username: user1.Username,
+			query:    "page_size=5&direction=sideways",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ListAccountHistory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "ToBeforeFrom",
+			username: user1.Username,
+			query:    "page_size=5&from=2024-02-01&to=2024-01-01",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ListAccountHistory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "MaxBelowMin",
+			username: user1.Username,
+			query:    "page_size=5&min_amount=500&max_amount=100",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ListAccountHistory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/history?%s", account.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"bank/token"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+const (
+	// defaultHoldDuration is how long a hold lasts when the request has no expires_at
+	defaultHoldDuration = 7 * 24 * time.Hour
+	maxHoldDuration     = 30 * 24 * time.Hour
+)
+
+type authorizeHoldRequest struct {
+	AccountID   int64      `json:"account_id" binding:"required,min=1"`
+	ToAccountID int64      `json:"to_account_id" binding:"required,min=1,nefield=AccountID"`
+	Amount      int64      `json:"amount" binding:"required,gt=0"`
+	Currency    string     `json:"currency" binding:"required,currency"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+// authorizeHold reserves funds on an account of the authenticated user for a later capture by the to account
+func (server *Server) authorizeHold(ctx *gin.Context) {
+	var req authorizeHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(defaultHoldDuration)
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+	if !expiresAt.After(now) || expiresAt.After(now.Add(maxHoldDuration)) {
+		err := fmt.Errorf("expires_at must be in the next %s", maxHoldDuration)
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validAccount(ctx, req.AccountID, req.Currency)
+	if !valid || !server.validAccountRole(ctx, account, db.AccountRoleCanTransfer) {
+		return
+	}
+	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+	if !valid {
+		return
+	}
+	// a capture is never more than the hold, so checking the hold covers its captures
+	if !server.withinApprovalThreshold(ctx, req.Amount, account.Currency, "hold") {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	idempotency, valid := idempotencyParams(ctx, authPayload.Username)
+	if !valid {
+		return
+	}
+
+	arg := db.AuthorizeHoldTxParams{
+		AccountID:   req.AccountID,
+		ToAccountID: req.ToAccountID,
+		Amount:      req.Amount,
+		ExpiresAt:   expiresAt,
+		Idempotency: idempotency,
+		InitiatedBy: authPayload.Username,
+	}
+	result, err := server.store.AuthorizeHoldTx(ctx, arg)
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	if result.Replayed {
+		setReplayedHeader(ctx)
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+type holdRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getHold(ctx *gin.Context) {
+	var req holdRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	hold, valid := server.validHold(ctx, req.ID, true)
+	if !valid {
+		return
+	}
+	ctx.JSON(http.StatusOK, hold)
+}
+
+type captureHoldRequest struct {
+	// Amount is optional, the whole hold is captured when it is not set
+	Amount int64 `json:"amount" binding:"omitempty,gt=0"`
+}
+
+// captureHold moves the held funds, only the owner of the to account can capture a hold
+func (server *Server) captureHold(ctx *gin.Context) {
+	var uri holdRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req captureHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	hold, valid := server.validHold(ctx, uri.ID, false)
+	if !valid {
+		return
+	}
+	if req.Amount == 0 {
+		req.Amount = hold.Amount
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	idempotency, valid := idempotencyParams(ctx, authPayload.Username)
+	if !valid {
+		return
+	}
+
+	arg := db.CaptureHoldTxParams{
+		HoldID:      hold.ID,
+		Amount:      req.Amount,
+		Idempotency: idempotency,
+	}
+	result, err := server.store.CaptureHoldTx(ctx, arg)
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	if result.Replayed {
+		setReplayedHeader(ctx)
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// voidHold releases the held funds, either side of the hold can void it
+func (server *Server) voidHold(ctx *gin.Context) {
+	var req holdRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	hold, valid := server.validHold(ctx, req.ID, true)
+	if !valid {
+		return
+	}
+
+	hold, err := server.store.VoidHoldTx(ctx, hold.ID)
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, hold)
+}
+
+// validHold loads a hold and makes sure the authenticated user can move money from its to account,
+// or from its held account as well when allowHolder is set
+func (server *Server) validHold(ctx *gin.Context, id int64, allowHolder bool) (db.Hold, bool) {
+	hold, err := server.store.GetHold(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return hold, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return hold, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	accountIDs := []int64{hold.ToAccountID}
+	if allowHolder {
+		accountIDs = append(accountIDs, hold.AccountID)
+	}
+	for _, accountID := range accountIDs {
+		account, err := server.store.GetAccount(ctx, accountID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+			return hold, false
+		}
+		role, err := db.AccountRole(ctx, server.store, account, authPayload.Username)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+			return hold, false
+		}
+		if db.AccountRoleGrants(role, db.AccountRoleCanTransfer) {
+			return hold, true
+		}
+	}
+
+	err = errors.New("hold doesn't belong to the authenticated user")
+	ctx.JSON(http.StatusUnauthorized, errResponse(err))
+	return hold, false
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// idempotencyParams reads the optional Idempotency-Key header, it writes a bad request response when the key is invalid
+func idempotencyParams(ctx *gin.Context, username string) (*db.IdempotencyParams, bool) {
+	key := ctx.GetHeader(idempotencyKeyHeader)
+	if key == "" {
+		return nil, true
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		err := fmt.Errorf("%s header must be at most %d characters", idempotencyKeyHeader, maxIdempotencyKeyLength)
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return nil, false
+	}
+
+	return &db.IdempotencyParams{
+		Username: username,
+		Key:      key,
+	}, true
+}
+
+// setReplayedHeader tells the client the response was saved by an earlier request with the same idempotency key
+func setReplayedHeader(ctx *gin.Context) {
+	ctx.Header(idempotentReplayedHeader, "true")
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type listInterestProductRequest struct {
+	Currency string `form:"currency" binding:"required,currency"`
+}
+
+func (server *Server) listInterestProduct(ctx *gin.Context) {
+	var req listInterestProductRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	products, err := server.store.ListInterestProducts(ctx, req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, products)
+}
+
+type setInterestProductRequest struct {
+	// InterestProductID is left out to stop the account earning interest
+	InterestProductID *int64 `json:"interest_product_id" binding:"omitempty,min=1"`
+}
+
+func (server *Server) setAccountInterestProduct(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req setInterestProductRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleOwner)
+	if !valid {
+		return
+	}
+
+	arg := db.UpdateAccountInterestProductParams{
+		ID: account.ID,
+	}
+	if req.InterestProductID != nil {
+		product, err := server.store.GetInterestProduct(ctx, *req.InterestProductID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+			return
+		}
+		if product.Currency != account.Currency {
+			err = fmt.Errorf("interest product [%d] currency mismatch: %s vs %s", product.ID, product.Currency, account.Currency)
+			ctx.JSON(http.StatusBadRequest, errResponse(err))
+			return
+		}
+		arg.InterestProductID = sql.NullInt64{Int64: product.ID, Valid: true}
+	}
+
+	account, err := server.store.UpdateAccountInterestProduct(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, account)
+}
+
+type listInterestPostingRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listInterestPosting(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req listInterestPostingRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleViewOnly)
+	if !valid {
+		return
+	}
+
+	postings, err := server.store.ListInterestPostings(ctx, db.ListInterestPostingsParams{
+		AccountID: account.ID,
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, postings)
+}
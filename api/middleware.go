@@ -1,12 +1,16 @@
 package api
 
 import (
+	db "bank/db/sqlc"
 	"bank/token"
+	"database/sql"
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"net/http"
 	"strings"
+	"time"
 )
 
 const (
@@ -15,7 +19,8 @@ const (
 	authorizationPayloadKey = "authorization_payload"
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+// authMiddleware accepts the requests with a valid access token whose session hasn't been revoked
+func authMiddleware(tokenMaker token.Maker, store db.Store) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 		if len(authorizationHeader) == 0 {
@@ -45,6 +50,28 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(err))
 			return
 		}
+		if payload.Type == token.TokenTypeRefresh {
+			err = errors.New("a refresh token can't authorize a request")
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(err))
+			return
+		}
+
+		// the tokens issued before sessions were tracked have no session and stay valid until they expire
+		if payload.SessionID != uuid.Nil {
+			session, err := store.GetSession(ctx, payload.SessionID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(errors.New("session not found")))
+					return
+				}
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, errResponse(err))
+				return
+			}
+			if err = session.Check(payload.Username, time.Now()); err != nil {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(err))
+				return
+			}
+		}
 
 		ctx.Set(authorizationPayloadKey, payload)
 		ctx.Next()
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"bank/util"
+)
+
+// pageRequest is the cursor pagination of list endpoints, the page token comes from the next_page_token
+// of the previous page and is empty for the first page
+type pageRequest struct {
+	PageToken string `form:"page_token"`
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=10"` //validate
+}
+
+// afterID decodes the page token into the id the page starts after
+func (req pageRequest) afterID() (int64, error) {
+	return util.DecodePageToken(req.PageToken)
+}
+
+// limit fetches one row more than the page size, for util.NextPage to know whether there is a next page
+func (req pageRequest) limit() int32 {
+	return req.PageSize + 1
+}
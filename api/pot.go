@@ -0,0 +1,329 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"bank/token"
+	"bank/util"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// potDateLayout is the layout of the target date of a pot
+const potDateLayout = "2006-01-02"
+
+type potResponse struct {
+	// AccountID is the id of the pot, its history and balance are read like those of any account
+	AccountID        int64  `json:"account_id"`
+	ParentAccountID  int64  `json:"parent_account_id"`
+	Name             string `json:"name"`
+	Currency         string `json:"currency"`
+	Status           string `json:"status"`
+	Balance          int64  `json:"balance"`
+	BalanceFormatted string `json:"balance_formatted"`
+	GoalAmount       int64  `json:"goal_amount,omitempty"`
+	TargetDate       string `json:"target_date,omitempty"`
+	RoundUpTo        int64  `json:"round_up_to,omitempty"`
+	// Progress is empty when the pot has no goal
+	Progress  *db.PotProgress `json:"progress,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func newPotResponse(parentAccountID int64, pot db.ListPotsRow) potResponse {
+	res := potResponse{
+		AccountID:        pot.AccountID,
+		ParentAccountID:  parentAccountID,
+		Name:             pot.Name,
+		Currency:         pot.Currency,
+		Status:           pot.Status,
+		Balance:          pot.Balance,
+		BalanceFormatted: util.FormatCurrencyAmount(pot.Balance, pot.Currency),
+		GoalAmount:       pot.GoalAmount.Int64,
+		RoundUpTo:        pot.RoundUpTo.Int64,
+		Progress:         pot.Progress(time.Now()),
+		CreatedAt:        pot.CreatedAt,
+	}
+	if pot.TargetDate.Valid {
+		res.TargetDate = pot.TargetDate.Time.Format(potDateLayout)
+	}
+	return res
+}
+
+// newPotRow puts a pot and its account together the way ListPots returns them
+func newPotRow(pot db.Pot, account db.Account) db.ListPotsRow {
+	return db.ListPotsRow{
+		AccountID:  pot.AccountID,
+		Name:       pot.Name,
+		GoalAmount: pot.GoalAmount,
+		TargetDate: pot.TargetDate,
+		RoundUpTo:  pot.RoundUpTo,
+		CreatedAt:  pot.CreatedAt,
+		Balance:    account.Balance,
+		Currency:   account.Currency,
+		Status:     account.Status,
+	}
+}
+
+type createPotRequest struct {
+	Name       string `json:"name" binding:"required,max=64"`
+	GoalAmount int64  `json:"goal_amount" binding:"omitempty,gt=0"`
+	TargetDate string `json:"target_date" binding:"omitempty,datetime=2006-01-02"`
+	// RoundUpTo rounds every payment from the parent account up to a multiple of it, in minor units,
+	// and moves the difference into the pot. Only one pot of an account can round up.
+	RoundUpTo int64 `json:"round_up_to" binding:"omitempty,gt=0,max=1000000"`
+}
+
+// createPot opens a savings pot under an account of the authenticated user, only its owners can do it
+func (server *Server) createPot(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req createPotRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	targetDate, err := parsePotTargetDate(req.TargetDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleOwner)
+	if !valid {
+		return
+	}
+
+	result, err := server.store.CreatePotTx(ctx, db.CreatePotTxParams{
+		ParentAccountID: account.ID,
+		Name:            req.Name,
+		GoalAmount:      sql.NullInt64{Int64: req.GoalAmount, Valid: req.GoalAmount > 0},
+		TargetDate:      targetDate,
+		RoundUpTo:       sql.NullInt64{Int64: req.RoundUpTo, Valid: req.RoundUpTo > 0},
+	})
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newPotResponse(account.ID, newPotRow(result.Pot, result.Account)))
+}
+
+// listPot shows the open pots of an account with their progress towards their goals
+func (server *Server) listPot(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleViewOnly)
+	if !valid {
+		return
+	}
+
+	pots, err := server.store.ListPots(ctx, sql.NullInt64{Int64: account.ID, Valid: true})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	res := make([]potResponse, len(pots))
+	for i, pot := range pots {
+		res[i] = newPotResponse(account.ID, pot)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+type updatePotRequest struct {
+	Name *string `json:"name" binding:"omitempty,min=1,max=64"`
+	// GoalAmount 0 removes the goal
+	GoalAmount *int64 `json:"goal_amount" binding:"omitempty,min=0"`
+	// TargetDate "" removes the target date
+	TargetDate *string `json:"target_date" binding:"omitempty,datetime=2006-01-02"`
+	// RoundUpTo 0 stops rounding up payments into the pot
+	RoundUpTo *int64 `json:"round_up_to" binding:"omitempty,min=0,max=1000000"`
+}
+
+// updatePot changes the name, goal or round up rule of a pot, only the owners of its account can do it
+func (server *Server) updatePot(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req updatePotRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validPot(ctx, uri.ID, db.AccountRoleOwner)
+	if !valid {
+		return
+	}
+	pot, err := server.store.GetPot(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	arg := db.UpdatePotTxParams{
+		AccountID:  pot.AccountID,
+		Name:       pot.Name,
+		GoalAmount: pot.GoalAmount,
+		TargetDate: pot.TargetDate,
+		RoundUpTo:  pot.RoundUpTo,
+	}
+	if req.Name != nil {
+		arg.Name = *req.Name
+	}
+	if req.GoalAmount != nil {
+		arg.GoalAmount = sql.NullInt64{Int64: *req.GoalAmount, Valid: *req.GoalAmount > 0}
+	}
+	if req.TargetDate != nil {
+		arg.TargetDate, err = parsePotTargetDate(*req.TargetDate)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errResponse(err))
+			return
+		}
+	}
+	if req.RoundUpTo != nil {
+		arg.RoundUpTo = sql.NullInt64{Int64: *req.RoundUpTo, Valid: *req.RoundUpTo > 0}
+	}
+
+	pot, err = server.store.UpdatePotTx(ctx, arg)
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newPotResponse(account.ParentAccountID.Int64, newPotRow(pot, account)))
+}
+
+type movePotRequest struct {
+	Amount int64 `json:"amount" binding:"required,gt=0"`
+}
+
+// depositPot moves money from the parent account into a pot
+func (server *Server) depositPot(ctx *gin.Context) {
+	server.movePot(ctx, false)
+}
+
+// withdrawPot moves money from a pot back to its parent account
+func (server *Server) withdrawPot(ctx *gin.Context) {
+	server.movePot(ctx, true)
+}
+
+func (server *Server) movePot(ctx *gin.Context, withdraw bool) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req movePotRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validPot(ctx, uri.ID, db.AccountRoleCanTransfer)
+	if !valid {
+		return
+	}
+
+	if server.config.RequiresApproval(req.Amount, account.Currency) {
+		fromAccountID, toAccountID := account.ParentAccountID.Int64, account.ID
+		if withdraw {
+			fromAccountID, toAccountID = toAccountID, fromAccountID
+		}
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		result, err := server.store.CreateTransferRequestTx(ctx, db.CreateTransferRequestTxParams{
+			FromAccountID: fromAccountID,
+			ToAccountID:   toAccountID,
+			Amount:        req.Amount,
+			RequestedBy:   authPayload.Username,
+			ExpiresAt:     time.Now().Add(server.config.TransferApprovalTTL),
+		})
+		if err != nil {
+			transferErrResponse(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusAccepted, result)
+		return
+	}
+
+	result, err := server.store.MovePotTx(ctx, db.MovePotTxParams{
+		PotAccountID: account.ID,
+		Amount:       req.Amount,
+		Withdraw:     withdraw,
+	})
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// closePot moves what is left in a pot back to its parent account and closes it, only the owners can do it
+func (server *Server) closePot(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validPot(ctx, uri.ID, db.AccountRoleOwner)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.CloseAccountTx(ctx, db.CloseAccountTxParams{
+		AccountID:      account.ID,
+		SweepAccountID: account.ParentAccountID.Int64,
+		Username:       authPayload.Username,
+	})
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// validPot loads the account of a pot and makes sure the authenticated user has at least the given role on its parent
+func (server *Server) validPot(ctx *gin.Context, id int64, role string) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return account, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return account, false
+	}
+	if !account.ParentAccountID.Valid {
+		err = fmt.Errorf("account [%d] is not a pot", account.ID)
+		ctx.JSON(http.StatusNotFound, errResponse(err))
+		return account, false
+	}
+
+	return account, server.validAccountRole(ctx, account, role)
+}
+
+// parsePotTargetDate reads a target date, which must be in the future, an empty date is no target date
+func parsePotTargetDate(value string) (sql.NullTime, error) {
+	if value == "" {
+		return sql.NullTime{}, nil
+	}
+	date, err := time.Parse(potDateLayout, value)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+	if !date.After(time.Now()) {
+		return sql.NullTime{}, errors.New("target_date must be in the future")
+	}
+	return sql.NullTime{Time: date, Valid: true}, nil
+}
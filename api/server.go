@@ -20,7 +20,7 @@ type Server struct {
 }
 
 func NewServer(config util.Config, store db.Store) (*Server, error) {
-	tokenMaker, err := token.NewJWTMaker(config.TokenSymmetricKey)
+	tokenMaker, err := token.NewMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("can not create token maker: %w", err)
 	}
@@ -31,6 +31,8 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 	}
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		_ = v.RegisterValidation("currency", validCurrency)
+		_ = v.RegisterValidation("schedule", validSchedule)
+		_ = v.RegisterValidation("json_object", validJSONObject)
 	}
 	server.setupRouter()
 
@@ -48,14 +50,76 @@ func (server *Server) setupRouter() {
 	router.POST("/users", server.createUser)
 	router.POST("/users/login", server.loginUser)
 	router.POST("/tokens/renew_access", server.reNewAccessToken)
+	router.GET("/.well-known/jwks.json", server.getJWKS)
 
-	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker, server.store))
+
+	authRoutes.POST("/users/logout", server.logoutUser)
+	authRoutes.GET("/sessions", server.listSession)
+	authRoutes.DELETE("/sessions/:id", server.revokeSession)
+	authRoutes.POST("/sessions/revoke_others", server.revokeOtherSession)
 
 	authRoutes.POST("/accounts", server.createAccount)
 	authRoutes.GET("/accounts/:id", server.getAccount)
 	authRoutes.GET("/accounts", server.listAccount)
+	authRoutes.GET("/accounts/:id/balance", server.getAccountBalance)
+	authRoutes.GET("/accounts/:id/balance_as_of", server.getBalanceAsOf)
+	authRoutes.GET("/accounts/:id/daily_balances", server.listDailyBalance)
+	authRoutes.PATCH("/accounts/:id/status", server.updateAccountStatus)
+	authRoutes.POST("/accounts/:id/close", server.closeAccount)
+	authRoutes.GET("/accounts/:id/entries", server.listEntry)
+	authRoutes.GET("/accounts/:id/transfers", server.listTransfer)
+	authRoutes.GET("/accounts/:id/history", server.listAccountHistory)
+	authRoutes.GET("/accounts/:id/transfer_requests", server.listAccountTransferRequest)
+	authRoutes.GET("/accounts/:id/statement", server.getAccountStatement)
+	authRoutes.PUT("/accounts/:id/interest_product", server.setAccountInterestProduct)
+	authRoutes.GET("/accounts/:id/interest_postings", server.listInterestPosting)
+	authRoutes.GET("/interest_products", server.listInterestProduct)
+
+	authRoutes.GET("/accounts/:id/members", server.listAccountMember)
+	authRoutes.POST("/accounts/:id/members", server.inviteAccountMember)
+	authRoutes.POST("/accounts/:id/members/accept", server.acceptAccountMember)
+	authRoutes.DELETE("/accounts/:id/members/:username", server.removeAccountMember)
+	authRoutes.GET("/account_invitations", server.listAccountInvitation)
+
+	authRoutes.GET("/accounts/:id/pots", server.listPot)
+	authRoutes.POST("/accounts/:id/pots", server.createPot)
+	authRoutes.PATCH("/pots/:id", server.updatePot)
+	authRoutes.POST("/pots/:id/deposit", server.depositPot)
+	authRoutes.POST("/pots/:id/withdraw", server.withdrawPot)
+	authRoutes.POST("/pots/:id/close", server.closePot)
+
+	authRoutes.GET("/currencies", server.listCurrency)
+	authRoutes.POST("/currencies", server.createCurrency)
+	authRoutes.PATCH("/currencies/:code", server.updateCurrency)
+
+	authRoutes.GET("/transfer_limits", server.listTransferLimit)
+	authRoutes.POST("/transfer_limits", server.createTransferLimit)
+	authRoutes.PUT("/transfer_limits/:id", server.updateTransferLimit)
+	authRoutes.DELETE("/transfer_limits/:id", server.deleteTransferLimit)
+
+	authRoutes.GET("/tx_stats", server.getTxStats)
 
 	authRoutes.POST("/transfers", server.createTransfer)
+	authRoutes.POST("/transfers/:id/reverse", server.reverseTransfer)
+	authRoutes.POST("/multi_transfers", server.createMultiTransfer)
+
+	authRoutes.GET("/transfer_requests", server.listTransferRequest)
+	authRoutes.GET("/transfer_requests/:id", server.getTransferRequest)
+	authRoutes.POST("/transfer_requests/:id/approve", server.approveTransferRequest)
+	authRoutes.POST("/transfer_requests/:id/reject", server.rejectTransferRequest)
+
+	authRoutes.POST("/holds", server.authorizeHold)
+	authRoutes.GET("/holds/:id", server.getHold)
+	authRoutes.POST("/holds/:id/capture", server.captureHold)
+	authRoutes.POST("/holds/:id/void", server.voidHold)
+
+	authRoutes.POST("/standing_orders", server.createStandingOrder)
+	authRoutes.GET("/standing_orders/:id", server.getStandingOrder)
+	authRoutes.GET("/standing_orders", server.listStandingOrder)
+	authRoutes.PATCH("/standing_orders/:id", server.updateStandingOrder)
+	authRoutes.DELETE("/standing_orders/:id", server.cancelStandingOrder)
+	authRoutes.GET("/standing_orders/:id/runs", server.listStandingOrderRun)
 
 	server.router = router
 }
@@ -0,0 +1,119 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"bank/token"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// sessionResponse leaves out the refresh token of the session
+type sessionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	ClientIP  string    `json:"client_ip"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiredAt time.Time `json:"expired_at"`
+	// Current is set on the session the request was made with
+	Current bool `json:"current"`
+}
+
+func newSessionResponse(session db.Session, currentID uuid.UUID) sessionResponse {
+	return sessionResponse{
+		ID:        session.ID,
+		UserAgent: session.UserAgent,
+		ClientIP:  session.ClientIp,
+		CreatedAt: session.CreatedAt,
+		ExpiredAt: session.ExpiredAt,
+		Current:   session.ID == currentID,
+	}
+}
+
+// listSession shows the sessions of the authenticated user that are neither revoked nor expired, newest first
+func (server *Server) listSession(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	sessions, err := server.store.ListActiveSessions(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	res := make([]sessionResponse, len(sessions))
+	for i, session := range sessions {
+		res[i] = newSessionResponse(session, authPayload.SessionID)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+type revokeSessionRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// revokeSession blocks a session of the authenticated user, its access and refresh tokens stop working at once
+func (server *Server) revokeSession(ctx *gin.Context) {
+	var req revokeSessionRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.blockSession(ctx, uuid.MustParse(req.ID), authPayload)
+}
+
+// logoutUser revokes the session the request was made with
+func (server *Server) logoutUser(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.SessionID == uuid.Nil {
+		err := errors.New("the access token doesn't belong to a session, log in again to get one that does")
+		ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+		return
+	}
+
+	server.blockSession(ctx, authPayload.SessionID, authPayload)
+}
+
+func (server *Server) blockSession(ctx *gin.Context, sessionID uuid.UUID, authPayload *token.Payload) {
+	session, err := server.store.BlockSession(ctx, db.BlockSessionParams{
+		ID:       sessionID,
+		Username: authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("session %s not found", sessionID)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newSessionResponse(session, authPayload.SessionID))
+}
+
+type revokeOtherSessionResponse struct {
+	Revoked []sessionResponse `json:"revoked"`
+}
+
+// revokeOtherSession blocks every active session of the authenticated user but the one the request was made with
+func (server *Server) revokeOtherSession(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	sessions, err := server.store.BlockOtherSessions(ctx, db.BlockOtherSessionsParams{
+		Username: authPayload.Username,
+		KeepID:   authPayload.SessionID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	res := revokeOtherSessionResponse{Revoked: make([]sessionResponse, len(sessions))}
+	for i, session := range sessions {
+		res.Revoked[i] = newSessionResponse(session, authPayload.SessionID)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
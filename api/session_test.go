@@ -0,0 +1,259 @@
+package api
+
+import (
+	mock "bank/db/mock"
+	db "bank/db/sqlc"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListSessionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	current := randomSession(user.Username)
+	other := randomSession(user.Username)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mock.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ListActiveSessions(gomock.Any(), gomock.Eq(user.Username)).Times(1).
+					Return([]db.Session{other, current}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []sessionResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, 2)
+				require.Equal(t, other.ID, got[0].ID)
+				require.False(t, got[0].Current)
+				require.Equal(t, current.ID, got[1].ID)
+				require.True(t, got[1].Current)
+				require.NotContains(t, recorder.Body.String(), current.RefreshToken)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ListActiveSessions(gomock.Any(), gomock.Any()).Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			store.EXPECT().GetSession(gomock.Any(), gomock.Eq(current.ID)).AnyTimes().Return(current, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/sessions", nil)
+			require.NoError(t, err)
+
+			addSessionAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, current.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRevokeSessionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	current := randomSession(user.Username)
+	other := randomSession(user.Username)
+
+	testCases := []struct {
+		name          string
+		sessionID     string
+		buildStubs    func(store *mock.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			sessionID: other.ID.String(),
+			buildStubs: func(store *mock.MockStore) {
+				arg := db.BlockSessionParams{ID: other.ID, Username: user.Username}
+				revoked := other
+				revoked.IsBlocked = true
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Eq(arg)).Times(1).Return(revoked, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got sessionResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, other.ID, got.ID)
+				require.False(t, got.Current)
+			},
+		},
+		{
+			name:      "NotFound",
+			sessionID: uuid.New().String(),
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Any()).Times(1).Return(db.Session{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidID",
+			sessionID: "current",
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			store.EXPECT().GetSession(gomock.Any(), gomock.Eq(current.ID)).AnyTimes().Return(current, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/sessions/%s", tc.sessionID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addSessionAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, current.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestLogoutUserAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	current := randomSession(user.Username)
+
+	testCases := []struct {
+		name          string
+		sessionID     uuid.UUID
+		buildStubs    func(store *mock.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			sessionID: current.ID,
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(current.ID)).Times(1).Return(current, nil)
+				arg := db.BlockSessionParams{ID: current.ID, Username: user.Username}
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Eq(arg)).Times(1).Return(current, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got sessionResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, current.ID, got.ID)
+				require.True(t, got.Current)
+			},
+		},
+		{
+			name:      "AlreadyLoggedOut",
+			sessionID: current.ID,
+			buildStubs: func(store *mock.MockStore) {
+				revoked := current
+				revoked.IsBlocked = true
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(current.ID)).Times(1).Return(revoked, nil)
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "NoSession",
+			sessionID: uuid.Nil,
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/users/logout", nil)
+			require.NoError(t, err)
+
+			addSessionAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, tc.sessionID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRevokeOtherSessionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	current := randomSession(user.Username)
+	other := randomSession(user.Username)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock.NewMockStore(ctrl)
+	store.EXPECT().GetSession(gomock.Any(), gomock.Eq(current.ID)).Times(1).Return(current, nil)
+	arg := db.BlockOtherSessionsParams{Username: user.Username, KeepID: current.ID}
+	store.EXPECT().BlockOtherSessions(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Session{other}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodPost, "/sessions/revoke_others", nil)
+	require.NoError(t, err)
+
+	addSessionAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, current.ID, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got revokeOtherSessionResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Len(t, got.Revoked, 1)
+	require.Equal(t, other.ID, got.Revoked[0].ID)
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"bank/token"
+	"bank/util"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// defaultStandingOrderMaxRetries is used when a retry policy is set without max_retries
+const defaultStandingOrderMaxRetries = 3
+
+type createStandingOrderRequest struct {
+	FromAccountID  int64      `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID    int64      `json:"to_account_id" binding:"required,min=1,nefield=FromAccountID"`
+	Amount         int64      `json:"amount" binding:"required,gt=0"`
+	Currency       string     `json:"currency" binding:"required,currency"`
+	Schedule       string     `json:"schedule" binding:"required,schedule"`
+	CronExpression string     `json:"cron_expression" binding:"required_if=Schedule cron"`
+	StartAt        time.Time  `json:"start_at" binding:"required"`
+	EndAt          *time.Time `json:"end_at"`
+	FailurePolicy  string     `json:"failure_policy" binding:"omitempty,oneof=retry skip"`
+	MaxRetries     *int32     `json:"max_retries" binding:"omitempty,min=0,max=10"`
+}
+
+func (server *Server) createStandingOrder(ctx *gin.Context) {
+	var req createStandingOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if req.StartAt.Before(time.Now()) {
+		err := errors.New("start_at must be in the future")
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	if req.Schedule != util.ScheduleCron {
+		req.CronExpression = ""
+	} else if err := util.ValidateCronExpression(req.CronExpression); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(fmt.Errorf("invalid cron expression: %w", err)))
+		return
+	}
+	nextRunAt, err := util.FirstRunAt(req.Schedule, req.CronExpression, req.StartAt)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	if req.EndAt != nil && req.EndAt.Before(nextRunAt) {
+		err = errors.New("end_at is before the first run")
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
+	if !valid || !server.validAccountRole(ctx, fromAccount, db.AccountRoleCanTransfer) {
+		return
+	}
+	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+	if !valid {
+		return
+	}
+	// the runs are made by the scheduler, nobody is there to wait for an approval
+	if !server.withinApprovalThreshold(ctx, req.Amount, fromAccount.Currency, "standing order") {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateStandingOrderParams{
+		Owner:          authPayload.Username,
+		FromAccountID:  req.FromAccountID,
+		ToAccountID:    req.ToAccountID,
+		Amount:         req.Amount,
+		Schedule:       req.Schedule,
+		CronExpression: req.CronExpression,
+		StartAt:        req.StartAt,
+		NextRunAt:      nextRunAt,
+		FailurePolicy:  db.StandingOrderRetry,
+		MaxRetries:     defaultStandingOrderMaxRetries,
+	}
+	if req.EndAt != nil {
+		arg.EndAt = sql.NullTime{Time: *req.EndAt, Valid: true}
+	}
+	if req.FailurePolicy != "" {
+		arg.FailurePolicy = req.FailurePolicy
+	}
+	if req.MaxRetries != nil {
+		arg.MaxRetries = *req.MaxRetries
+	}
+
+	order, err := server.store.CreateStandingOrder(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, order)
+}
+
+type getStandingOrderRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getStandingOrder(ctx *gin.Context) {
+	var req getStandingOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	order, valid := server.validStandingOrder(ctx, req.ID)
+	if !valid {
+		return
+	}
+	ctx.JSON(http.StatusOK, order)
+}
+
+type listStandingOrderRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listStandingOrder(ctx *gin.Context) {
+	var req listStandingOrderRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ListStandingOrdersParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	orders, err := server.store.ListStandingOrders(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, orders)
+}
+
+type updateStandingOrderRequest struct {
+	Amount        *int64  `json:"amount" binding:"omitempty,gt=0"`
+	Status        *string `json:"status" binding:"omitempty,oneof=active paused"`
+	FailurePolicy *string `json:"failure_policy" binding:"omitempty,oneof=retry skip"`
+	MaxRetries    *int32  `json:"max_retries" binding:"omitempty,min=0,max=10"`
+}
+
+func (server *Server) updateStandingOrder(ctx *gin.Context) {
+	var uri getStandingOrderRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req updateStandingOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	order, valid := server.validStandingOrder(ctx, uri.ID)
+	if !valid || !validStandingOrderStatus(ctx, order) {
+		return
+	}
+	// the threshold depends on the currency of the from account, which is only loaded when there is one to check
+	if req.Amount != nil && server.config.TransferApprovalThreshold > 0 {
+		fromAccount, err := server.store.GetAccount(ctx, order.FromAccountID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+			return
+		}
+		if !server.withinApprovalThreshold(ctx, *req.Amount, fromAccount.Currency, "standing order") {
+			return
+		}
+	}
+
+	arg := db.UpdateStandingOrderParams{
+		ID: order.ID,
+	}
+	if req.Amount != nil {
+		arg.Amount = sql.NullInt64{Int64: *req.Amount, Valid: true}
+	}
+	if req.Status != nil {
+		arg.Status = sql.NullString{String: *req.Status, Valid: true}
+	}
+	if req.FailurePolicy != nil {
+		arg.FailurePolicy = sql.NullString{String: *req.FailurePolicy, Valid: true}
+	}
+	if req.MaxRetries != nil {
+		arg.MaxRetries = sql.NullInt32{Int32: *req.MaxRetries, Valid: true}
+	}
+
+	resumed := order.Status == db.StandingOrderPaused && arg.Status.String == db.StandingOrderActive
+	order, err := server.store.UpdateStandingOrder(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	// runs missed while the order was paused are skipped rather than executed all at once
+	if resumed && order.NextRunAt.Before(time.Now()) {
+		advance, err := skipMissedRuns(order, time.Now())
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+			return
+		}
+		order, err = server.store.AdvanceStandingOrder(ctx, advance)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
+func (server *Server) cancelStandingOrder(ctx *gin.Context) {
+	var req getStandingOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	order, valid := server.validStandingOrder(ctx, req.ID)
+	if !valid || !validStandingOrderStatus(ctx, order) {
+		return
+	}
+
+	arg := db.UpdateStandingOrderParams{
+		ID:     order.ID,
+		Status: sql.NullString{String: db.StandingOrderCancelled, Valid: true},
+	}
+	order, err := server.store.UpdateStandingOrder(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, order)
+}
+
+type listStandingOrderRunRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listStandingOrderRun(ctx *gin.Context) {
+	var uri getStandingOrderRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req listStandingOrderRunRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	order, valid := server.validStandingOrder(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	arg := db.ListStandingOrderRunsParams{
+		StandingOrderID: order.ID,
+		Limit:           req.PageSize,
+		Offset:          (req.PageID - 1) * req.PageSize,
+	}
+	runs, err := server.store.ListStandingOrderRuns(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, runs)
+}
+
+// validStandingOrder loads a standing order and makes sure it belongs to the authenticated user
+func (server *Server) validStandingOrder(ctx *gin.Context, id int64) (db.StandingOrder, bool) {
+	order, err := server.store.GetStandingOrder(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return order, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return order, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if order.Owner != authPayload.Username {
+		err = errors.New("standing order doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errResponse(err))
+		return order, false
+	}
+
+	return order, true
+}
+
+// validStandingOrderStatus refuses changes to orders that have already finished
+func validStandingOrderStatus(ctx *gin.Context, order db.StandingOrder) bool {
+	if order.Status == db.StandingOrderCompleted || order.Status == db.StandingOrderCancelled {
+		err := fmt.Errorf("standing order [%d] is %s", order.ID, order.Status)
+		ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+		return false
+	}
+	return true
+}
+
+// skipMissedRuns moves the order to its first run at or after now, completing it when there is none left
+func skipMissedRuns(order db.StandingOrder, now time.Time) (db.AdvanceStandingOrderParams, error) {
+	arg := db.AdvanceStandingOrderParams{
+		ID:        order.ID,
+		NextRunAt: order.NextRunAt,
+		Status:    order.Status,
+	}
+	for arg.NextRunAt.Before(now) {
+		next, ok, err := util.NextRunAt(order.Schedule, order.CronExpression, order.StartAt, arg.NextRunAt)
+		if err != nil {
+			return arg, err
+		}
+		if !ok || (order.EndAt.Valid && next.After(order.EndAt.Time)) {
+			arg.Status = db.StandingOrderCompleted
+			return arg, nil
+		}
+		arg.NextRunAt = next
+	}
+	return arg, nil
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"bank/statement"
+	"bank/util"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// maxStatementDays is the longest period a single statement can cover
+const maxStatementDays = 366
+
+type getStatementRequest struct {
+	// From and To are both inclusive dates
+	From   time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To     time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	Format string    `form:"format" binding:"omitempty,oneof=csv ofx camt053"`
+}
+
+func (server *Server) getAccountStatement(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req getStatementRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	if req.Format == "" {
+		req.Format = statement.FormatCSV
+	}
+
+	to := req.To.AddDate(0, 0, 1)
+	if !to.After(req.From) {
+		err := errors.New("to must not be before from")
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	if to.Sub(req.From) > maxStatementDays*24*time.Hour {
+		err := fmt.Errorf("a statement can't cover more than %d days", maxStatementDays)
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleViewOnly)
+	if !valid {
+		return
+	}
+
+	result, err := server.store.AccountStatementTx(ctx, db.AccountStatementTxParams{
+		AccountID: account.ID,
+		From:      req.From,
+		To:        to,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = statement.Write(&buf, req.Format, newStatement(result, req.From, to)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	contentType, extension := statement.ContentType(req.Format)
+	filename := fmt.Sprintf("statement-%d-%s-%s.%s",
+		account.ID, req.From.Format("20060102"), req.To.Format("20060102"), extension)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+func newStatement(result db.AccountStatementTxResult, from, to time.Time) statement.Statement {
+	stmt := statement.Statement{
+		ID: fmt.Sprintf("%d-%s-%s", result.Account.ID, from.Format("20060102"), to.Format("20060102")),
+		Account: statement.Account{
+			ID:       result.Account.ID,
+			Owner:    result.Account.Owner,
+			Currency: result.Account.Currency,
+		},
+		From:           from,
+		To:             to,
+		Exponent:       util.CurrencyExponent(result.Account.Currency),
+		OpeningBalance: result.OpeningBalance,
+		ClosingBalance: result.ClosingBalance,
+		CreatedAt:      time.Now(),
+	}
+
+	for _, entry := range result.Entries {
+		line := statement.Line{
+			EntryID:    entry.ID,
+			TransferID: entry.TransferID.Int64,
+			Amount:     entry.Amount,
+			BookedAt:   entry.CreatedAt,
+		}
+		if entry.FromAccountID.Valid {
+			line.Counterparty.ID = entry.FromAccountID.Int64
+			if line.Counterparty.ID == entry.AccountID {
+				line.Counterparty.ID = entry.ToAccountID.Int64
+			}
+			line.Counterparty.Owner = entry.CounterpartyOwner.String
+		}
+		stmt.Lines = append(stmt.Lines, line)
+	}
+	return stmt
+}
@@ -1,10 +1,13 @@
 package api
 
 import (
+	db "bank/db/sqlc"
+	"bank/token"
 	"database/sql"
 	"errors"
-	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 	"net/http"
 	"time"
 )
@@ -16,8 +19,14 @@ type reNewAccessTokenRequest struct {
 type reNewAccessTokenResponse struct {
 	AccessToken          string    `json:"access_token"`
 	AccessTokenExpiredAt time.Time `json:"access_token_expired_at"`
+	// RefreshToken replaces the one of the request, which can't be used again
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiredAt time.Time `json:"refresh_token_expired_at"`
 }
 
+// reNewAccessToken rotates the refresh token of a session: it returns a new access token and a new refresh token
+// and retires the one of the request. A retired refresh token that comes back was stolen or leaked,
+// so the whole session is blocked, the tokens issued to the thief and to the user alike.
 func (server *Server) reNewAccessToken(ctx *gin.Context) {
 
 	var req reNewAccessTokenRequest
@@ -31,7 +40,17 @@ func (server *Server) reNewAccessToken(ctx *gin.Context) {
 		ctx.JSON(http.StatusUnauthorized, errResponse(err))
 		return
 	}
-	session, err := server.store.GetSession(ctx, refreshPayload.ID)
+	if refreshPayload.Type == token.TokenTypeAccess {
+		err = errors.New("an access token can't renew a session")
+		ctx.JSON(http.StatusUnauthorized, errResponse(err))
+		return
+	}
+	// refresh tokens issued before access tokens carried a session were the id of their session
+	sessionID := refreshPayload.SessionID
+	if sessionID == uuid.Nil {
+		sessionID = refreshPayload.ID
+	}
+	session, err := server.store.GetSession(ctx, sessionID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			ctx.JSON(http.StatusNotFound, errResponse(err))
@@ -40,36 +59,92 @@ func (server *Server) reNewAccessToken(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errResponse(err))
 		return
 	}
-	if session.IsBlocked {
-		err = fmt.Errorf("session is blocked")
+	if err = session.Check(refreshPayload.Username, time.Now()); err != nil {
 		ctx.JSON(http.StatusUnauthorized, errResponse(err))
 		return
 	}
-	if session.Username != refreshPayload.Username {
-		err = fmt.Errorf("incorrect session user")
+	if session.RefreshToken != req.RefreshToken {
+		// every refresh token of the session but the current one has been retired by a rotation,
+		// the untyped tokens from before rotation are just refused
+		if refreshPayload.Type == token.TokenTypeRefresh {
+			server.blockReusedSession(ctx, session)
+			return
+		}
+		err = errors.New("incorrect session token")
 		ctx.JSON(http.StatusUnauthorized, errResponse(err))
 		return
 	}
-	if session.RefreshToken != req.RefreshToken {
-		err = fmt.Errorf("incorrect session token")
-		ctx.JSON(http.StatusUnauthorized, errResponse(err))
+
+	// the new refresh token expires with the session, rotating doesn't make a session last longer than a login
+	newRefreshToken, newRefreshPayload, err := server.tokenMaker.CreateToken(session.Username, session.ID, token.TokenTypeRefresh, time.Until(session.ExpiredAt))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
 		return
 	}
-	if time.Now().After(session.ExpiredAt) {
-		err = fmt.Errorf("expired session")
-		ctx.JSON(http.StatusUnauthorized, errResponse(err))
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(session.Username, session.ID, token.TokenTypeAccess, server.config.AccessTokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
 		return
 	}
 
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(refreshPayload.Username, server.config.AccessTokenDuration)
+	// the update only matches while the token of the request is still current,
+	// so of two renewals racing with the same token only one can win
+	_, err = server.store.RotateSessionRefreshToken(ctx, db.RotateSessionRefreshTokenParams{
+		NewRefreshToken: newRefreshToken,
+		ID:              session.ID,
+		OldRefreshToken: req.RefreshToken,
+	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			server.blockReusedSession(ctx, session)
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errResponse(err))
 		return
 	}
 
 	res := reNewAccessTokenResponse{
-		AccessToken:          accessToken,
-		AccessTokenExpiredAt: accessPayload.ExpiredAt,
+		AccessToken:           accessToken,
+		AccessTokenExpiredAt:  accessPayload.ExpiredAt,
+		RefreshToken:          newRefreshToken,
+		RefreshTokenExpiredAt: newRefreshPayload.ExpiredAt,
 	}
 	ctx.JSON(http.StatusOK, res)
 }
+
+// blockReusedSession answers a renewal made with a retired refresh token of the session by blocking the session
+func (server *Server) blockReusedSession(ctx *gin.Context, session db.Session) {
+	log.Warn().
+		Str("session_id", session.ID.String()).
+		Str("username", session.Username).
+		Str("client_ip", ctx.ClientIP()).
+		Str("user_agent", ctx.Request.UserAgent()).
+		Msg("retired refresh token reused, blocking the session")
+
+	_, err := server.store.BlockSession(ctx, db.BlockSessionParams{
+		ID:       session.ID,
+		Username: session.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	err = errors.New("refresh token was already used, the session is blocked")
+	ctx.JSON(http.StatusUnauthorized, errResponse(err))
+}
+
+// jwksCacheControl lets verifiers cache the key set, a rotated key is served next to the new one long before they need it
+const jwksCacheControl = "public, max-age=300"
+
+// getJWKS serves the public keys tokens are signed with, so other services can verify them without calling us.
+// There is nothing to publish when tokens are signed with the symmetric key.
+func (server *Server) getJWKS(ctx *gin.Context) {
+	keySet, ok := server.tokenMaker.(token.KeySet)
+	if !ok {
+		ctx.JSON(http.StatusNotFound, errResponse(errors.New("tokens are not signed with a public key")))
+		return
+	}
+	ctx.Header("Cache-Control", jwksCacheControl)
+	ctx.JSON(http.StatusOK, keySet.JWKS())
+}
@@ -0,0 +1,277 @@
+package api
+
+import (
+	mock "bank/db/mock"
+	db "bank/db/sqlc"
+	"bank/token"
+	"bank/util"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"database/sql"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRenewAccessTokenAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name      string
+		sessionID uuid.UUID
+		// tokenType is the type of the token the request is made with
+		tokenType token.TokenType
+		// retired makes the request with a token the session has already replaced
+		retired       bool
+		buildStubs    func(store *mock.MockStore, session db.Session)
+		checkResponse func(recorder *httptest.ResponseRecorder, tokenMaker token.Maker, session db.Session)
+	}{
+		{
+			name:      "OK",
+			sessionID: uuid.New(),
+			tokenType: token.TokenTypeRefresh,
+			buildStubs: func(store *mock.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ any, arg db.RotateSessionRefreshTokenParams) (db.Session, error) {
+						require.Equal(t, session.ID, arg.ID)
+						require.Equal(t, session.RefreshToken, arg.OldRefreshToken)
+						require.NotEqual(t, arg.OldRefreshToken, arg.NewRefreshToken)
+						session.RefreshToken = arg.NewRefreshToken
+						return session, nil
+					})
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, tokenMaker token.Maker, session db.Session) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got reNewAccessTokenResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				payload, err := tokenMaker.VerifyToken(got.AccessToken)
+				require.NoError(t, err)
+				require.Equal(t, session.ID, payload.SessionID)
+
+				require.NotEqual(t, session.RefreshToken, got.RefreshToken)
+				payload, err = tokenMaker.VerifyToken(got.RefreshToken)
+				require.NoError(t, err)
+				require.Equal(t, session.ID, payload.SessionID)
+				require.Equal(t, token.TokenTypeRefresh, payload.Type)
+				// the rotated refresh token doesn't outlive the session
+				require.WithinDuration(t, session.ExpiredAt, got.RefreshTokenExpiredAt, time.Second)
+			},
+		},
+		{
+			// refresh tokens issued before tokens carried a session are the id of their session
+			name:      "TokenWithoutSession",
+			sessionID: uuid.Nil,
+			tokenType: token.TokenTypeRefresh,
+			buildStubs: func(store *mock.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(1).Return(session, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, tokenMaker token.Maker, session db.Session) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got reNewAccessTokenResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				payload, err := tokenMaker.VerifyToken(got.RefreshToken)
+				require.NoError(t, err)
+				require.Equal(t, session.ID, payload.SessionID)
+			},
+		},
+		{
+			name:      "RevokedSession",
+			sessionID: uuid.New(),
+			tokenType: token.TokenTypeRefresh,
+			buildStubs: func(store *mock.MockStore, session db.Session) {
+				session.IsBlocked = true
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, tokenMaker token.Maker, session db.Session) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "RetiredToken",
+			sessionID: uuid.New(),
+			tokenType: token.TokenTypeRefresh,
+			retired:   true,
+			buildStubs: func(store *mock.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(0)
+				arg := db.BlockSessionParams{ID: session.ID, Username: session.Username}
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Eq(arg)).Times(1).Return(session, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, tokenMaker token.Maker, session db.Session) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			// an access token of the session isn't a stolen refresh token, the session stays as it is
+			name:      "AccessToken",
+			sessionID: uuid.New(),
+			tokenType: token.TokenTypeAccess,
+			retired:   true,
+			buildStubs: func(store *mock.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, tokenMaker token.Maker, session db.Session) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			// a stale token from before tokens had a type is refused without blocking the session
+			name:      "UntypedStaleToken",
+			sessionID: uuid.New(),
+			tokenType: "",
+			retired:   true,
+			buildStubs: func(store *mock.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, tokenMaker token.Maker, session db.Session) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			// another renewal rotated the token between the read and the update
+			name:      "RotatedConcurrently",
+			sessionID: uuid.New(),
+			tokenType: token.TokenTypeRefresh,
+			buildStubs: func(store *mock.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(1).Return(db.Session{}, sql.ErrNoRows)
+				arg := db.BlockSessionParams{ID: session.ID, Username: session.Username}
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Eq(arg)).Times(1).Return(session, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, tokenMaker token.Maker, session db.Session) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "InternalError",
+			sessionID: uuid.New(),
+			tokenType: token.TokenTypeRefresh,
+			buildStubs: func(store *mock.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().RotateSessionRefreshToken(gomock.Any(), gomock.Any()).Times(1).Return(db.Session{}, sql.ErrConnDone)
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, tokenMaker token.Maker, session db.Session) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, tc.sessionID, tc.tokenType, time.Hour)
+			require.NoError(t, err)
+			session := randomSession(user.Username)
+			session.ID = tc.sessionID
+			if session.ID == uuid.Nil {
+				session.ID = refreshPayload.ID
+			}
+			session.ExpiredAt = refreshPayload.ExpiredAt
+			if !tc.retired {
+				session.RefreshToken = refreshToken
+			}
+			tc.buildStubs(store, session)
+
+			body, err := json.Marshal(gin.H{"refresh_token": refreshToken})
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/tokens/renew_access", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder, server.tokenMaker, session)
+		})
+	}
+}
+
+func TestGetJWKSAPI(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	signingKeyFile := filepath.Join(t.TempDir(), "token_signing_key.pem")
+	err = os.WriteFile(signingKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		config        util.Config
+		checkResponse func(recorder *httptest.ResponseRecorder, tokenMaker token.Maker)
+	}{
+		{
+			name: "OK",
+			config: util.Config{
+				TokenSigningKeyFile: signingKeyFile,
+				AccessTokenDuration: time.Minute,
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, jwksCacheControl, recorder.Header().Get("Cache-Control"))
+
+				var got token.JWKS
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, tokenMaker.(token.KeySet).JWKS(), got)
+				require.Len(t, got.Keys, 1)
+				require.Equal(t, "OKP", got.Keys[0].KeyType)
+				require.Empty(t, got.Keys[0].N)
+			},
+		},
+		{
+			name: "SymmetricKey",
+			config: util.Config{
+				TokenSymmetricKey:   util.RandomString(32),
+				AccessTokenDuration: time.Minute,
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			server, err := NewServer(tc.config, store)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder, server.tokenMaker)
+		})
+	}
+}
@@ -3,10 +3,13 @@ package api
 import (
 	db "bank/db/sqlc"
 	"bank/token"
+	"bank/util"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"math"
 	"net/http"
 )
 
@@ -15,6 +18,20 @@ type transferRequest struct {
 	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
 	Amount        int64  `json:"amount" binding:"required,gt=0"`
 	Currency      string `json:"currency" binding:"required,currency"`
+	// ToCurrency is only needed when the to account holds a different currency
+	ToCurrency string `json:"to_currency" binding:"omitempty,currency"`
+	Memo       string `json:"memo" binding:"omitempty,max=140"`
+	// ClientReference is the sender's own id, it can't be used twice from the same account
+	ClientReference string          `json:"client_reference" binding:"omitempty,max=64,printascii"`
+	Metadata        json.RawMessage `json:"metadata" binding:"omitempty,max=4096,json_object"`
+}
+
+func (req transferRequest) details() db.TransferDetails {
+	return db.TransferDetails{
+		Memo:            req.Memo,
+		ClientReference: req.ClientReference,
+		Metadata:        req.Metadata,
+	}
 }
 
 func (server *Server) createTransfer(ctx *gin.Context) {
@@ -28,30 +45,336 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 	if !valid {
 		return
 	}
+	if !server.validAccountRole(ctx, fromAccount, db.AccountRoleCanTransfer) {
+		return
+	}
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if fromAccount.Owner != authPayload.Username {
-		err := errors.New("from account doesn't belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errResponse(err))
+	toCurrency := req.Currency
+	if req.ToCurrency != "" {
+		toCurrency = req.ToCurrency
+	}
+	_, valid = server.validAccount(ctx, req.ToAccountID, toCurrency)
+	if !valid {
 		return
 	}
-	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+
+	idempotency, valid := idempotencyParams(ctx, authPayload.Username)
 	if !valid {
 		return
 	}
 
+	if server.config.RequiresApproval(req.Amount, fromAccount.Currency) {
+		server.createTransferRequest(ctx, req, idempotency)
+		return
+	}
+
+	if toCurrency != req.Currency {
+		args := db.ExchangeTransferTxParams{
+			FromAccountID:   req.FromAccountID,
+			ToAccountID:     req.ToAccountID,
+			Amount:          req.Amount,
+			TransferDetails: req.details(),
+			Idempotency:     idempotency,
+			InitiatedBy:     authPayload.Username,
+		}
+		result, err := server.store.ExchangeTransferTx(ctx, args)
+		if err != nil {
+			transferErrResponse(ctx, err)
+			return
+		}
+		if result.Replayed {
+			setReplayedHeader(ctx)
+		}
+		ctx.JSON(http.StatusOK, result)
+		return
+	}
+
 	args := db.TransferTxParams{
-		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
+		FromAccountID:   req.FromAccountID,
+		ToAccountID:     req.ToAccountID,
+		Amount:          req.Amount,
+		TransferDetails: req.details(),
+		Idempotency:     idempotency,
+		InitiatedBy:     authPayload.Username,
 	}
 	result, err := server.store.TransferTx(ctx, args)
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	if result.Replayed {
+		setReplayedHeader(ctx)
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+type transferLegRequest struct {
+	ToAccountID int64 `json:"to_account_id" binding:"required,min=1"`
+	Amount      int64 `json:"amount" binding:"required,gt=0"`
+}
+
+type multiTransferRequest struct {
+	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
+	Currency      string `json:"currency" binding:"required,currency"`
+	// at most 100 accounts can be paid at once
+	Legs []transferLegRequest `json:"legs" binding:"required,min=1,max=100,dive"`
+}
+
+// createMultiTransfer pays several accounts from one account, all legs are paid or none is
+func (server *Server) createMultiTransfer(ctx *gin.Context) {
+	var req multiTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
+	if !valid {
+		return
+	}
+	if !server.validAccountRole(ctx, fromAccount, db.AccountRoleCanTransfer) {
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	var total int64
+	for _, leg := range req.Legs {
+		if leg.Amount > math.MaxInt64-total {
+			total = math.MaxInt64
+			break
+		}
+		total += leg.Amount
+	}
+	legs := make([]db.TransferLeg, len(req.Legs))
+	checked := make(map[int64]bool)
+	for i, leg := range req.Legs {
+		if leg.ToAccountID == req.FromAccountID {
+			err := fmt.Errorf("leg %d pays the from account", i)
+			ctx.JSON(http.StatusBadRequest, errResponse(err))
+			return
+		}
+		if !checked[leg.ToAccountID] {
+			if _, valid = server.validAccount(ctx, leg.ToAccountID, req.Currency); !valid {
+				return
+			}
+			checked[leg.ToAccountID] = true
+		}
+		legs[i] = db.TransferLeg{
+			ToAccountID: leg.ToAccountID,
+			Amount:      leg.Amount,
+		}
+	}
+
+	idempotency, valid := idempotencyParams(ctx, authPayload.Username)
+	if !valid {
+		return
+	}
+
+	// a large payment waits for a second user to approve it
+	if server.config.RequiresApproval(total, req.Currency) {
+		server.createMultiTransferRequest(ctx, req.FromAccountID, legs, idempotency)
+		return
+	}
+
+	args := db.MultiTransferTxParams{
+		FromAccountID: req.FromAccountID,
+		Legs:          legs,
+		Idempotency:   idempotency,
+		InitiatedBy:   authPayload.Username,
+	}
+	result, err := server.store.MultiTransferTx(ctx, args)
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	if result.Replayed {
+		setReplayedHeader(ctx)
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+type reverseTransferURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type reverseTransferRequest struct {
+	// Amount is optional, the whole transfer is reversed when it is not set
+	Amount int64 `json:"amount" binding:"omitempty,gt=0"`
+}
+
+// reverseTransfer sends a transfer back to its sender. Only the owner of the account that received
+// the money can reverse it, so a sender can't take a payment back on their own.
+func (server *Server) reverseTransfer(ctx *gin.Context) {
+	var uri reverseTransferURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req reverseTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	transfer, err := server.store.GetTransfer(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	toAccount, err := server.store.GetAccount(ctx, transfer.ToAccountID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errResponse(err))
 		return
 	}
+	// only the receiving side can send the money back
+	if !server.validAccountRole(ctx, toAccount, db.AccountRoleCanTransfer) {
+		return
+	}
+
+	if req.Amount == 0 {
+		req.Amount = transfer.Amount
+	}
+	if req.Amount > transfer.Amount {
+		err = fmt.Errorf("amount %d is more than the transfer amount %d", req.Amount, transfer.Amount)
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	idempotency, valid := idempotencyParams(ctx, authPayload.Username)
+	if !valid {
+		return
+	}
+
+	args := db.ReverseTransferTxParams{
+		TransferID:  transfer.ID,
+		Amount:      req.Amount,
+		Idempotency: idempotency,
+	}
+	result, err := server.store.ReverseTransferTx(ctx, args)
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	if result.Replayed {
+		setReplayedHeader(ctx)
+	}
 	ctx.JSON(http.StatusOK, result)
 }
 
+// transferResponse adds the amounts of a transfer as decimals in the currencies of its from and to accounts
+type transferResponse struct {
+	db.Transfer
+	AmountFormatted   string `json:"amount_formatted"`
+	ToAmountFormatted string `json:"to_amount_formatted"`
+}
+
+type listTransferResponse struct {
+	Transfers     []transferResponse `json:"transfers"`
+	NextPageToken string             `json:"next_page_token"`
+}
+
+// listTransfer returns a page of the transfers into or out of an account of the authenticated user, oldest first
+func (server *Server) listTransfer(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	afterID, err := req.afterID()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleViewOnly)
+	if !valid {
+		return
+	}
+
+	transfers, err := server.store.ListTransfersAfter(ctx, db.ListTransfersAfterParams{
+		AccountID:  account.ID,
+		AfterID:    afterID,
+		LimitCount: req.limit(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	var res listTransferResponse
+	transfers, res.NextPageToken = util.NextPage(transfers, req.PageSize, func(transfer db.Transfer) int64 { return transfer.ID })
+	// counterparties of exchange transfers hold another currency, look each one up once
+	currencies := map[int64]string{account.ID: account.Currency}
+	res.Transfers = make([]transferResponse, len(transfers))
+	for i, transfer := range transfers {
+		fromCurrency, toCurrency := account.Currency, account.Currency
+		if transfer.FxRate.Valid {
+			fromCurrency, err = server.accountCurrency(ctx, transfer.FromAccountID, currencies)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, errResponse(err))
+				return
+			}
+			toCurrency, err = server.accountCurrency(ctx, transfer.ToAccountID, currencies)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, errResponse(err))
+				return
+			}
+		}
+		res.Transfers[i] = transferResponse{
+			Transfer:          transfer,
+			AmountFormatted:   util.FormatCurrencyAmount(transfer.Amount, fromCurrency),
+			ToAmountFormatted: util.FormatCurrencyAmount(transfer.ToAmount, toCurrency),
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// accountCurrency returns the currency of an account, reading it from the store the first time
+func (server *Server) accountCurrency(ctx *gin.Context, accountID int64, currencies map[int64]string) (string, error) {
+	if currency, ok := currencies[accountID]; ok {
+		return currency, nil
+	}
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		return "", err
+	}
+	currencies[accountID] = account.Currency
+	return account.Currency, nil
+}
+
+// transferErrResponse maps errors from the money moving transactions to a response
+func transferErrResponse(ctx *gin.Context, err error) {
+	var limitErr *db.TransferLimitError
+	switch {
+	case errors.As(err, &limitErr):
+		// tell the client which limit was hit and when it resets
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "limit": limitErr})
+	case errors.Is(err, db.ErrInsufficientFunds), errors.Is(err, db.ErrFxRateNotFound),
+		errors.Is(err, db.ErrTransferNotReversible), errors.Is(err, db.ErrHoldNotActive),
+		errors.Is(err, db.ErrHoldAmountExceeded), errors.Is(err, db.ErrAccountFrozen),
+		errors.Is(err, db.ErrAccountClosed), errors.Is(err, db.ErrAccountDormant),
+		errors.Is(err, db.ErrAccountStatusTransition), errors.Is(err, db.ErrInvalidSweepAccount),
+		errors.Is(err, db.ErrPotTransfer), errors.Is(err, db.ErrInvalidPotParent):
+		ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+	case errors.Is(err, db.ErrIdempotencyKeyReused), errors.Is(err, db.ErrTransferAlreadyReversed),
+		errors.Is(err, db.ErrDuplicateClientReference), errors.Is(err, db.ErrRoundUpPotTaken):
+		ctx.JSON(http.StatusConflict, errResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+	}
+}
+
 func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
 	account, err := server.store.GetAccount(ctx, accountID)
 	if err != nil {
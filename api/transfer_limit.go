@@ -0,0 +1,173 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"bank/token"
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"net/http"
+)
+
+// listTransferLimit shows every default limit and user override, only admins can see them
+func (server *Server) listTransferLimit(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validAdmin(ctx, authPayload.Username); !valid {
+		return
+	}
+
+	limits, err := server.store.ListAllTransferLimits(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, limits)
+}
+
+// transferLimitValues are the limits of a transfer limit row, a missing value means no limit,
+// or the default limit for a user override
+type transferLimitValues struct {
+	MaxSingle    *int64 `json:"max_single" binding:"omitempty,min=0"`
+	DailyTotal   *int64 `json:"daily_total" binding:"omitempty,min=0"`
+	MonthlyTotal *int64 `json:"monthly_total" binding:"omitempty,min=0"`
+	HourlyCount  *int32 `json:"hourly_count" binding:"omitempty,min=0"`
+}
+
+func (values transferLimitValues) maxSingle() sql.NullInt64 {
+	return nullInt64(values.MaxSingle)
+}
+
+func (values transferLimitValues) dailyTotal() sql.NullInt64 {
+	return nullInt64(values.DailyTotal)
+}
+
+func (values transferLimitValues) monthlyTotal() sql.NullInt64 {
+	return nullInt64(values.MonthlyTotal)
+}
+
+func (values transferLimitValues) hourlyCount() sql.NullInt32 {
+	if values.HourlyCount == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *values.HourlyCount, Valid: true}
+}
+
+func nullInt64(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
+
+type createTransferLimitRequest struct {
+	Scope    string `json:"scope" binding:"required,oneof=account user"`
+	Currency string `json:"currency" binding:"required,currency"`
+	// Username makes the limit an override for the user, the limit is the default for everyone without it
+	Username string `json:"username" binding:"omitempty,alphanum"`
+	transferLimitValues
+}
+
+// createTransferLimit adds a default limit or a user override, only admins can do it
+func (server *Server) createTransferLimit(ctx *gin.Context) {
+	var req createTransferLimitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validAdmin(ctx, authPayload.Username); !valid {
+		return
+	}
+
+	limit, err := server.store.CreateTransferLimit(ctx, db.CreateTransferLimitParams{
+		Scope:        req.Scope,
+		Currency:     req.Currency,
+		Username:     sql.NullString{String: req.Username, Valid: req.Username != ""},
+		MaxSingle:    req.maxSingle(),
+		DailyTotal:   req.dailyTotal(),
+		MonthlyTotal: req.monthlyTotal(),
+		HourlyCount:  req.hourlyCount(),
+	})
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code.Name() {
+			case "foreign_key_violation", "unique_violation":
+				ctx.JSON(http.StatusForbidden, errResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, limit)
+}
+
+type transferLimitURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// updateTransferLimit replaces the limits of a row, the scope, currency and user stay.
+// Only admins can do it.
+func (server *Server) updateTransferLimit(ctx *gin.Context) {
+	var uri transferLimitURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req transferLimitValues
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validAdmin(ctx, authPayload.Username); !valid {
+		return
+	}
+
+	limit, err := server.store.UpdateTransferLimit(ctx, db.UpdateTransferLimitParams{
+		MaxSingle:    req.maxSingle(),
+		DailyTotal:   req.dailyTotal(),
+		MonthlyTotal: req.monthlyTotal(),
+		HourlyCount:  req.hourlyCount(),
+		ID:           uri.ID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, limit)
+}
+
+// deleteTransferLimit removes a row, a user falls back to the default limits once the override is gone.
+// Only admins can do it.
+func (server *Server) deleteTransferLimit(ctx *gin.Context) {
+	var uri transferLimitURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validAdmin(ctx, authPayload.Username); !valid {
+		return
+	}
+
+	limit, err := server.store.DeleteTransferLimit(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, limit)
+}
@@ -0,0 +1,296 @@
+package api
+
+import (
+	db "bank/db/sqlc"
+	"bank/token"
+	"bank/util"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// withinApprovalThreshold refuses an amount that would need approval for an operation that can't wait for one
+func (server *Server) withinApprovalThreshold(ctx *gin.Context, amount int64, currency string, operation string) bool {
+	if server.config.RequiresApproval(amount, currency) {
+		err := fmt.Errorf("amount %s is above the approval threshold %s %s, a %s can't wait for approval",
+			util.FormatCurrencyAmount(amount, currency), util.FormatCurrencyAmount(server.config.ApprovalThreshold(currency), currency),
+			currency, operation)
+		ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+		return false
+	}
+	return true
+}
+
+// createTransferRequest holds a transfer above the approval threshold until a second user decides on it
+func (server *Server) createTransferRequest(ctx *gin.Context, req transferRequest, idempotency *db.IdempotencyParams) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.CreateTransferRequestTx(ctx, db.CreateTransferRequestTxParams{
+		FromAccountID:   req.FromAccountID,
+		ToAccountID:     req.ToAccountID,
+		Amount:          req.Amount,
+		RequestedBy:     authPayload.Username,
+		TransferDetails: req.details(),
+		ExpiresAt:       time.Now().Add(server.config.TransferApprovalTTL),
+		Idempotency:     idempotency,
+	})
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	if result.Replayed {
+		setReplayedHeader(ctx)
+	}
+	ctx.JSON(http.StatusAccepted, result)
+}
+
+// createMultiTransferRequest holds a multi transfer whose total is above the approval threshold
+// until a second user decides on it
+func (server *Server) createMultiTransferRequest(ctx *gin.Context, fromAccountID int64, legs []db.TransferLeg, idempotency *db.IdempotencyParams) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.CreateTransferRequestTx(ctx, db.CreateTransferRequestTxParams{
+		FromAccountID: fromAccountID,
+		RequestedBy:   authPayload.Username,
+		Legs:          legs,
+		ExpiresAt:     time.Now().Add(server.config.TransferApprovalTTL),
+		Idempotency:   idempotency,
+	})
+	if err != nil {
+		transferErrResponse(ctx, err)
+		return
+	}
+	if result.Replayed {
+		setReplayedHeader(ctx)
+	}
+	ctx.JSON(http.StatusAccepted, result)
+}
+
+type transferRequestURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// transferRequestResponse adds the legs of a multi transfer request
+type transferRequestResponse struct {
+	db.TransferRequest
+	Legs []db.TransferRequestLeg `json:"legs,omitempty"`
+}
+
+// getTransferRequest shows a transfer request to its requester and to the users who can decide on it
+func (server *Server) getTransferRequest(ctx *gin.Context) {
+	var uri transferRequestURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	request, err := server.store.GetTransferRequest(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if request.RequestedBy != authPayload.Username {
+		allowed, err := db.CanDecideTransferRequest(ctx, server.store, request, authPayload.Username)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+			return
+		}
+		if !allowed {
+			err = errors.New("only the requester, an officer or an owner of the from account can see this transfer request")
+			ctx.JSON(http.StatusForbidden, errResponse(err))
+			return
+		}
+	}
+
+	res := transferRequestResponse{TransferRequest: request}
+	if !request.ToAccountID.Valid {
+		res.Legs, err = server.store.ListTransferRequestLegs(ctx, request.ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+			return
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+type listTransferRequestRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	// Pending lists the requests waiting for a decision from any user, only officers can see them
+	Pending bool `form:"pending"`
+}
+
+func (server *Server) listTransferRequest(ctx *gin.Context) {
+	var req listTransferRequestRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	var requests []db.TransferRequest
+	var err error
+	if req.Pending {
+		if _, valid := server.validOfficer(ctx, authPayload.Username); !valid {
+			return
+		}
+		requests, err = server.store.ListPendingTransferRequests(ctx, db.ListPendingTransferRequestsParams{
+			Now:         time.Now(),
+			LimitCount:  req.PageSize,
+			OffsetCount: (req.PageID - 1) * req.PageSize,
+		})
+	} else {
+		requests, err = server.store.ListTransferRequests(ctx, db.ListTransferRequestsParams{
+			RequestedBy: authPayload.Username,
+			Limit:       req.PageSize,
+			Offset:      (req.PageID - 1) * req.PageSize,
+		})
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, requests)
+}
+
+type listAccountTransferRequestResponse struct {
+	TransferRequests []transferRequestResponse `json:"transfer_requests"`
+	NextPageToken    string                    `json:"next_page_token"`
+}
+
+// listAccountTransferRequest returns a page of the requests waiting for a decision on transfers from an account,
+// oldest first. Every owner of the account can see them, so a co-owner knows there is a request to decide on.
+func (server *Server) listAccountTransferRequest(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	afterID, err := req.afterID()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	account, valid := server.validMemberAccount(ctx, uri.ID, db.AccountRoleOwner)
+	if !valid {
+		return
+	}
+
+	requests, err := server.store.ListAccountPendingTransferRequestsAfter(ctx, db.ListAccountPendingTransferRequestsAfterParams{
+		FromAccountID: account.ID,
+		Now:           time.Now(),
+		AfterID:       afterID,
+		LimitCount:    req.limit(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	var res listAccountTransferRequestResponse
+	requests, res.NextPageToken = util.NextPage(requests, req.PageSize, func(request db.TransferRequest) int64 { return request.ID })
+	res.TransferRequests = make([]transferRequestResponse, len(requests))
+	for i, request := range requests {
+		res.TransferRequests[i].TransferRequest = request
+		if !request.ToAccountID.Valid {
+			res.TransferRequests[i].Legs, err = server.store.ListTransferRequestLegs(ctx, request.ID)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, errResponse(err))
+				return
+			}
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// approveTransferRequest makes the requested transfer, an officer or an owner of the from account other than
+// the requester can approve it
+func (server *Server) approveTransferRequest(ctx *gin.Context) {
+	var uri transferRequestURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.ApproveTransferRequestTx(ctx, db.ApproveTransferRequestTxParams{
+		RequestID:  uri.ID,
+		ApprovedBy: authPayload.Username,
+	})
+	if err != nil {
+		transferRequestErrResponse(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+type rejectTransferRequestRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+// rejectTransferRequest closes a transfer request without moving money
+func (server *Server) rejectTransferRequest(ctx *gin.Context) {
+	var uri transferRequestURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+	var req rejectTransferRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	request, err := server.store.RejectTransferRequestTx(ctx, db.RejectTransferRequestTxParams{
+		RequestID:  uri.ID,
+		RejectedBy: authPayload.Username,
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		transferRequestErrResponse(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, request)
+}
+
+// validOfficer makes sure the authenticated user is a bank officer
+func (server *Server) validOfficer(ctx *gin.Context, username string) (db.User, bool) {
+	user, err := server.store.GetUser(ctx, username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return user, false
+	}
+	if user.Role != db.RoleOfficer {
+		err = errors.New("only an officer can do this")
+		ctx.JSON(http.StatusForbidden, errResponse(err))
+		return user, false
+	}
+	return user, true
+}
+
+func transferRequestErrResponse(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		ctx.JSON(http.StatusNotFound, errResponse(err))
+	case errors.Is(err, db.ErrTransferRequestNotPending):
+		ctx.JSON(http.StatusConflict, errResponse(err))
+	case errors.Is(err, db.ErrTransferRequestSelfDecision), errors.Is(err, db.ErrTransferRequestDecider):
+		ctx.JSON(http.StatusForbidden, errResponse(err))
+	default:
+		transferErrResponse(ctx, err)
+	}
+}
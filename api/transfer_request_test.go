@@ -0,0 +1,509 @@
+package api
+
+import (
+	mock "bank/db/mock"
+	db "bank/db/sqlc"
+	"bank/token"
+	"bank/util"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateTransferAboveApprovalThreshold(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().CreateTransferRequestTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ any, arg db.CreateTransferRequestTxParams) (db.CreateTransferRequestTxResult, error) {
+			require.Equal(t, user1.Username, arg.RequestedBy)
+			require.Equal(t, int64(1001), arg.Amount)
+			require.WithinDuration(t, time.Now().Add(time.Hour), arg.ExpiresAt, time.Minute)
+			return db.CreateTransferRequestTxResult{TransferRequest: db.TransferRequest{
+				ID:          1,
+				Amount:      arg.Amount,
+				RequestedBy: arg.RequestedBy,
+				Status:      db.TransferRequestPending,
+			}}, nil
+		})
+
+	server := newTestServer(t, store)
+	server.config.TransferApprovalThreshold = 10
+	server.config.TransferApprovalTTL = time.Hour
+	recorder := httptest.NewRecorder()
+
+	data, err := json.Marshal(gin.H{
+		"from_account_id": account1.ID,
+		"to_account_id":   account2.ID,
+		"amount":          1001,
+		"currency":        util.USD,
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusAccepted, recorder.Code)
+
+	var got db.CreateTransferRequestTxResult
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, db.TransferRequestPending, got.TransferRequest.Status)
+}
+
+func TestCreateMultiTransferAboveApprovalThreshold(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account3 := randomAccount(user2.Username)
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+	account3.Currency = util.USD
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+	store.EXPECT().MultiTransferTx(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().CreateTransferRequestTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ any, arg db.CreateTransferRequestTxParams) (db.CreateTransferRequestTxResult, error) {
+			require.Equal(t, account1.ID, arg.FromAccountID)
+			require.Equal(t, user1.Username, arg.RequestedBy)
+			require.Equal(t, []db.TransferLeg{
+				{ToAccountID: account2.ID, Amount: 600},
+				{ToAccountID: account3.ID, Amount: 401},
+			}, arg.Legs)
+			return db.CreateTransferRequestTxResult{TransferRequest: db.TransferRequest{
+				ID:          1,
+				Amount:      1001,
+				RequestedBy: arg.RequestedBy,
+				Status:      db.TransferRequestPending,
+			}}, nil
+		})
+
+	server := newTestServer(t, store)
+	server.config.TransferApprovalThreshold = 10
+	recorder := httptest.NewRecorder()
+
+	data, err := json.Marshal(gin.H{
+		"from_account_id": account1.ID,
+		"currency":        util.USD,
+		"legs": []gin.H{
+			{"to_account_id": account2.ID, "amount": 600},
+			{"to_account_id": account3.ID, "amount": 401},
+		},
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/multi_transfers", bytes.NewReader(data))
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusAccepted, recorder.Code)
+
+	var got db.CreateTransferRequestTxResult
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, db.TransferRequestPending, got.TransferRequest.Status)
+}
+
+func TestApprovalThresholdOutsideTransfers(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+	potAccount := randomPotAccount(account1)
+	amount := int64(1001)
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		buildStubs    func(store *mock.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "StandingOrder",
+			method: http.MethodPost,
+			url:    "/standing_orders",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        util.USD,
+				"schedule":        util.ScheduleMonthly,
+				"start_at":        time.Now().Add(24 * time.Hour),
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CreateStandingOrder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:   "Hold",
+			method: http.MethodPost,
+			url:    "/holds",
+			body: gin.H{
+				"account_id":    account1.ID,
+				"to_account_id": account2.ID,
+				"amount":        amount,
+				"currency":      util.USD,
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().AuthorizeHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:   "PotWithdrawal",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/pots/%d/withdraw", potAccount.ID),
+			body:   gin.H{"amount": amount},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(potAccount.ID)).Times(1).Return(potAccount, nil)
+				store.EXPECT().MovePotTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateTransferRequestTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ any, arg db.CreateTransferRequestTxParams) (db.CreateTransferRequestTxResult, error) {
+						require.Equal(t, potAccount.ID, arg.FromAccountID)
+						require.Equal(t, account1.ID, arg.ToAccountID)
+						require.Equal(t, amount, arg.Amount)
+						require.Equal(t, user1.Username, arg.RequestedBy)
+						return db.CreateTransferRequestTxResult{}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.config.TransferApprovalThreshold = 10
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestApproveTransferRequestAPI(t *testing.T) {
+	officer, _ := randomUser(t)
+	officer.Role = db.RoleOfficer
+	customer, _ := randomUser(t)
+	customer.Role = db.RoleCustomer
+	requestID := util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mock.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, officer.Username, time.Minute)
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ApproveTransferRequestTx(gomock.Any(), gomock.Eq(db.ApproveTransferRequestTxParams{
+					RequestID:  requestID,
+					ApprovedBy: officer.Username,
+				})).Times(1).Return(db.ApproveTransferRequestTxResult{
+					TransferRequest: db.TransferRequest{ID: requestID, Status: db.TransferRequestApproved},
+				}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "CoOwner",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, customer.Username, time.Minute)
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ApproveTransferRequestTx(gomock.Any(), gomock.Eq(db.ApproveTransferRequestTxParams{
+					RequestID:  requestID,
+					ApprovedBy: customer.Username,
+				})).Times(1).Return(db.ApproveTransferRequestTxResult{
+					TransferRequest: db.TransferRequest{ID: requestID, Status: db.TransferRequestApproved},
+				}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotDecider",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, customer.Username, time.Minute)
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ApproveTransferRequestTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveTransferRequestTxResult{}, db.ErrTransferRequestDecider)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "SelfApproval",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, officer.Username, time.Minute)
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ApproveTransferRequestTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveTransferRequestTxResult{}, db.ErrTransferRequestSelfDecision)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotPending",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, officer.Username, time.Minute)
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ApproveTransferRequestTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveTransferRequestTxResult{}, db.ErrTransferRequestNotPending)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, officer.Username, time.Minute)
+			},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ApproveTransferRequestTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveTransferRequestTxResult{}, db.ErrInsufficientFunds)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:      "NoAuthorization",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().ApproveTransferRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/transfer_requests/%d/approve", requestID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRejectTransferRequestAPI(t *testing.T) {
+	officer, _ := randomUser(t)
+	officer.Role = db.RoleOfficer
+	requestID := util.RandomInt(1, 1000)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock.NewMockStore(ctrl)
+	store.EXPECT().RejectTransferRequestTx(gomock.Any(), gomock.Eq(db.RejectTransferRequestTxParams{
+		RequestID:  requestID,
+		RejectedBy: officer.Username,
+		Reason:     "unusual payee",
+	})).Times(1).Return(db.TransferRequest{ID: requestID, Status: db.TransferRequestRejected}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	data, err := json.Marshal(gin.H{"reason": "unusual payee"})
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("/transfer_requests/%d/reject", requestID)
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, officer.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got db.TransferRequest
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, db.TransferRequestRejected, got.Status)
+}
+
+func TestListAccountTransferRequestAPI(t *testing.T) {
+	holder, _ := randomUser(t)
+	coOwner, _ := randomUser(t)
+	member, _ := randomUser(t)
+	other, _ := randomUser(t)
+	account := randomAccount(holder.Username)
+	account.Joint = true
+
+	requests := []db.TransferRequest{
+		{ID: 1, FromAccountID: account.ID, ToAccountID: sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true}, Amount: 2000, RequestedBy: holder.Username, Status: db.TransferRequestPending},
+		{ID: 2, FromAccountID: account.ID, Amount: 3000, RequestedBy: holder.Username, Status: db.TransferRequestPending},
+	}
+	legs := []db.TransferRequestLeg{{ID: 1, TransferRequestID: 2, ToAccountID: util.RandomInt(1, 1000), Amount: 3000}}
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mock.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "CoOwner",
+			username: coOwner.Username,
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{AccountID: account.ID, Username: coOwner.Username})).
+					Times(1).Return(db.AccountMember{Role: db.AccountRoleOwner, Status: db.AccountMemberActive}, nil)
+				store.EXPECT().ListAccountPendingTransferRequestsAfter(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ any, arg db.ListAccountPendingTransferRequestsAfterParams) ([]db.TransferRequest, error) {
+						require.Equal(t, account.ID, arg.FromAccountID)
+						require.Equal(t, int64(0), arg.AfterID)
+						require.Equal(t, int32(6), arg.LimitCount)
+						require.WithinDuration(t, time.Now(), arg.Now, time.Minute)
+						return requests, nil
+					})
+				store.EXPECT().ListTransferRequestLegs(gomock.Any(), gomock.Eq(int64(2))).Times(1).Return(legs, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got listAccountTransferRequestResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.TransferRequests, 2)
+				require.Equal(t, requests[0].ID, got.TransferRequests[0].ID)
+				require.Empty(t, got.TransferRequests[0].Legs)
+				require.Equal(t, legs[0].ToAccountID, got.TransferRequests[1].Legs[0].ToAccountID)
+				require.Empty(t, got.NextPageToken)
+			},
+		},
+		{
+			name:     "CanTransferMember",
+			username: member.Username,
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{AccountID: account.ID, Username: member.Username})).
+					Times(1).Return(db.AccountMember{Role: db.AccountRoleCanTransfer, Status: db.AccountMemberActive}, nil)
+				store.EXPECT().ListAccountPendingTransferRequestsAfter(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "NotMember",
+			username: other.Username,
+			buildStubs: func(store *mock.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().ListAccountPendingTransferRequestsAfter(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/transfer_requests?page_size=5", account.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
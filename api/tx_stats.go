@@ -0,0 +1,17 @@
+package api
+
+import (
+	"bank/token"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// getTxStats shows how often database transactions were retried because of contention, only admins can see it
+func (server *Server) getTxStats(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validAdmin(ctx, authPayload.Username); !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.store.TxStats())
+}
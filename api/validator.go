@@ -2,6 +2,7 @@ package api
 
 import (
 	"bank/util"
+	"encoding/json"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -12,3 +13,17 @@ var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
 	}
 	return false
 }
+
+var validSchedule validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if schedule, ok := fieldLevel.Field().Interface().(string); ok {
+		return util.IsSupportSchedule(schedule)
+	}
+	return false
+}
+
+var validJSONObject validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if raw, ok := fieldLevel.Field().Interface().(json.RawMessage); ok {
+		return util.IsJSONObject(raw)
+	}
+	return false
+}
@@ -0,0 +1,8 @@
+ALTER TABLE IF EXISTS "accounts"
+    DROP CONSTRAINT IF EXISTS "balance_overdraft_check";
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP CONSTRAINT IF EXISTS "overdraft_limit_check";
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP COLUMN IF EXISTS "overdraft_limit";
@@ -0,0 +1,11 @@
+ALTER TABLE "accounts"
+    ADD COLUMN "overdraft_limit" bigint NOT NULL DEFAULT 0;
+
+COMMENT
+ON COLUMN "accounts"."overdraft_limit" IS 'how far below zero the balance may go, must be non-negative';
+
+ALTER TABLE "accounts"
+    ADD CONSTRAINT "overdraft_limit_check" CHECK ("overdraft_limit" >= 0);
+
+ALTER TABLE "accounts"
+    ADD CONSTRAINT "balance_overdraft_check" CHECK ("balance" >= -"overdraft_limit");
@@ -0,0 +1,10 @@
+ALTER TABLE IF EXISTS "transfers"
+    DROP COLUMN IF EXISTS "fx_spread_bps";
+
+ALTER TABLE IF EXISTS "transfers"
+    DROP COLUMN IF EXISTS "fx_rate";
+
+ALTER TABLE IF EXISTS "transfers"
+    DROP COLUMN IF EXISTS "to_amount";
+
+DROP TABLE IF EXISTS "fx_rates";
@@ -0,0 +1,41 @@
+CREATE TABLE "fx_rates"
+(
+    "id"            bigserial PRIMARY KEY,
+    "from_currency" varchar         NOT NULL,
+    "to_currency"   varchar         NOT NULL,
+    "rate"          numeric(20, 10) NOT NULL,
+    "spread_bps"    integer         NOT NULL DEFAULT 0,
+    "created_at"    timestamptz     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON "fx_rates" ("from_currency", "to_currency", "created_at");
+
+COMMENT
+ON COLUMN "fx_rates"."rate" IS 'units of to_currency for one unit of from_currency, must be positive';
+
+COMMENT
+ON COLUMN "fx_rates"."spread_bps" IS 'margin taken off the converted amount, in basis points';
+
+ALTER TABLE "fx_rates"
+    ADD CONSTRAINT "fx_rate_check" CHECK ("rate" > 0 AND "spread_bps" >= 0 AND "spread_bps" < 10000);
+
+ALTER TABLE "transfers"
+    ADD COLUMN "to_amount" bigint;
+
+UPDATE "transfers"
+SET "to_amount" = "amount";
+
+ALTER TABLE "transfers"
+    ALTER COLUMN "to_amount" SET NOT NULL;
+
+ALTER TABLE "transfers"
+    ADD COLUMN "fx_rate" numeric(20, 10);
+
+ALTER TABLE "transfers"
+    ADD COLUMN "fx_spread_bps" integer;
+
+COMMENT
+ON COLUMN "transfers"."to_amount" IS 'amount credited in the destination currency, must be positive';
+
+COMMENT
+ON COLUMN "transfers"."fx_rate" IS 'rate applied to a cross-currency transfer';
@@ -0,0 +1,72 @@
+CREATE TABLE "standing_orders"
+(
+    "id"              bigserial PRIMARY KEY,
+    "owner"           varchar     NOT NULL,
+    "from_account_id" bigint      NOT NULL,
+    "to_account_id"   bigint      NOT NULL,
+    "amount"          bigint      NOT NULL,
+    "schedule"        varchar     NOT NULL,
+    "cron_expression" varchar     NOT NULL DEFAULT '',
+    "start_at"        timestamptz NOT NULL,
+    "end_at"          timestamptz,
+    "next_run_at"     timestamptz NOT NULL,
+    "failure_policy"  varchar     NOT NULL DEFAULT 'retry',
+    "max_retries"     integer     NOT NULL DEFAULT 3,
+    "status"          varchar     NOT NULL DEFAULT 'active',
+    "created_at"      timestamptz NOT NULL DEFAULT now(),
+    "updated_at"      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE "standing_order_runs"
+(
+    "id"                bigserial PRIMARY KEY,
+    "standing_order_id" bigint      NOT NULL,
+    "scheduled_at"      timestamptz NOT NULL,
+    "attempt"           integer     NOT NULL,
+    "status"            varchar     NOT NULL,
+    "transfer_id"       bigint,
+    "error"             varchar     NOT NULL DEFAULT '',
+    "created_at"        timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON "standing_orders" ("owner");
+
+CREATE INDEX ON "standing_orders" ("status", "next_run_at");
+
+CREATE INDEX ON "standing_order_runs" ("standing_order_id");
+
+COMMENT
+ON COLUMN "standing_orders"."schedule" IS 'once, daily, weekly, monthly or cron';
+
+COMMENT
+ON COLUMN "standing_orders"."failure_policy" IS 'what to do when funds are insufficient: retry or skip';
+
+COMMENT
+ON COLUMN "standing_orders"."status" IS 'active, paused, completed or cancelled';
+
+COMMENT
+ON COLUMN "standing_order_runs"."status" IS 'succeeded, failed or skipped';
+
+ALTER TABLE "standing_orders"
+    ADD CONSTRAINT "standing_order_check" CHECK (
+        "amount" > 0
+            AND "schedule" IN ('once', 'daily', 'weekly', 'monthly', 'cron')
+            AND "failure_policy" IN ('retry', 'skip')
+            AND "status" IN ('active', 'paused', 'completed', 'cancelled')
+            AND "max_retries" >= 0
+        );
+
+ALTER TABLE "standing_orders"
+    ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "standing_orders"
+    ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders"
+    ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_order_runs"
+    ADD FOREIGN KEY ("standing_order_id") REFERENCES "standing_orders" ("id");
+
+ALTER TABLE "standing_order_runs"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
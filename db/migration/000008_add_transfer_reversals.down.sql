@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS "transfers"
+    DROP COLUMN IF EXISTS "reversed_amount";
+
+ALTER TABLE IF EXISTS "transfers"
+    DROP COLUMN IF EXISTS "reversal_of";
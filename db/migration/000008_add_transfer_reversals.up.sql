@@ -0,0 +1,21 @@
+ALTER TABLE "transfers"
+    ADD COLUMN "reversal_of" bigint;
+
+ALTER TABLE "transfers"
+    ADD COLUMN "reversed_amount" bigint NOT NULL DEFAULT 0;
+
+COMMENT
+ON COLUMN "transfers"."reversal_of" IS 'the transfer this one reverses';
+
+COMMENT
+ON COLUMN "transfers"."reversed_amount" IS 'part of amount that has been reversed';
+
+ALTER TABLE "transfers"
+    ADD FOREIGN KEY ("reversal_of") REFERENCES "transfers" ("id");
+
+-- a transfer can be reversed only once
+ALTER TABLE "transfers"
+    ADD CONSTRAINT "transfers_reversal_of_key" UNIQUE ("reversal_of");
+
+ALTER TABLE "transfers"
+    ADD CONSTRAINT "reversed_amount_check" CHECK ("reversed_amount" >= 0 AND "reversed_amount" <= "amount");
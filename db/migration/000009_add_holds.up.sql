@@ -0,0 +1,43 @@
+CREATE TABLE "holds"
+(
+    "id"              bigserial PRIMARY KEY,
+    "account_id"      bigint      NOT NULL,
+    "to_account_id"   bigint      NOT NULL,
+    "amount"          bigint      NOT NULL,
+    "captured_amount" bigint      NOT NULL DEFAULT 0,
+    "status"          varchar     NOT NULL DEFAULT 'active',
+    "transfer_id"     bigint,
+    "expires_at"      timestamptz NOT NULL,
+    "created_at"      timestamptz NOT NULL DEFAULT now(),
+    "updated_at"      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON "holds" ("account_id", "status");
+
+CREATE INDEX ON "holds" ("status", "expires_at");
+
+COMMENT
+ON COLUMN "holds"."amount" IS 'reserved on account_id until the hold is captured, voided or expires';
+
+COMMENT
+ON COLUMN "holds"."status" IS 'active, captured, voided or expired';
+
+COMMENT
+ON COLUMN "holds"."transfer_id" IS 'the transfer made when the hold was captured';
+
+ALTER TABLE "holds"
+    ADD CONSTRAINT "hold_check" CHECK (
+        "amount" > 0
+            AND "captured_amount" >= 0
+            AND "captured_amount" <= "amount"
+            AND "status" IN ('active', 'captured', 'voided', 'expired')
+        );
+
+ALTER TABLE "holds"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds"
+    ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
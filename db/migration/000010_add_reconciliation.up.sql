@@ -0,0 +1,35 @@
+ALTER TABLE "entries"
+    ADD COLUMN "transfer_id" bigint;
+
+-- entries and their transfer are written in one transaction, so they share created_at
+UPDATE "entries" e
+SET "transfer_id" = t."id"
+FROM "transfers" t
+WHERE e."created_at" = t."created_at"
+  AND ((e."account_id" = t."from_account_id" AND e."amount" = -t."amount")
+    OR (e."account_id" = t."to_account_id" AND e."amount" = t."to_amount"));
+
+COMMENT
+ON COLUMN "entries"."transfer_id" IS 'the transfer that wrote this entry';
+
+ALTER TABLE "entries"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "entries" ("transfer_id");
+
+CREATE TABLE "reconciliation_runs"
+(
+    "id"                   bigserial PRIMARY KEY,
+    "status"               varchar     NOT NULL,
+    "drifted_accounts"     integer     NOT NULL,
+    "orphaned_entries"     integer     NOT NULL,
+    "unbalanced_transfers" integer     NOT NULL,
+    "report"               jsonb       NOT NULL,
+    "started_at"           timestamptz NOT NULL,
+    "finished_at"          timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON "reconciliation_runs" ("started_at");
+
+COMMENT
+ON COLUMN "reconciliation_runs"."status" IS 'ok or drift';
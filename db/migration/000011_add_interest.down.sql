@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS "interest_accruals";
+
+DROP TABLE IF EXISTS "interest_postings";
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP COLUMN IF EXISTS "interest_product_id";
+
+DROP TABLE IF EXISTS "interest_products";
+
+-- the interest expense accounts are kept, transfers that paid interest still reference them
@@ -0,0 +1,100 @@
+CREATE TABLE "interest_products"
+(
+    "id"              bigserial PRIMARY KEY,
+    "name"            varchar     NOT NULL,
+    "currency"        varchar     NOT NULL,
+    "annual_rate_bps" integer     NOT NULL,
+    "created_at"      timestamptz NOT NULL DEFAULT now()
+);
+
+COMMENT
+ON COLUMN "interest_products"."annual_rate_bps" IS 'yearly interest rate in basis points, accrued daily on a 365 day year';
+
+ALTER TABLE "interest_products"
+    ADD CONSTRAINT "interest_product_name_currency_key" UNIQUE ("name", "currency");
+
+ALTER TABLE "interest_products"
+    ADD CONSTRAINT "interest_product_check" CHECK ("annual_rate_bps" >= 0);
+
+ALTER TABLE "accounts"
+    ADD COLUMN "interest_product_id" bigint;
+
+ALTER TABLE "accounts"
+    ADD FOREIGN KEY ("interest_product_id") REFERENCES "interest_products" ("id");
+
+CREATE TABLE "interest_postings"
+(
+    "id"          bigserial PRIMARY KEY,
+    "account_id"  bigint          NOT NULL,
+    "period"      date            NOT NULL,
+    "accrued"     numeric(30, 10) NOT NULL,
+    "amount"      bigint          NOT NULL,
+    "carried"     numeric(30, 10) NOT NULL,
+    "transfer_id" bigint,
+    "created_at"  timestamptz     NOT NULL DEFAULT now()
+);
+
+COMMENT
+ON COLUMN "interest_postings"."period" IS 'first day of the month the interest was accrued in';
+
+COMMENT
+ON COLUMN "interest_postings"."accrued" IS 'interest accrued in the period plus the fraction carried from the previous posting';
+
+COMMENT
+ON COLUMN "interest_postings"."amount" IS 'whole units of accrued that were paid';
+
+COMMENT
+ON COLUMN "interest_postings"."carried" IS 'fraction of accrued left over for the next posting';
+
+COMMENT
+ON COLUMN "interest_postings"."transfer_id" IS 'the transfer from the interest expense account, null when nothing was paid';
+
+ALTER TABLE "interest_postings"
+    ADD CONSTRAINT "interest_posting_account_period_key" UNIQUE ("account_id", "period");
+
+ALTER TABLE "interest_postings"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE TABLE "interest_accruals"
+(
+    "id"              bigserial PRIMARY KEY,
+    "account_id"      bigint          NOT NULL,
+    "accrual_date"    date            NOT NULL,
+    "balance"         bigint          NOT NULL,
+    "annual_rate_bps" integer         NOT NULL,
+    "amount"          numeric(30, 10) NOT NULL,
+    "posting_id"      bigint,
+    "created_at"      timestamptz     NOT NULL DEFAULT now()
+);
+
+COMMENT
+ON COLUMN "interest_accruals"."balance" IS 'end of day balance the interest was computed on';
+
+COMMENT
+ON COLUMN "interest_accruals"."amount" IS 'interest earned on the day, kept below the smallest currency unit';
+
+COMMENT
+ON COLUMN "interest_accruals"."posting_id" IS 'the monthly posting that paid this accrual';
+
+ALTER TABLE "interest_accruals"
+    ADD CONSTRAINT "interest_accrual_account_date_key" UNIQUE ("account_id", "accrual_date");
+
+ALTER TABLE "interest_accruals"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals"
+    ADD FOREIGN KEY ("posting_id") REFERENCES "interest_postings" ("id");
+
+CREATE INDEX ON "interest_accruals" ("account_id", "posting_id");
+
+-- interest is paid out of a bank-owned account per currency, which has no overdraft limit
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email")
+VALUES ('bank_interest_expense', '!', 'Interest expense', 'interest-expense@bank.internal');
+
+INSERT INTO "accounts" ("owner", "balance", "currency", "overdraft_limit")
+VALUES ('bank_interest_expense', 0, 'USD', 9223372036854775807),
+       ('bank_interest_expense', 0, 'EUR', 9223372036854775807),
+       ('bank_interest_expense', 0, 'CAD', 9223372036854775807);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "fee_schedules";
+
+-- the fee income accounts are kept, transfers that paid fees still reference them
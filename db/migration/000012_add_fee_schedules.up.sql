@@ -0,0 +1,48 @@
+CREATE TABLE "fee_schedules"
+(
+    "id"             bigserial PRIMARY KEY,
+    "currency"       varchar     NOT NULL,
+    "transfer_type"  varchar     NOT NULL,
+    "kind"           varchar     NOT NULL,
+    "flat_amount"    bigint      NOT NULL DEFAULT 0,
+    "percentage_bps" integer     NOT NULL DEFAULT 0,
+    "tiers"          jsonb       NOT NULL DEFAULT '[]',
+    "min_fee"        bigint      NOT NULL DEFAULT 0,
+    "max_fee"        bigint,
+    "created_at"     timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON "fee_schedules" ("currency", "transfer_type", "created_at");
+
+COMMENT
+ON COLUMN "fee_schedules"."transfer_type" IS 'transfer or standing_order';
+
+COMMENT
+ON COLUMN "fee_schedules"."kind" IS 'flat, percentage or tiered';
+
+COMMENT
+ON COLUMN "fee_schedules"."percentage_bps" IS 'share of the amount charged on top of flat_amount, in basis points';
+
+COMMENT
+ON COLUMN "fee_schedules"."tiers" IS 'for tiered fees, [{"up_to", "flat_amount", "percentage_bps"}] in ascending up_to order, the last tier has no up_to';
+
+COMMENT
+ON COLUMN "fee_schedules"."max_fee" IS 'no cap when null';
+
+ALTER TABLE "fee_schedules"
+    ADD CONSTRAINT "fee_schedule_check" CHECK (
+        "kind" IN ('flat', 'percentage', 'tiered')
+            AND "flat_amount" >= 0
+            AND "percentage_bps" >= 0
+            AND "min_fee" >= 0
+            AND ("max_fee" IS NULL OR "max_fee" >= "min_fee")
+        );
+
+-- fees are paid into a bank-owned account per currency
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email")
+VALUES ('bank_fee_income', '!', 'Fee income', 'fee-income@bank.internal');
+
+INSERT INTO "accounts" ("owner", "balance", "currency")
+VALUES ('bank_fee_income', 0, 'USD'),
+       ('bank_fee_income', 0, 'EUR'),
+       ('bank_fee_income', 0, 'CAD');
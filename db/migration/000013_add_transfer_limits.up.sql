@@ -0,0 +1,38 @@
+CREATE TABLE "transfer_limits"
+(
+    "id"            bigserial PRIMARY KEY,
+    "scope"         varchar     NOT NULL,
+    "currency"      varchar     NOT NULL,
+    "username"      varchar,
+    "max_single"    bigint,
+    "daily_total"   bigint,
+    "monthly_total" bigint,
+    "hourly_count"  integer,
+    "created_at"    timestamptz NOT NULL DEFAULT now(),
+    "updated_at"    timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX ON "transfer_limits" ("scope", "currency", COALESCE("username", ''));
+
+COMMENT
+ON COLUMN "transfer_limits"."scope" IS 'account limits apply to each account on its own, user limits to all accounts of the user in the currency';
+
+COMMENT
+ON COLUMN "transfer_limits"."username" IS 'null for the default limits, set for a user override';
+
+COMMENT
+ON COLUMN "transfer_limits"."max_single" IS 'null means no limit, or the default limit for an override';
+
+ALTER TABLE "transfer_limits"
+    ADD CONSTRAINT "transfer_limit_check" CHECK (
+        "scope" IN ('account', 'user')
+            AND "max_single" >= 0
+            AND "daily_total" >= 0
+            AND "monthly_total" >= 0
+            AND "hourly_count" >= 0
+        );
+
+ALTER TABLE "transfer_limits"
+    ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
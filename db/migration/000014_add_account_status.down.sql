@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS "entries_account_id_created_at_idx";
+
+DROP INDEX IF EXISTS "owner_currency_key";
+
+ALTER TABLE IF EXISTS "accounts"
+    ADD CONSTRAINT "owner_currency_key" UNIQUE ("owner", "currency");
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP CONSTRAINT IF EXISTS "account_status_check";
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP COLUMN IF EXISTS "status_changed_at";
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP COLUMN IF EXISTS "status";
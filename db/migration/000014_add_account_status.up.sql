@@ -0,0 +1,19 @@
+ALTER TABLE "accounts"
+    ADD COLUMN "status" varchar NOT NULL DEFAULT 'active';
+
+ALTER TABLE "accounts"
+    ADD COLUMN "status_changed_at" timestamptz NOT NULL DEFAULT now();
+
+COMMENT
+ON COLUMN "accounts"."status" IS 'active, frozen, closed or dormant';
+
+ALTER TABLE "accounts"
+    ADD CONSTRAINT "account_status_check" CHECK ("status" IN ('active', 'frozen', 'closed', 'dormant'));
+
+-- a closed account keeps its history, the owner can open a new account in the same currency
+ALTER TABLE "accounts"
+    DROP CONSTRAINT IF EXISTS "owner_currency_key";
+
+CREATE UNIQUE INDEX "owner_currency_key" ON "accounts" ("owner", "currency") WHERE "status" <> 'closed';
+
+CREATE INDEX ON "entries" ("account_id", "created_at");
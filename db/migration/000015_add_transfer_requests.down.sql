@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "transfer_requests";
+
+ALTER TABLE IF EXISTS "users"
+    DROP CONSTRAINT IF EXISTS "user_role_check";
+
+ALTER TABLE IF EXISTS "users"
+    DROP COLUMN IF EXISTS "role";
@@ -0,0 +1,61 @@
+ALTER TABLE "users"
+    ADD COLUMN "role" varchar NOT NULL DEFAULT 'customer';
+
+COMMENT
+ON COLUMN "users"."role" IS 'customer or officer, officers can approve transfer requests';
+
+ALTER TABLE "users"
+    ADD CONSTRAINT "user_role_check" CHECK ("role" IN ('customer', 'officer'));
+
+CREATE TABLE "transfer_requests"
+(
+    "id"              bigserial PRIMARY KEY,
+    "from_account_id" bigint      NOT NULL,
+    "to_account_id"   bigint      NOT NULL,
+    "amount"          bigint      NOT NULL,
+    "requested_by"    varchar     NOT NULL,
+    "status"          varchar     NOT NULL DEFAULT 'pending',
+    "expires_at"      timestamptz NOT NULL,
+    "decided_by"      varchar,
+    "decided_at"      timestamptz,
+    "reason"          varchar     NOT NULL DEFAULT '',
+    "transfer_id"     bigint,
+    "created_at"      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON "transfer_requests" ("requested_by");
+
+CREATE INDEX ON "transfer_requests" ("status", "expires_at");
+
+COMMENT
+ON COLUMN "transfer_requests"."amount" IS 'debited in the currency of the from account, must be positive';
+
+COMMENT
+ON COLUMN "transfer_requests"."status" IS 'pending, approved, rejected or expired';
+
+COMMENT
+ON COLUMN "transfer_requests"."decided_by" IS 'the user who approved or rejected the request, null while pending or once expired';
+
+COMMENT
+ON COLUMN "transfer_requests"."transfer_id" IS 'the transfer made when the request was approved';
+
+ALTER TABLE "transfer_requests"
+    ADD CONSTRAINT "transfer_request_check" CHECK (
+        "amount" > 0
+            AND "status" IN ('pending', 'approved', 'rejected', 'expired')
+        );
+
+ALTER TABLE "transfer_requests"
+    ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_requests"
+    ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_requests"
+    ADD FOREIGN KEY ("requested_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_requests"
+    ADD FOREIGN KEY ("decided_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_requests"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1,14 @@
+UPDATE "users"
+SET "role" = 'customer'
+WHERE "role" = 'admin';
+
+ALTER TABLE IF EXISTS "users"
+    DROP CONSTRAINT IF EXISTS "user_role_check";
+
+ALTER TABLE IF EXISTS "users"
+    ADD CONSTRAINT "user_role_check" CHECK ("role" IN ('customer', 'officer'));
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP CONSTRAINT IF EXISTS "accounts_currency_fkey";
+
+DROP TABLE IF EXISTS "currencies";
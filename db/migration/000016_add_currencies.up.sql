@@ -0,0 +1,40 @@
+CREATE TABLE "currencies"
+(
+    "code"       varchar PRIMARY KEY,
+    "exponent"   integer     NOT NULL,
+    "enabled"    boolean     NOT NULL DEFAULT true,
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    "updated_at" timestamptz NOT NULL DEFAULT now()
+);
+
+COMMENT
+ON COLUMN "currencies"."code" IS 'ISO 4217 alphabetic code';
+
+COMMENT
+ON COLUMN "currencies"."exponent" IS 'number of minor unit digits, 2 for USD and 0 for JPY';
+
+COMMENT
+ON COLUMN "currencies"."enabled" IS 'new accounts and transfers are only allowed in enabled currencies';
+
+ALTER TABLE "currencies"
+    ADD CONSTRAINT "currency_check" CHECK (
+        "code" ~ '^[A-Z]{3}$'
+            AND "exponent" BETWEEN 0 AND 4
+        );
+
+INSERT INTO "currencies" ("code", "exponent")
+VALUES ('USD', 2),
+       ('EUR', 2),
+       ('CAD', 2);
+
+ALTER TABLE "accounts"
+    ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "users"
+    DROP CONSTRAINT "user_role_check";
+
+ALTER TABLE "users"
+    ADD CONSTRAINT "user_role_check" CHECK ("role" IN ('customer', 'officer', 'admin'));
+
+COMMENT
+ON COLUMN "users"."role" IS 'customer, officer or admin, officers can approve transfer requests and admins manage currencies';
@@ -0,0 +1,24 @@
+ALTER TABLE "entries"
+    ADD COLUMN "balance_after" bigint;
+
+COMMENT
+ON COLUMN "entries"."balance_after" IS 'balance of the account right after the entry was booked';
+
+-- existing entries are worked back from the current balances, the way statements are
+WITH "running" AS (SELECT e."id",
+                          a."balance" - COALESCE(SUM(e."amount") OVER (
+                              PARTITION BY e."account_id"
+                              ORDER BY e."id"
+                              ROWS BETWEEN 1 FOLLOWING AND UNBOUNDED FOLLOWING
+                              ), 0) AS "balance_after"
+                   FROM "entries" e
+                            JOIN "accounts" a ON a."id" = e."account_id")
+UPDATE "entries"
+SET "balance_after" = "running"."balance_after"
+FROM "running"
+WHERE "entries"."id" = "running"."id";
+
+ALTER TABLE "entries"
+    ALTER COLUMN "balance_after" SET NOT NULL;
+
+CREATE INDEX ON "entries" ("account_id", "id");
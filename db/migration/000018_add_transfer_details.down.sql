@@ -0,0 +1,19 @@
+ALTER TABLE IF EXISTS "transfer_requests"
+    DROP COLUMN IF EXISTS "metadata";
+
+ALTER TABLE IF EXISTS "transfer_requests"
+    DROP COLUMN IF EXISTS "client_reference";
+
+ALTER TABLE IF EXISTS "transfer_requests"
+    DROP COLUMN IF EXISTS "memo";
+
+DROP INDEX IF EXISTS "transfer_client_reference_key";
+
+ALTER TABLE IF EXISTS "transfers"
+    DROP COLUMN IF EXISTS "metadata";
+
+ALTER TABLE IF EXISTS "transfers"
+    DROP COLUMN IF EXISTS "client_reference";
+
+ALTER TABLE IF EXISTS "transfers"
+    DROP COLUMN IF EXISTS "memo";
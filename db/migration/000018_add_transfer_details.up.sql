@@ -0,0 +1,32 @@
+ALTER TABLE "transfers"
+    ADD COLUMN "memo" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfers"
+    ADD COLUMN "client_reference" varchar;
+
+ALTER TABLE "transfers"
+    ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
+
+COMMENT
+ON COLUMN "transfers"."memo" IS 'free text from the sender, shown to both parties';
+
+COMMENT
+ON COLUMN "transfers"."client_reference" IS 'the sender''s own id for the transfer, unique per from account';
+
+COMMENT
+ON COLUMN "transfers"."metadata" IS 'a JSON object attached by the sender';
+
+ALTER TABLE "transfers"
+    ADD CONSTRAINT "transfer_metadata_check" CHECK (jsonb_typeof("metadata") = 'object');
+
+CREATE UNIQUE INDEX "transfer_client_reference_key" ON "transfers" ("from_account_id", "client_reference");
+
+-- a request carries the details to the transfer made when it is approved
+ALTER TABLE "transfer_requests"
+    ADD COLUMN "memo" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfer_requests"
+    ADD COLUMN "client_reference" varchar;
+
+ALTER TABLE "transfer_requests"
+    ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "account_members";
+
+DROP INDEX IF EXISTS "owner_currency_key";
+
+CREATE UNIQUE INDEX "owner_currency_key" ON "accounts" ("owner", "currency") WHERE "status" <> 'closed';
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP COLUMN IF EXISTS "joint";
@@ -0,0 +1,48 @@
+ALTER TABLE "accounts"
+    ADD COLUMN "joint" boolean NOT NULL DEFAULT false;
+
+COMMENT
+ON COLUMN "accounts"."joint" IS 'a joint account can be shared with other users through account_members';
+
+-- a user still has one personal account per currency, joint accounts are not limited
+DROP INDEX IF EXISTS "owner_currency_key";
+
+CREATE UNIQUE INDEX "owner_currency_key" ON "accounts" ("owner", "currency") WHERE "status" <> 'closed' AND NOT "joint";
+
+CREATE TABLE "account_members"
+(
+    "account_id"  bigint      NOT NULL,
+    "username"    varchar     NOT NULL,
+    "role"        varchar     NOT NULL,
+    "status"      varchar     NOT NULL DEFAULT 'invited',
+    "invited_by"  varchar     NOT NULL,
+    "accepted_at" timestamptz,
+    "created_at"  timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY ("account_id", "username")
+);
+
+COMMENT
+ON TABLE "account_members" IS 'the users a joint account is shared with, the holder in accounts.owner is always an owner and has no row';
+
+COMMENT
+ON COLUMN "account_members"."role" IS 'owner, can_transfer or view_only';
+
+COMMENT
+ON COLUMN "account_members"."status" IS 'invited until the user accepts, then active';
+
+ALTER TABLE "account_members"
+    ADD CONSTRAINT "account_member_check" CHECK (
+        "role" IN ('owner', 'can_transfer', 'view_only')
+            AND "status" IN ('invited', 'active')
+        );
+
+ALTER TABLE "account_members"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "account_members"
+    ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_members"
+    ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "account_members" ("username");
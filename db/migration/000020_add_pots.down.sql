@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "pots";
+
+DROP INDEX IF EXISTS "owner_currency_key";
+
+CREATE UNIQUE INDEX "owner_currency_key" ON "accounts" ("owner", "currency") WHERE "status" <> 'closed' AND NOT "joint";
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP COLUMN IF EXISTS "parent_account_id";
@@ -0,0 +1,41 @@
+ALTER TABLE "accounts"
+    ADD COLUMN "parent_account_id" bigint;
+
+ALTER TABLE "accounts"
+    ADD FOREIGN KEY ("parent_account_id") REFERENCES "accounts" ("id");
+
+COMMENT
+ON COLUMN "accounts"."parent_account_id" IS 'set on savings pots, the account a pot sets money aside from';
+
+CREATE INDEX ON "accounts" ("parent_account_id");
+
+-- pots hold the currency of their parent, they don't count as a second account in it
+DROP INDEX IF EXISTS "owner_currency_key";
+
+CREATE UNIQUE INDEX "owner_currency_key" ON "accounts" ("owner", "currency") WHERE "status" <> 'closed' AND NOT "joint" AND "parent_account_id" IS NULL;
+
+CREATE TABLE "pots"
+(
+    "account_id"  bigint PRIMARY KEY,
+    "name"        varchar     NOT NULL,
+    "goal_amount" bigint,
+    "target_date" date,
+    "round_up_to" bigint,
+    "created_at"  timestamptz NOT NULL DEFAULT now(),
+    "updated_at"  timestamptz NOT NULL DEFAULT now()
+);
+
+COMMENT
+ON TABLE "pots" IS 'the savings goal of a pot account, its balance is kept in accounts like any other account';
+
+COMMENT
+ON COLUMN "pots"."round_up_to" IS 'payments from the parent account are rounded up to a multiple of it and the difference is moved into the pot';
+
+ALTER TABLE "pots"
+    ADD CONSTRAINT "pot_check" CHECK (
+        ("goal_amount" IS NULL OR "goal_amount" > 0)
+            AND ("round_up_to" IS NULL OR "round_up_to" > 0)
+        );
+
+ALTER TABLE "pots"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
@@ -0,0 +1,2 @@
+-- sessions are listed and revoked per user
+CREATE INDEX ON "sessions" ("username", "created_at");
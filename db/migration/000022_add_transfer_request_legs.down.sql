@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "transfer_request_legs";
+
+DELETE
+FROM "transfer_requests"
+WHERE "to_account_id" IS NULL;
+
+ALTER TABLE IF EXISTS "transfer_requests"
+    ALTER COLUMN "to_account_id" SET NOT NULL;
@@ -0,0 +1,35 @@
+-- a request with several legs has no single to account, its legs are kept in transfer_request_legs
+ALTER TABLE "transfer_requests"
+    ALTER COLUMN "to_account_id" DROP NOT NULL;
+
+COMMENT
+ON COLUMN "transfer_requests"."to_account_id" IS 'null when the request pays several accounts, see transfer_request_legs';
+
+CREATE TABLE "transfer_request_legs"
+(
+    "id"                  bigserial PRIMARY KEY,
+    "transfer_request_id" bigint NOT NULL,
+    "to_account_id"       bigint NOT NULL,
+    "amount"              bigint NOT NULL,
+    "transfer_id"         bigint
+);
+
+CREATE INDEX ON "transfer_request_legs" ("transfer_request_id");
+
+COMMENT
+ON TABLE "transfer_request_legs" IS 'the accounts a multi transfer waiting for approval pays, in the order of the request';
+
+COMMENT
+ON COLUMN "transfer_request_legs"."transfer_id" IS 'the transfer made for the leg when the request was approved';
+
+ALTER TABLE "transfer_request_legs"
+    ADD CONSTRAINT "transfer_request_leg_check" CHECK ("amount" > 0);
+
+ALTER TABLE "transfer_request_legs"
+    ADD FOREIGN KEY ("transfer_request_id") REFERENCES "transfer_requests" ("id");
+
+ALTER TABLE "transfer_request_legs"
+    ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_request_legs"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS "transfers"
+    DROP COLUMN IF EXISTS "initiated_by";
@@ -0,0 +1,17 @@
+ALTER TABLE "transfers"
+    ADD COLUMN "initiated_by" varchar;
+
+ALTER TABLE "transfers"
+    ADD FOREIGN KEY ("initiated_by") REFERENCES "users" ("username");
+
+COMMENT
+ON COLUMN "transfers"."initiated_by" IS 'the user who made the transfer, null for the ones the bank makes; user transfer limits count against them';
+
+-- the transfers made so far are put on the owner of the from account, as the limits used to count them
+UPDATE "transfers" t
+SET "initiated_by" = a."owner"
+FROM "accounts" a
+WHERE a."id" = t."from_account_id"
+  AND a."owner" NOT IN ('bank_fee_income', 'bank_interest_expense');
+
+CREATE INDEX ON "transfers" ("initiated_by", "created_at");
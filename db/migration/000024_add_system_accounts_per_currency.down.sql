@@ -0,0 +1,6 @@
+-- only the accounts nothing was ever booked on can go, the seeded USD, EUR and CAD accounts stay
+DELETE
+FROM "accounts" a
+WHERE a."owner" IN ('bank_fee_income', 'bank_interest_expense')
+  AND a."currency" NOT IN ('USD', 'EUR', 'CAD')
+  AND NOT EXISTS (SELECT 1 FROM "entries" e WHERE e."account_id" = a."id");
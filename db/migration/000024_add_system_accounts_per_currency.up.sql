@@ -0,0 +1,18 @@
+-- currencies registered after 000016 got no fee income or interest expense account, give every currency both
+INSERT INTO "accounts" ("owner", "balance", "currency")
+SELECT 'bank_fee_income', 0, c."code"
+FROM "currencies" c
+WHERE NOT EXISTS (SELECT 1
+                  FROM "accounts" a
+                  WHERE a."owner" = 'bank_fee_income'
+                    AND a."currency" = c."code"
+                    AND a."status" <> 'closed');
+
+INSERT INTO "accounts" ("owner", "balance", "currency", "overdraft_limit")
+SELECT 'bank_interest_expense', 0, c."code", 9223372036854775807
+FROM "currencies" c
+WHERE NOT EXISTS (SELECT 1
+                  FROM "accounts" a
+                  WHERE a."owner" = 'bank_interest_expense'
+                    AND a."currency" = c."code"
+                    AND a."status" <> 'closed');
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS "accounts"
+    DROP COLUMN IF EXISTS "frozen_by";
@@ -0,0 +1,8 @@
+ALTER TABLE "accounts"
+    ADD COLUMN "frozen_by" varchar;
+
+ALTER TABLE "accounts"
+    ADD FOREIGN KEY ("frozen_by") REFERENCES "users" ("username");
+
+COMMENT
+ON COLUMN "accounts"."frozen_by" IS 'the user who froze the account, only they or an officer can unfreeze it';
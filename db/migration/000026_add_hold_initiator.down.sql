@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS "holds"
+    DROP COLUMN IF EXISTS "initiated_by";
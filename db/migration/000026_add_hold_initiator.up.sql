@@ -0,0 +1,14 @@
+ALTER TABLE "holds"
+    ADD COLUMN "initiated_by" varchar;
+
+ALTER TABLE "holds"
+    ADD FOREIGN KEY ("initiated_by") REFERENCES "users" ("username");
+
+COMMENT
+ON COLUMN "holds"."initiated_by" IS 'the user who authorized the hold, the capture counts against their transfer limits';
+
+UPDATE "holds" h
+SET "initiated_by" = a."owner"
+FROM "accounts" a
+WHERE a."id" = h."account_id"
+  AND a."owner" NOT IN ('bank_fee_income', 'bank_interest_expense');
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "transfer_requests_from_account_id_status_idx";
@@ -0,0 +1 @@
+CREATE INDEX "transfer_requests_from_account_id_status_idx" ON "transfer_requests" ("from_account_id", "status");
@@ -1,8 +1,10 @@
 -- name: CreateAccount :one
 INSERT INTO accounts (owner,
                       balance,
-                      currency)
-VALUES ($1, $2, $3) RETURNING *;
+                      currency,
+                      joint,
+                      parent_account_id)
+VALUES ($1, $2, $3, $4, $5) RETURNING *;
 
 -- name: GetAccount :one
 SELECT *
@@ -19,15 +21,31 @@ UPDATE;
 -- name: ListAccounts :many
 SELECT *
 FROM accounts
-WHERE owner = $1
+WHERE (owner = $1
+    OR id IN (SELECT account_id FROM account_members WHERE username = $1 AND status = 'active'))
+  AND parent_account_id IS NULL
 ORDER BY id LIMIT $2
 OFFSET $3;
 
+-- name: ListAccountsAfter :many
+SELECT *
+FROM accounts
+WHERE (owner = sqlc.arg(owner)
+    OR id IN (SELECT account_id FROM account_members WHERE username = sqlc.arg(owner) AND status = 'active'))
+  AND parent_account_id IS NULL
+  AND id > sqlc.arg(after_id)
+ORDER BY id LIMIT sqlc.arg(limit_count);
+
 -- name: UpdateAccount :one
 UPDATE accounts
 SET balance = $2
 WHERE id = $1 RETURNING *;
 
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+SET overdraft_limit = sqlc.arg(overdraft_limit)
+WHERE id = sqlc.arg(id) RETURNING *;
+
 -- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + sqlc.arg(amount)
@@ -37,3 +55,42 @@ WHERE id = sqlc.arg(id) RETURNING *;
 DELETE
 FROM accounts
 WHERE id = $1;
+
+-- name: GetAccountByOwner :one
+SELECT *
+FROM accounts
+WHERE owner = $1
+  AND currency = $2
+  AND status <> 'closed'
+  AND NOT joint
+  AND parent_account_id IS NULL LIMIT 1;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status            = sqlc.arg(status),
+    frozen_by         = sqlc.narg(frozen_by),
+    status_changed_at = now()
+WHERE id = sqlc.arg(id) RETURNING *;
+
+-- name: CloseAccount :one
+UPDATE accounts
+SET status              = 'closed',
+    status_changed_at   = now(),
+    overdraft_limit     = 0,
+    interest_product_id = NULL
+WHERE id = sqlc.arg(id) RETURNING *;
+
+-- name: MarkDormantAccounts :many
+UPDATE accounts
+SET status            = 'dormant',
+    status_changed_at = now()
+WHERE status = 'active'
+  AND status_changed_at < sqlc.arg(inactive_since)
+  AND created_at < sqlc.arg(inactive_since)
+  AND owner <> sqlc.arg(fee_owner)
+  AND owner <> sqlc.arg(expense_owner)
+  AND parent_account_id IS NULL
+  AND NOT EXISTS(SELECT 1
+                 FROM entries
+                 WHERE entries.account_id = accounts.id
+                   AND entries.created_at >= sqlc.arg(inactive_since)) RETURNING *;
@@ -0,0 +1,39 @@
+-- name: CreateAccountMember :one
+INSERT INTO account_members (account_id,
+                             username,
+                             role,
+                             invited_by)
+VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: GetAccountMember :one
+SELECT *
+FROM account_members
+WHERE account_id = $1
+  AND username = $2 LIMIT 1;
+
+-- name: ListAccountMembers :many
+SELECT *
+FROM account_members
+WHERE account_id = $1
+ORDER BY created_at, username;
+
+-- name: ListAccountInvitations :many
+SELECT *
+FROM account_members
+WHERE username = $1
+  AND status = 'invited'
+ORDER BY created_at;
+
+-- name: AcceptAccountMember :one
+UPDATE account_members
+SET status      = 'active',
+    accepted_at = now()
+WHERE account_id = sqlc.arg(account_id)
+  AND username = sqlc.arg(username)
+  AND status = 'invited' RETURNING *;
+
+-- name: DeleteAccountMember :one
+DELETE
+FROM account_members
+WHERE account_id = sqlc.arg(account_id)
+  AND username = sqlc.arg(username) RETURNING *;
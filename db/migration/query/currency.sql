@@ -0,0 +1,21 @@
+-- name: CreateCurrency :one
+INSERT INTO currencies (code,
+                        exponent,
+                        enabled)
+VALUES ($1, $2, $3) RETURNING *;
+
+-- name: GetCurrency :one
+SELECT *
+FROM currencies
+WHERE code = $1 LIMIT 1;
+
+-- name: ListCurrencies :many
+SELECT *
+FROM currencies
+ORDER BY code;
+
+-- name: UpdateCurrency :one
+UPDATE currencies
+SET enabled    = sqlc.arg(enabled),
+    updated_at = now()
+WHERE code = sqlc.arg(code) RETURNING *;
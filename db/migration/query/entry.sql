@@ -1,7 +1,9 @@
 -- name: CreateEntry :one
 INSERT INTO entries (account_id,
-                     amount)
-VALUES ($1, $2) RETURNING *;
+                     amount,
+                     transfer_id,
+                     balance_after)
+VALUES ($1, $2, $3, $4) RETURNING *;
 
 -- name: GetEntry :one
 SELECT *
@@ -14,3 +16,100 @@ FROM entries
 WHERE account_id = $1
 ORDER BY id LIMIT $2
 OFFSET $3;
+
+-- name: ListEntriesAfter :many
+SELECT *
+FROM entries
+WHERE account_id = sqlc.arg(account_id)
+  AND id > sqlc.arg(after_id)
+ORDER BY id LIMIT sqlc.arg(limit_count);
+
+-- name: GetEntriesTotalSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = sqlc.arg(account_id)
+  AND created_at >= sqlc.arg(since);
+
+-- name: ListStatementEntries :many
+SELECT e.id,
+       e.account_id,
+       e.amount,
+       e.created_at,
+       e.transfer_id,
+       t.from_account_id,
+       t.to_account_id,
+       c.owner AS counterparty_owner
+FROM entries e
+         LEFT JOIN transfers t ON t.id = e.transfer_id
+         LEFT JOIN accounts c ON c.id = CASE
+                                            WHEN t.from_account_id = e.account_id THEN t.to_account_id
+                                            ELSE t.from_account_id END
+WHERE e.account_id = sqlc.arg(account_id)
+  AND e.created_at >= sqlc.arg(from_time)
+  AND e.created_at < sqlc.arg(to_time)
+ORDER BY e.created_at, e.id;
+
+-- name: ListAccountHistory :many
+SELECT e.id,
+       e.account_id,
+       e.amount,
+       e.created_at,
+       e.transfer_id,
+       e.balance_after,
+       c.id                              AS counterparty_account_id,
+       c.owner                           AS counterparty_owner,
+       COALESCE(t.memo, '')::varchar     AS memo,
+       t.client_reference,
+       COALESCE(t.metadata, '{}')::jsonb AS metadata
+FROM entries e
+         LEFT JOIN transfers t ON t.id = e.transfer_id
+         LEFT JOIN accounts c ON c.id = CASE
+                                            WHEN t.from_account_id = e.account_id THEN t.to_account_id
+                                            ELSE t.from_account_id END
+WHERE e.account_id = sqlc.arg(account_id)
+  AND (sqlc.narg(before_id)::bigint IS NULL OR e.id < sqlc.narg(before_id))
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR e.created_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR e.created_at < sqlc.narg(to_time))
+  AND (sqlc.narg(min_amount)::bigint IS NULL OR abs(e.amount) >= sqlc.narg(min_amount))
+  AND (sqlc.narg(max_amount)::bigint IS NULL OR abs(e.amount) <= sqlc.narg(max_amount))
+  AND (sqlc.narg(direction)::varchar IS NULL
+    OR (sqlc.narg(direction) = 'in' AND e.amount > 0)
+    OR (sqlc.narg(direction) = 'out' AND e.amount < 0))
+  AND (sqlc.narg(counterparty_account_id)::bigint IS NULL OR c.id = sqlc.narg(counterparty_account_id))
+  AND (sqlc.narg(client_reference)::varchar IS NULL OR t.client_reference = sqlc.narg(client_reference))
+ORDER BY e.id DESC LIMIT sqlc.arg(limit_count);
+
+-- name: GetBalanceAsOf :one
+SELECT COALESCE(
+               (SELECT balance_after
+                FROM entries
+                WHERE account_id = sqlc.arg(account_id)
+                  AND created_at <= sqlc.arg(as_of)
+                ORDER BY id DESC LIMIT 1),
+               (SELECT balance_after - amount
+                FROM entries
+                WHERE account_id = sqlc.arg(account_id)
+                ORDER BY id LIMIT 1),
+               (SELECT balance
+                FROM accounts
+                WHERE id = sqlc.arg(account_id))
+       )::bigint AS balance;
+
+-- name: ListDailyBalances :many
+SELECT d.day::date AS day,
+       COALESCE(
+               (SELECT balance_after
+                FROM entries
+                WHERE account_id = sqlc.arg(account_id)
+                  AND created_at < (d.day + interval '1 day') AT TIME ZONE 'UTC'
+                ORDER BY id DESC LIMIT 1),
+               (SELECT balance_after - amount
+                FROM entries
+                WHERE account_id = sqlc.arg(account_id)
+                ORDER BY id LIMIT 1),
+               (SELECT balance
+                FROM accounts
+                WHERE id = sqlc.arg(account_id))
+       )::bigint AS balance
+FROM generate_series(sqlc.arg(from_day)::date, sqlc.arg(to_day)::date, interval '1 day') AS d(day)
+ORDER BY d.day;
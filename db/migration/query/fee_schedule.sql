@@ -0,0 +1,17 @@
+-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (currency,
+                           transfer_type,
+                           kind,
+                           flat_amount,
+                           percentage_bps,
+                           tiers,
+                           min_fee,
+                           max_fee)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *;
+
+-- name: GetFeeSchedule :one
+SELECT *
+FROM fee_schedules
+WHERE currency = $1
+  AND transfer_type = $2
+ORDER BY created_at DESC LIMIT 1;
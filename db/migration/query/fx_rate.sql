@@ -0,0 +1,13 @@
+-- name: CreateFxRate :one
+INSERT INTO fx_rates (from_currency,
+                      to_currency,
+                      rate,
+                      spread_bps)
+VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: GetLatestFxRate :one
+SELECT *
+FROM fx_rates
+WHERE from_currency = $1
+  AND to_currency = $2
+ORDER BY created_at DESC, id DESC LIMIT 1;
@@ -0,0 +1,48 @@
+-- name: CreateHold :one
+INSERT INTO holds (account_id,
+                   to_account_id,
+                   amount,
+                   expires_at,
+                   initiated_by)
+VALUES ($1, $2, $3, $4, $5) RETURNING *;
+
+-- name: GetHold :one
+SELECT *
+FROM holds
+WHERE id = $1 LIMIT 1;
+
+-- name: GetHoldForUpdate :one
+SELECT *
+FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY
+UPDATE;
+
+-- name: ListHolds :many
+SELECT *
+FROM holds
+WHERE account_id = $1
+ORDER BY id LIMIT $2
+OFFSET $3;
+
+-- name: GetHeldAmount :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS held_amount
+FROM holds
+WHERE account_id = sqlc.arg(account_id)
+  AND status = 'active'
+  AND expires_at > sqlc.arg(now);
+
+-- name: UpdateHold :one
+UPDATE holds
+SET status          = sqlc.arg(status),
+    captured_amount = sqlc.arg(captured_amount),
+    transfer_id     = sqlc.narg(transfer_id),
+    updated_at      = now()
+WHERE id = sqlc.arg(id) RETURNING *;
+
+-- name: ExpireHolds :many
+UPDATE holds
+SET status     = 'expired',
+    updated_at = now()
+WHERE status = 'active'
+  AND expires_at <= sqlc.arg(now) RETURNING *;
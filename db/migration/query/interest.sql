@@ -0,0 +1,89 @@
+-- name: CreateInterestProduct :one
+INSERT INTO interest_products (name,
+                               currency,
+                               annual_rate_bps)
+VALUES ($1, $2, $3) RETURNING *;
+
+-- name: GetInterestProduct :one
+SELECT *
+FROM interest_products
+WHERE id = $1 LIMIT 1;
+
+-- name: ListInterestProducts :many
+SELECT *
+FROM interest_products
+WHERE currency = $1
+ORDER BY id;
+
+-- name: UpdateAccountInterestProduct :one
+UPDATE accounts
+SET interest_product_id = sqlc.narg(interest_product_id)
+WHERE id = sqlc.arg(id) RETURNING *;
+
+-- name: AccrueInterest :many
+INSERT INTO interest_accruals (account_id,
+                               accrual_date,
+                               balance,
+                               annual_rate_bps,
+                               amount)
+SELECT b.id,
+       sqlc.arg(accrual_date)::date,
+       b.balance,
+       b.annual_rate_bps,
+       b.balance::numeric * b.annual_rate_bps / 10000 / 365
+FROM (SELECT a.id,
+             a.balance - COALESCE((SELECT SUM(e.amount)
+                                   FROM entries e
+                                   WHERE e.account_id = a.id
+                                     AND e.created_at >= sqlc.arg(day_end)), 0) AS balance,
+             p.annual_rate_bps
+      FROM accounts a
+               JOIN interest_products p ON p.id = a.interest_product_id) b
+WHERE b.balance > 0
+ON CONFLICT (account_id, accrual_date) DO NOTHING
+RETURNING *;
+
+-- name: ListAccountsWithUnpostedInterest :many
+SELECT DISTINCT interest_accruals.account_id
+FROM interest_accruals
+         JOIN accounts ON accounts.id = interest_accruals.account_id
+WHERE interest_accruals.posting_id IS NULL
+  AND interest_accruals.accrual_date < sqlc.arg(before)::date
+  AND accounts.status <> 'closed'
+ORDER BY interest_accruals.account_id;
+
+-- name: GetUnpostedInterest :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS accrued
+FROM interest_accruals
+WHERE account_id = sqlc.arg(account_id)
+  AND posting_id IS NULL
+  AND accrual_date < sqlc.arg(before)::date;
+
+-- name: MarkInterestPosted :exec
+UPDATE interest_accruals
+SET posting_id = sqlc.arg(posting_id)
+WHERE account_id = sqlc.arg(account_id)
+  AND posting_id IS NULL
+  AND accrual_date < sqlc.arg(before)::date;
+
+-- name: GetLastInterestPosting :one
+SELECT *
+FROM interest_postings
+WHERE account_id = $1
+ORDER BY period DESC LIMIT 1;
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (account_id,
+                               period,
+                               accrued,
+                               amount,
+                               carried,
+                               transfer_id)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
+
+-- name: ListInterestPostings :many
+SELECT *
+FROM interest_postings
+WHERE account_id = $1
+ORDER BY period DESC LIMIT $2
+OFFSET $3;
@@ -0,0 +1,51 @@
+-- name: CreatePot :one
+INSERT INTO pots (account_id,
+                  name,
+                  goal_amount,
+                  target_date,
+                  round_up_to)
+VALUES ($1, $2, $3, $4, $5) RETURNING *;
+
+-- name: GetPot :one
+SELECT *
+FROM pots
+WHERE account_id = $1 LIMIT 1;
+
+-- name: UpdatePot :one
+UPDATE pots
+SET name        = sqlc.arg(name),
+    goal_amount = sqlc.narg(goal_amount),
+    target_date = sqlc.narg(target_date),
+    round_up_to = sqlc.narg(round_up_to),
+    updated_at  = now()
+WHERE account_id = sqlc.arg(account_id) RETURNING *;
+
+-- name: ListPots :many
+SELECT p.account_id,
+       p.name,
+       p.goal_amount,
+       p.target_date,
+       p.round_up_to,
+       p.created_at,
+       a.balance,
+       a.currency,
+       a.status
+FROM pots p
+         JOIN accounts a ON a.id = p.account_id
+WHERE a.parent_account_id = $1
+  AND a.status <> 'closed'
+ORDER BY p.account_id;
+
+-- name: GetRoundUpPot :one
+SELECT p.*
+FROM pots p
+         JOIN accounts a ON a.id = p.account_id
+WHERE a.parent_account_id = $1
+  AND a.status <> 'closed'
+  AND p.round_up_to IS NOT NULL LIMIT 1;
+
+-- name: CountOpenPots :one
+SELECT COUNT(*)
+FROM accounts
+WHERE parent_account_id = $1
+  AND status <> 'closed';
@@ -0,0 +1,43 @@
+-- name: ListDriftedAccounts :many
+SELECT a.id                               AS account_id,
+       a.balance,
+       COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM accounts a
+         LEFT JOIN entries e ON e.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY a.id;
+
+-- name: ListOrphanedEntries :many
+SELECT *
+FROM entries
+WHERE transfer_id IS NULL
+ORDER BY id;
+
+-- name: ListUnbalancedTransfers :many
+SELECT t.id                               AS transfer_id,
+       t.amount,
+       t.to_amount,
+       COUNT(e.id)                        AS entry_count,
+       COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM transfers t
+         LEFT JOIN entries e ON e.transfer_id = t.id
+GROUP BY t.id
+HAVING COUNT(e.id) <> 2
+    OR COALESCE(SUM(e.amount), 0) <> t.to_amount - t.amount
+ORDER BY t.id;
+
+-- name: CreateReconciliationRun :one
+INSERT INTO reconciliation_runs (status,
+                                 drifted_accounts,
+                                 orphaned_entries,
+                                 unbalanced_transfers,
+                                 report,
+                                 started_at)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
+
+-- name: ListReconciliationRuns :many
+SELECT *
+FROM reconciliation_runs
+ORDER BY id DESC LIMIT $1
+OFFSET $2;
@@ -12,3 +12,32 @@ VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
 SELECT *
 FROM sessions
 WHERE id = $1 LIMIT 1;
+
+-- name: ListActiveSessions :many
+SELECT *
+FROM sessions
+WHERE username = $1
+  AND is_blocked = false
+  AND expired_at > now()
+ORDER BY created_at DESC;
+
+-- name: BlockSession :one
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1
+  AND username = $2 RETURNING *;
+
+-- name: BlockOtherSessions :many
+UPDATE sessions
+SET is_blocked = true
+WHERE username = sqlc.arg(username)
+  AND id <> sqlc.arg(keep_id)
+  AND is_blocked = false
+  AND expired_at > now() RETURNING *;
+
+-- name: RotateSessionRefreshToken :one
+UPDATE sessions
+SET refresh_token = sqlc.arg(new_refresh_token)
+WHERE id = sqlc.arg(id)
+  AND refresh_token = sqlc.arg(old_refresh_token)
+  AND is_blocked = false RETURNING *;
@@ -0,0 +1,62 @@
+-- name: CreateStandingOrder :one
+INSERT INTO standing_orders (owner,
+                             from_account_id,
+                             to_account_id,
+                             amount,
+                             schedule,
+                             cron_expression,
+                             start_at,
+                             end_at,
+                             next_run_at,
+                             failure_policy,
+                             max_retries)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING *;
+
+-- name: GetStandingOrder :one
+SELECT *
+FROM standing_orders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetStandingOrderForUpdate :one
+SELECT *
+FROM standing_orders
+WHERE id = $1 LIMIT 1
+FOR NO KEY
+UPDATE;
+
+-- name: ListStandingOrders :many
+SELECT *
+FROM standing_orders
+WHERE owner = $1
+ORDER BY id LIMIT $2
+OFFSET $3;
+
+-- name: ListDueStandingOrders :many
+SELECT *
+FROM standing_orders
+WHERE status = 'active'
+  AND next_run_at <= sqlc.arg(now)
+ORDER BY next_run_at LIMIT sqlc.arg(limit_count);
+
+-- name: UpdateStandingOrder :one
+UPDATE standing_orders
+SET amount         = COALESCE(sqlc.narg(amount), amount),
+    failure_policy = COALESCE(sqlc.narg(failure_policy), failure_policy),
+    max_retries    = COALESCE(sqlc.narg(max_retries), max_retries),
+    status         = COALESCE(sqlc.narg(status), status),
+    updated_at     = now()
+WHERE id = sqlc.arg(id) RETURNING *;
+
+-- name: AdvanceStandingOrder :one
+UPDATE standing_orders
+SET next_run_at = $2,
+    status      = $3,
+    updated_at  = now()
+WHERE id = $1 RETURNING *;
+
+-- name: CancelAccountStandingOrders :many
+UPDATE standing_orders
+SET status     = 'cancelled',
+    updated_at = now()
+WHERE status IN ('active', 'paused')
+  AND (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id)) RETURNING *;
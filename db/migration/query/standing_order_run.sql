@@ -0,0 +1,15 @@
+-- name: CreateStandingOrderRun :one
+INSERT INTO standing_order_runs (standing_order_id,
+                                 scheduled_at,
+                                 attempt,
+                                 status,
+                                 transfer_id,
+                                 error)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
+
+-- name: ListStandingOrderRuns :many
+SELECT *
+FROM standing_order_runs
+WHERE standing_order_id = $1
+ORDER BY id LIMIT $2
+OFFSET $3;
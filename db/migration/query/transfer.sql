@@ -1,14 +1,29 @@
 -- name: CreateTransfer :one
 INSERT INTO transfers (from_account_id,
                        to_account_id,
-                       amount)
-VALUES ($1, $2, $3) RETURNING *;
+                       amount,
+                       to_amount,
+                       fx_rate,
+                       fx_spread_bps,
+                       reversal_of,
+                       memo,
+                       client_reference,
+                       metadata,
+                       initiated_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING *;
 
 -- name: GetTransfer :one
 SELECT *
 FROM transfers
 WHERE id = $1 LIMIT 1;
 
+-- name: GetTransferForUpdate :one
+SELECT *
+FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY
+UPDATE;
+
 -- name: ListTransfers :many
 SELECT *
 FROM transfers
@@ -16,3 +31,15 @@ WHERE from_account_id = $1
    OR to_account_id = $2
 ORDER BY id LIMIT $3
 OFFSET $4;
+
+-- name: ListTransfersAfter :many
+SELECT *
+FROM transfers
+WHERE (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id))
+  AND id > sqlc.arg(after_id)
+ORDER BY id LIMIT sqlc.arg(limit_count);
+
+-- name: MarkTransferReversed :one
+UPDATE transfers
+SET reversed_amount = sqlc.arg(reversed_amount)
+WHERE id = sqlc.arg(id) RETURNING *;
@@ -0,0 +1,70 @@
+-- name: CreateTransferLimit :one
+INSERT INTO transfer_limits (scope,
+                             currency,
+                             username,
+                             max_single,
+                             daily_total,
+                             monthly_total,
+                             hourly_count)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
+
+-- name: GetTransferLimit :one
+SELECT *
+FROM transfer_limits
+WHERE id = $1 LIMIT 1;
+
+-- name: ListAllTransferLimits :many
+SELECT *
+FROM transfer_limits
+ORDER BY scope, currency, username NULLS FIRST;
+
+-- name: UpdateTransferLimit :one
+UPDATE transfer_limits
+SET max_single    = sqlc.narg(max_single),
+    daily_total   = sqlc.narg(daily_total),
+    monthly_total = sqlc.narg(monthly_total),
+    hourly_count  = sqlc.narg(hourly_count),
+    updated_at    = now()
+WHERE id = sqlc.arg(id) RETURNING *;
+
+-- name: DeleteTransferLimit :one
+DELETE
+FROM transfer_limits
+WHERE id = $1 RETURNING *;
+
+-- name: ListTransferLimits :many
+SELECT *
+FROM transfer_limits
+WHERE scope = sqlc.arg(scope)
+  AND currency = sqlc.arg(currency)
+  AND (username IS NULL OR username = sqlc.arg(username))
+ORDER BY username NULLS FIRST;
+
+-- name: GetAccountTransferUsage :one
+SELECT COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(day_start)), 0)::bigint  AS daily_total,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(month_start)), 0)::bigint AS monthly_total,
+       COUNT(*) FILTER (WHERE t.created_at >= sqlc.arg(hour_start))                          AS hourly_count,
+       COALESCE(MIN(t.created_at) FILTER (WHERE t.created_at >= sqlc.arg(hour_start)),
+                sqlc.arg(now))::timestamptz                                                  AS hourly_first_at
+FROM transfers t
+         JOIN accounts dest ON dest.id = t.to_account_id
+WHERE t.from_account_id = sqlc.arg(account_id)
+  AND t.created_at >= LEAST(sqlc.arg(month_start)::timestamptz, sqlc.arg(hour_start)::timestamptz)
+  AND dest.owner <> sqlc.arg(fee_owner)
+  AND dest.parent_account_id IS DISTINCT FROM t.from_account_id;
+
+-- name: GetUserTransferUsage :one
+SELECT COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(day_start)), 0)::bigint  AS daily_total,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(month_start)), 0)::bigint AS monthly_total,
+       COUNT(*) FILTER (WHERE t.created_at >= sqlc.arg(hour_start))                          AS hourly_count,
+       COALESCE(MIN(t.created_at) FILTER (WHERE t.created_at >= sqlc.arg(hour_start)),
+                sqlc.arg(now))::timestamptz                                                  AS hourly_first_at
+FROM transfers t
+         JOIN accounts src ON src.id = t.from_account_id
+         JOIN accounts dest ON dest.id = t.to_account_id
+WHERE t.initiated_by = sqlc.arg(initiated_by)
+  AND src.currency = sqlc.arg(currency)
+  AND t.created_at >= LEAST(sqlc.arg(month_start)::timestamptz, sqlc.arg(hour_start)::timestamptz)
+  AND dest.owner <> sqlc.arg(fee_owner)
+  AND src.parent_account_id IS NULL
+  AND dest.parent_account_id IS DISTINCT FROM src.id;
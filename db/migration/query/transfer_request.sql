@@ -0,0 +1,78 @@
+-- name: CreateTransferRequest :one
+INSERT INTO transfer_requests (from_account_id,
+                               to_account_id,
+                               amount,
+                               requested_by,
+                               expires_at,
+                               memo,
+                               client_reference,
+                               metadata)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *;
+
+-- name: GetTransferRequest :one
+SELECT *
+FROM transfer_requests
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferRequestForUpdate :one
+SELECT *
+FROM transfer_requests
+WHERE id = $1 LIMIT 1
+FOR NO KEY
+UPDATE;
+
+-- name: ListTransferRequests :many
+SELECT *
+FROM transfer_requests
+WHERE requested_by = $1
+ORDER BY id DESC LIMIT $2
+OFFSET $3;
+
+-- name: ListPendingTransferRequests :many
+SELECT *
+FROM transfer_requests
+WHERE status = 'pending'
+  AND expires_at > sqlc.arg(now)
+ORDER BY id LIMIT sqlc.arg(limit_count)
+OFFSET sqlc.arg(offset_count);
+
+-- name: ListAccountPendingTransferRequestsAfter :many
+SELECT *
+FROM transfer_requests
+WHERE from_account_id = sqlc.arg(from_account_id)
+  AND status = 'pending'
+  AND expires_at > sqlc.arg(now)
+  AND id > sqlc.arg(after_id)
+ORDER BY id LIMIT sqlc.arg(limit_count);
+
+-- name: DecideTransferRequest :one
+UPDATE transfer_requests
+SET status      = sqlc.arg(status),
+    decided_by  = sqlc.arg(decided_by),
+    decided_at  = now(),
+    reason      = sqlc.arg(reason),
+    transfer_id = sqlc.narg(transfer_id)
+WHERE id = sqlc.arg(id) RETURNING *;
+
+-- name: ExpireTransferRequests :many
+UPDATE transfer_requests
+SET status = 'expired'
+WHERE status = 'pending'
+  AND expires_at <= sqlc.arg(now) RETURNING *;
+
+-- name: CreateTransferRequestLeg :one
+INSERT INTO transfer_request_legs (transfer_request_id,
+                                   to_account_id,
+                                   amount)
+VALUES ($1, $2, $3) RETURNING *;
+
+-- name: ListTransferRequestLegs :many
+SELECT *
+FROM transfer_request_legs
+WHERE transfer_request_id = $1
+ORDER BY id;
+
+-- name: SetTransferRequestLegTransfer :one
+UPDATE transfer_request_legs
+SET transfer_id = sqlc.arg(transfer_id)
+WHERE id = sqlc.arg(id) RETURNING *;
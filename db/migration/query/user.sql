@@ -16,4 +16,16 @@ SET hashed_password     = COALESCE(sqlc.narg(hashed_password), hashed_password),
     password_changed_at = COALESCE(sqlc.narg(password_changed_at), password_changed_at),
     full_name           = COALESCE(sqlc.narg(full_name), full_name),
     email               = COALESCE(sqlc.narg(email), email)
-WHERE username = sqlc.arg(username) RETURNING *;
\ No newline at end of file
+WHERE username = sqlc.arg(username) RETURNING *;
+
+-- name: GetUserForUpdate :one
+SELECT *
+FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY
+UPDATE;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET role = sqlc.arg(role)
+WHERE username = sqlc.arg(username) RETURNING *;
@@ -7,12 +7,14 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $1
-WHERE id = $2 RETURNING id, owner, balance, currency, created_at
+WHERE id = $2 RETURNING id, owner, balance, currency, created_at, overdraft_limit, interest_product_id, status, status_changed_at, joint, parent_account_id, frozen_by
 `
 
 type AddAccountBalanceParams struct {
@@ -29,6 +31,42 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.InterestProductID,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.Joint,
+		&i.ParentAccountID,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const closeAccount = `-- name: CloseAccount :one
+UPDATE accounts
+SET status              = 'closed',
+    status_changed_at   = now(),
+    overdraft_limit     = 0,
+    interest_product_id = NULL
+WHERE id = $1 RETURNING id, owner, balance, currency, created_at, overdraft_limit, interest_product_id, status, status_changed_at, joint, parent_account_id, frozen_by
+`
+
+func (q *Queries) CloseAccount(ctx context.Context, id int64) (Account, error) {
+	row := q.db.QueryRowContext(ctx, closeAccount, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.InterestProductID,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.Joint,
+		&i.ParentAccountID,
+		&i.FrozenBy,
 	)
 	return i, err
 }
@@ -36,18 +74,28 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (owner,
                       balance,
-                      currency)
-VALUES ($1, $2, $3) RETURNING id, owner, balance, currency, created_at
+                      currency,
+                      joint,
+                      parent_account_id)
+VALUES ($1, $2, $3, $4, $5) RETURNING id, owner, balance, currency, created_at, overdraft_limit, interest_product_id, status, status_changed_at, joint, parent_account_id, frozen_by
 `
 
 type CreateAccountParams struct {
-	Owner    string `json:"owner"`
-	Balance  int64  `json:"balance"`
-	Currency string `json:"currency"`
+	Owner           string        `json:"owner"`
+	Balance         int64         `json:"balance"`
+	Currency        string        `json:"currency"`
+	Joint           bool          `json:"joint"`
+	ParentAccountID sql.NullInt64 `json:"parent_account_id"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRowContext(ctx, createAccount,
+		arg.Owner,
+		arg.Balance,
+		arg.Currency,
+		arg.Joint,
+		arg.ParentAccountID,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -55,6 +103,13 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.InterestProductID,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.Joint,
+		&i.ParentAccountID,
+		&i.FrozenBy,
 	)
 	return i, err
 }
@@ -71,7 +126,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, overdraft_limit, interest_product_id, status, status_changed_at, joint, parent_account_id, frozen_by
 FROM accounts
 WHERE id = $1 LIMIT 1
 `
@@ -85,12 +140,54 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.InterestProductID,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.Joint,
+		&i.ParentAccountID,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const getAccountByOwner = `-- name: GetAccountByOwner :one
+SELECT id, owner, balance, currency, created_at, overdraft_limit, interest_product_id, status, status_changed_at, joint, parent_account_id, frozen_by
+FROM accounts
+WHERE owner = $1
+  AND currency = $2
+  AND status <> 'closed'
+  AND NOT joint
+  AND parent_account_id IS NULL LIMIT 1
+`
+
+type GetAccountByOwnerParams struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) GetAccountByOwner(ctx context.Context, arg GetAccountByOwnerParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByOwner, arg.Owner, arg.Currency)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.InterestProductID,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.Joint,
+		&i.ParentAccountID,
+		&i.FrozenBy,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, overdraft_limit, interest_product_id, status, status_changed_at, joint, parent_account_id, frozen_by
 FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY
@@ -106,14 +203,23 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.InterestProductID,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.Joint,
+		&i.ParentAccountID,
+		&i.FrozenBy,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, overdraft_limit, interest_product_id, status, status_changed_at, joint, parent_account_id, frozen_by
 FROM accounts
-WHERE owner = $1
+WHERE (owner = $1
+    OR id IN (SELECT account_id FROM account_members WHERE username = $1 AND status = 'active'))
+  AND parent_account_id IS NULL
 ORDER BY id LIMIT $2
 OFFSET $3
 `
@@ -139,6 +245,123 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.OverdraftLimit,
+			&i.InterestProductID,
+			&i.Status,
+			&i.StatusChangedAt,
+			&i.Joint,
+			&i.ParentAccountID,
+			&i.FrozenBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsAfter = `-- name: ListAccountsAfter :many
+SELECT id, owner, balance, currency, created_at, overdraft_limit, interest_product_id, status, status_changed_at, joint, parent_account_id, frozen_by
+FROM accounts
+WHERE (owner = $1
+    OR id IN (SELECT account_id FROM account_members WHERE username = $1 AND status = 'active'))
+  AND parent_account_id IS NULL
+  AND id > $2
+ORDER BY id LIMIT $3
+`
+
+type ListAccountsAfterParams struct {
+	Owner      string `json:"owner"`
+	AfterID    int64  `json:"after_id"`
+	LimitCount int32  `json:"limit_count"`
+}
+
+func (q *Queries) ListAccountsAfter(ctx context.Context, arg ListAccountsAfterParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsAfter, arg.Owner, arg.AfterID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.OverdraftLimit,
+			&i.InterestProductID,
+			&i.Status,
+			&i.StatusChangedAt,
+			&i.Joint,
+			&i.ParentAccountID,
+			&i.FrozenBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markDormantAccounts = `-- name: MarkDormantAccounts :many
+UPDATE accounts
+SET status            = 'dormant',
+    status_changed_at = now()
+WHERE status = 'active'
+  AND status_changed_at < $1
+  AND created_at < $1
+  AND owner <> $2
+  AND owner <> $3
+  AND parent_account_id IS NULL
+  AND NOT EXISTS(SELECT 1
+                 FROM entries
+                 WHERE entries.account_id = accounts.id
+                   AND entries.created_at >= $1) RETURNING id, owner, balance, currency, created_at, overdraft_limit, interest_product_id, status, status_changed_at, joint, parent_account_id, frozen_by
+`
+
+type MarkDormantAccountsParams struct {
+	InactiveSince time.Time `json:"inactive_since"`
+	FeeOwner      string    `json:"fee_owner"`
+	ExpenseOwner  string    `json:"expense_owner"`
+}
+
+func (q *Queries) MarkDormantAccounts(ctx context.Context, arg MarkDormantAccountsParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, markDormantAccounts, arg.InactiveSince, arg.FeeOwner, arg.ExpenseOwner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.OverdraftLimit,
+			&i.InterestProductID,
+			&i.Status,
+			&i.StatusChangedAt,
+			&i.Joint,
+			&i.ParentAccountID,
+			&i.FrozenBy,
 		); err != nil {
 			return nil, err
 		}
@@ -156,7 +379,7 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
 SET balance = $2
-WHERE id = $1 RETURNING id, owner, balance, currency, created_at
+WHERE id = $1 RETURNING id, owner, balance, currency, created_at, overdraft_limit, interest_product_id, status, status_changed_at, joint, parent_account_id, frozen_by
 `
 
 type UpdateAccountParams struct {
@@ -173,6 +396,78 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.InterestProductID,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.Joint,
+		&i.ParentAccountID,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const updateAccountOverdraftLimit = `-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+SET overdraft_limit = $1
+WHERE id = $2 RETURNING id, owner, balance, currency, created_at, overdraft_limit, interest_product_id, status, status_changed_at, joint, parent_account_id, frozen_by
+`
+
+type UpdateAccountOverdraftLimitParams struct {
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	ID             int64 `json:"id"`
+}
+
+func (q *Queries) UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountOverdraftLimit, arg.OverdraftLimit, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.InterestProductID,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.Joint,
+		&i.ParentAccountID,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status            = $1,
+    frozen_by         = $2,
+    status_changed_at = now()
+WHERE id = $3 RETURNING id, owner, balance, currency, created_at, overdraft_limit, interest_product_id, status, status_changed_at, joint, parent_account_id, frozen_by
+`
+
+type UpdateAccountStatusParams struct {
+	Status   string         `json:"status"`
+	FrozenBy sql.NullString `json:"frozen_by"`
+	ID       int64          `json:"id"`
+}
+
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountStatus, arg.Status, arg.FrozenBy, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.InterestProductID,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.Joint,
+		&i.ParentAccountID,
+		&i.FrozenBy,
 	)
 	return i, err
 }
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// Account member roles, each role has the rights of the roles after it
+const (
+	// AccountRoleOwner can also close the account, change its settings and manage its members
+	AccountRoleOwner = "owner"
+	// AccountRoleCanTransfer can also move money out of the account
+	AccountRoleCanTransfer = "can_transfer"
+	// AccountRoleViewOnly can see the account, its balance and its history
+	AccountRoleViewOnly = "view_only"
+)
+
+// Account member statuses
+const (
+	AccountMemberInvited = "invited"
+	AccountMemberActive  = "active"
+)
+
+var accountRoleRanks = map[string]int{
+	AccountRoleViewOnly:    1,
+	AccountRoleCanTransfer: 2,
+	AccountRoleOwner:       3,
+}
+
+// IsAccountRole reports whether role is one of the account member roles
+func IsAccountRole(role string) bool {
+	_, ok := accountRoleRanks[role]
+	return ok
+}
+
+// AccountRoleGrants reports whether role has the rights of want, an empty role has none
+func AccountRoleGrants(role, want string) bool {
+	return role != "" && accountRoleRanks[role] >= accountRoleRanks[want]
+}
+
+// AccountRole returns the role username has on the account, the holder of the account is always an owner.
+// The role is empty when username isn't a member or hasn't accepted the invitation yet.
+// A pot is shared like its parent account.
+func AccountRole(ctx context.Context, q Querier, account Account, username string) (string, error) {
+	if account.Owner == username {
+		return AccountRoleOwner, nil
+	}
+	if account.ParentAccountID.Valid {
+		parent, err := q.GetAccount(ctx, account.ParentAccountID.Int64)
+		if err != nil {
+			return "", err
+		}
+		return AccountRole(ctx, q, parent, username)
+	}
+	if !account.Joint {
+		return "", nil
+	}
+
+	member, err := q.GetAccountMember(ctx, GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	if member.Status != AccountMemberActive {
+		return "", nil
+	}
+	return member.Role, nil
+}
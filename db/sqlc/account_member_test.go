@@ -0,0 +1,85 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createRandomJointAccount(t *testing.T) Account {
+	user := createRandomUser(t)
+
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  util.RandomMoney(),
+		Currency: util.RandomCurrency(),
+		Joint:    true,
+	})
+	require.NoError(t, err)
+	require.True(t, account.Joint)
+
+	return account
+}
+
+func TestAccountMembers(t *testing.T) {
+	account := createRandomJointAccount(t)
+	invitee := createRandomUser(t)
+
+	member, err := testQueries.CreateAccountMember(context.Background(), CreateAccountMemberParams{
+		AccountID: account.ID,
+		Username:  invitee.Username,
+		Role:      AccountRoleCanTransfer,
+		InvitedBy: account.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountMemberInvited, member.Status)
+	require.False(t, member.AcceptedAt.Valid)
+
+	// an invitation grants nothing until it is accepted
+	role, err := AccountRole(context.Background(), testQueries, account, invitee.Username)
+	require.NoError(t, err)
+	require.Empty(t, role)
+
+	invitations, err := testQueries.ListAccountInvitations(context.Background(), invitee.Username)
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+
+	member, err = testQueries.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+		AccountID: account.ID,
+		Username:  invitee.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountMemberActive, member.Status)
+	require.True(t, member.AcceptedAt.Valid)
+
+	role, err = AccountRole(context.Background(), testQueries, account, invitee.Username)
+	require.NoError(t, err)
+	require.Equal(t, AccountRoleCanTransfer, role)
+
+	// the member sees the shared account next to their own
+	accounts, err := testQueries.ListAccounts(context.Background(), ListAccountsParams{
+		Owner: invitee.Username,
+		Limit: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	_, err = testQueries.DeleteAccountMember(context.Background(), DeleteAccountMemberParams{
+		AccountID: account.ID,
+		Username:  invitee.Username,
+	})
+	require.NoError(t, err)
+
+	role, err = AccountRole(context.Background(), testQueries, account, invitee.Username)
+	require.NoError(t, err)
+	require.Empty(t, role)
+}
+
+func TestAccountRoleGrants(t *testing.T) {
+	require.True(t, AccountRoleGrants(AccountRoleOwner, AccountRoleCanTransfer))
+	require.True(t, AccountRoleGrants(AccountRoleCanTransfer, AccountRoleCanTransfer))
+	require.False(t, AccountRoleGrants(AccountRoleViewOnly, AccountRoleCanTransfer))
+	require.False(t, AccountRoleGrants("", AccountRoleViewOnly))
+}
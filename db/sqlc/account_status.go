@@ -0,0 +1,61 @@
+package db
+
+import "fmt"
+
+// Account statuses
+const (
+	AccountActive  = "active"
+	AccountFrozen  = "frozen"
+	AccountClosed  = "closed"
+	AccountDormant = "dormant"
+)
+
+// accountStatusTransitions lists the statuses each status can move to, closed is final
+var accountStatusTransitions = map[string][]string{
+	AccountActive:  {AccountFrozen, AccountDormant, AccountClosed},
+	AccountFrozen:  {AccountActive},
+	AccountDormant: {AccountActive, AccountClosed},
+	AccountClosed:  {},
+}
+
+// CanTransitionAccountStatus reports whether an account can move from one status to another
+func CanTransitionAccountStatus(from, to string) bool {
+	for _, status := range accountStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCanDebit makes sure money can be taken out of the account, only active accounts can pay
+func checkCanDebit(account Account) error {
+	switch account.Status {
+	case AccountFrozen:
+		return fmt.Errorf("%w: account [%d]", ErrAccountFrozen, account.ID)
+	case AccountClosed:
+		return fmt.Errorf("%w: account [%d]", ErrAccountClosed, account.ID)
+	case AccountDormant:
+		return fmt.Errorf("%w: account [%d]", ErrAccountDormant, account.ID)
+	}
+	return nil
+}
+
+// checkCanCredit makes sure money can be paid into the account, dormant accounts can still receive money
+func checkCanCredit(account Account) error {
+	switch account.Status {
+	case AccountFrozen:
+		return fmt.Errorf("%w: account [%d]", ErrAccountFrozen, account.ID)
+	case AccountClosed:
+		return fmt.Errorf("%w: account [%d]", ErrAccountClosed, account.ID)
+	}
+	return nil
+}
+
+// checkCanTransfer makes sure money can move from one account to the other
+func checkCanTransfer(fromAccount, toAccount Account) error {
+	if err := checkCanDebit(fromAccount); err != nil {
+		return err
+	}
+	return checkCanCredit(toAccount)
+}
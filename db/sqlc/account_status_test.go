@@ -0,0 +1,218 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCanTransitionAccountStatus(t *testing.T) {
+	require.True(t, CanTransitionAccountStatus(AccountActive, AccountFrozen))
+	require.True(t, CanTransitionAccountStatus(AccountFrozen, AccountActive))
+	require.True(t, CanTransitionAccountStatus(AccountDormant, AccountClosed))
+	require.False(t, CanTransitionAccountStatus(AccountFrozen, AccountClosed))
+	require.False(t, CanTransitionAccountStatus(AccountClosed, AccountActive))
+	require.False(t, CanTransitionAccountStatus(AccountActive, AccountActive))
+}
+
+func TestUpdateAccountStatusTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 100)
+
+	account1, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account1.ID,
+		Status:    AccountFrozen,
+		Username:  account1.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountFrozen, account1.Status)
+	require.Equal(t, sql.NullString{String: account1.Owner, Valid: true}, account1.FrozenBy)
+
+	// money can't move out of or into a frozen account
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	// closing goes through CloseAccountTx
+	_, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account1.ID,
+		Status:    AccountClosed,
+	})
+	require.ErrorIs(t, err, ErrAccountStatusTransition)
+
+	account1, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account1.ID,
+		Status:    AccountActive,
+		Username:  account1.Owner,
+	})
+	require.NoError(t, err)
+	require.False(t, account1.FrozenBy.Valid)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+}
+
+func TestUpdateAccountStatusTxOfficerFreeze(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createRandomAccountWithBalance(t, 100)
+	officer := createRandomOfficer(t)
+
+	account, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountFrozen,
+		Username:  officer.Username,
+	})
+	require.NoError(t, err)
+
+	// the owner can't lift a freeze the bank put on
+	_, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountActive,
+		Username:  account.Owner,
+	})
+	require.ErrorIs(t, err, ErrAccountUnfreeze)
+
+	// any officer can
+	account, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountActive,
+		Username:  createRandomOfficer(t).Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountActive, account.Status)
+}
+
+func TestCloseAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	owner := createRandomUser(t).Username
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    owner,
+		Balance:  1000,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+	sweep, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    owner,
+		Balance:  0,
+		Currency: util.EUR,
+	})
+	require.NoError(t, err)
+	other := createRandomAccountWithBalance(t, 0)
+
+	_, err = store.CreateFxRate(context.Background(), CreateFxRateParams{
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         "0.9",
+		SpreadBps:    100,
+	})
+	require.NoError(t, err)
+
+	// the balance has to go somewhere
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account.ID})
+	require.ErrorIs(t, err, ErrInvalidSweepAccount)
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:      account.ID,
+		SweepAccountID: other.ID,
+	})
+	require.ErrorIs(t, err, ErrInvalidSweepAccount)
+
+	result, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:      account.ID,
+		SweepAccountID: sweep.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountClosed, result.Account.Status)
+	require.Zero(t, result.Account.Balance)
+	require.NotNil(t, result.Sweep)
+	require.Equal(t, int64(1000), result.Sweep.Transfer.Amount)
+	require.Equal(t, sweep.ID, result.Sweep.ToAccount.ID)
+	require.Equal(t, result.Sweep.Transfer.ToAmount, result.Sweep.ToAccount.Balance)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: other.ID,
+		ToAccountID:   account.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account.ID})
+	require.ErrorIs(t, err, ErrAccountStatusTransition)
+
+	// the owner can open a new account in the currency of the closed one
+	_, err = testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    owner,
+		Balance:  0,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+}
+
+func TestCloseJointAccountTxIntoCoOwnerAccount(t *testing.T) {
+	store := NewStore(testDB)
+
+	holder := createRandomUser(t)
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    holder.Username,
+		Balance:  1000,
+		Currency: util.USD,
+		Joint:    true,
+	})
+	require.NoError(t, err)
+	coOwner := createRandomUser(t)
+	_, err = testQueries.CreateAccountMember(context.Background(), CreateAccountMemberParams{
+		AccountID: account.ID,
+		Username:  coOwner.Username,
+		Role:      AccountRoleOwner,
+		InvitedBy: holder.Username,
+	})
+	require.NoError(t, err)
+	_, err = testQueries.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+		AccountID: account.ID,
+		Username:  coOwner.Username,
+	})
+	require.NoError(t, err)
+
+	sweep, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    coOwner.Username,
+		Balance:  0,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+
+	// the holder can't sweep the balance into an account of the co-owner
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:      account.ID,
+		SweepAccountID: sweep.ID,
+		Username:       holder.Username,
+	})
+	require.ErrorIs(t, err, ErrInvalidSweepAccount)
+
+	result, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:      account.ID,
+		SweepAccountID: sweep.ID,
+		Username:       coOwner.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountClosed, result.Account.Status)
+	require.NotNil(t, result.Sweep)
+	require.Equal(t, int64(1000), result.Sweep.ToAccount.Balance)
+}
@@ -10,11 +10,15 @@ import (
 )
 
 func createRandomAccount(t *testing.T) Account {
+	return createRandomAccountWithBalance(t, util.RandomMoney())
+}
+
+func createRandomAccountWithBalance(t *testing.T, balance int64) Account {
 	user := createRandomUser(t)
 
 	arg := CreateAccountParams{
 		Owner:    user.Username,
-		Balance:  util.RandomMoney(),
+		Balance:  balance,
 		Currency: util.RandomCurrency(),
 	}
 
@@ -26,6 +30,8 @@ func createRandomAccount(t *testing.T) Account {
 	require.Equal(t, arg.Balance, account.Balance)
 	require.Equal(t, arg.Currency, account.Currency)
 
+	require.Zero(t, account.OverdraftLimit)
+	require.Equal(t, AccountActive, account.Status)
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
 
@@ -67,6 +73,22 @@ func TestQueries_UpdateAccount(t *testing.T) {
 	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
 }
 
+func TestQueries_UpdateAccountOverdraftLimit(t *testing.T) {
+	account1 := createRandomAccount(t)
+	arg := UpdateAccountOverdraftLimitParams{
+		ID:             account1.ID,
+		OverdraftLimit: util.RandomMoney(),
+	}
+
+	account2, err := testQueries.UpdateAccountOverdraftLimit(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, account2)
+
+	require.Equal(t, account1.ID, account2.ID)
+	require.Equal(t, account1.Balance, account2.Balance)
+	require.Equal(t, arg.OverdraftLimit, account2.OverdraftLimit)
+}
+
 func TestQueries_DeleteAccount(t *testing.T) {
 	account1 := createRandomAccount(t)
 	err := testQueries.DeleteAccount(context.Background(), account1.ID)
@@ -99,3 +121,35 @@ func TestQueries_GetListAccount(t *testing.T) {
 		require.Equal(t, lastAccount.Owner, account.Owner)
 	}
 }
+
+func TestQueries_ListAccountsAfter(t *testing.T) {
+	user := createRandomUser(t)
+	var created []Account
+	for _, currency := range []string{util.USD, util.EUR, util.CAD} {
+		account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+			Owner:    user.Username,
+			Currency: currency,
+		})
+		require.NoError(t, err)
+		created = append(created, account)
+	}
+
+	accounts, err := testQueries.ListAccountsAfter(context.Background(), ListAccountsAfterParams{
+		Owner:      user.Username,
+		AfterID:    0,
+		LimitCount: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	require.Equal(t, created[0].ID, accounts[0].ID)
+	require.Equal(t, created[1].ID, accounts[1].ID)
+
+	accounts, err = testQueries.ListAccountsAfter(context.Background(), ListAccountsAfterParams{
+		Owner:      user.Username,
+		AfterID:    accounts[1].ID,
+		LimitCount: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, created[2].ID, accounts[0].ID)
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"encoding/json"
+)
+
+// LoadCurrencies replaces the in-memory currency registry with the content of the currencies table
+func LoadCurrencies(ctx context.Context, q Querier) error {
+	currencies, err := q.ListCurrencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	registry := make([]util.Currency, len(currencies))
+	for i, currency := range currencies {
+		registry[i] = currency.Registry()
+	}
+	util.SetCurrencies(registry)
+	return nil
+}
+
+// Registry returns the currency as an entry of the in-memory registry
+func (currency Currency) Registry() util.Currency {
+	return util.Currency{
+		Code:     currency.Code,
+		Exponent: int(currency.Exponent),
+		Enabled:  currency.Enabled,
+	}
+}
+
+// MarshalJSON adds the balance as a decimal in the currency of the account, so clients don't have to know its exponent
+func (account Account) MarshalJSON() ([]byte, error) {
+	type plainAccount Account
+	return json.Marshal(struct {
+		plainAccount
+		BalanceFormatted string `json:"balance_formatted"`
+	}{
+		plainAccount:     plainAccount(account),
+		BalanceFormatted: util.FormatCurrencyAmount(account.Balance, account.Currency),
+	})
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"github.com/stretchr/testify/require"
+	"math"
+	"strings"
+	"testing"
+)
+
+func createRandomCurrency(t *testing.T, exponent int32) Currency {
+	arg := CreateCurrencyParams{
+		Code:     strings.ToUpper(util.RandomString(3)),
+		Exponent: exponent,
+		Enabled:  true,
+	}
+	currency, err := testQueries.CreateCurrency(context.Background(), arg)
+	if err != nil {
+		// the code space is small, try again on a collision with an earlier run
+		return createRandomCurrency(t, exponent)
+	}
+	require.Equal(t, arg.Code, currency.Code)
+	require.Equal(t, arg.Exponent, currency.Exponent)
+	require.True(t, currency.Enabled)
+	return currency
+}
+
+func TestLoadCurrencies(t *testing.T) {
+	currency := createRandomCurrency(t, 0)
+
+	require.NoError(t, LoadCurrencies(context.Background(), testQueries))
+	require.True(t, util.IsSupportCurrency(currency.Code))
+	require.True(t, util.IsSupportCurrency(util.USD))
+	require.Equal(t, "250", util.FormatCurrencyAmount(250, currency.Code))
+
+	currency, err := testQueries.UpdateCurrency(context.Background(), UpdateCurrencyParams{
+		Enabled: false,
+		Code:    currency.Code,
+	})
+	require.NoError(t, err)
+	require.False(t, currency.Enabled)
+
+	require.NoError(t, LoadCurrencies(context.Background(), testQueries))
+	require.False(t, util.IsSupportCurrency(currency.Code))
+	require.Equal(t, "250", util.FormatCurrencyAmount(250, currency.Code))
+}
+
+func TestCreateCurrencyTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	var arg CreateCurrencyParams
+	var result CreateCurrencyTxResult
+	var err error
+	// the code space is small, try again on a collision with an earlier run
+	for i := 0; i < 5; i++ {
+		arg = CreateCurrencyParams{
+			Code:     strings.ToUpper(util.RandomString(3)),
+			Exponent: 2,
+			Enabled:  true,
+		}
+		if result, err = store.CreateCurrencyTx(context.Background(), arg); err == nil {
+			break
+		}
+	}
+	require.NoError(t, err)
+	require.Equal(t, arg.Code, result.Currency.Code)
+
+	// fees and interest in the new currency find their bank accounts
+	feeAccount, err := testQueries.GetAccountByOwner(context.Background(), GetAccountByOwnerParams{
+		Owner:    FeeIncomeOwner,
+		Currency: arg.Code,
+	})
+	require.NoError(t, err)
+	require.Equal(t, result.FeeIncomeAccount.ID, feeAccount.ID)
+	require.Zero(t, feeAccount.Balance)
+
+	expenseAccount, err := testQueries.GetAccountByOwner(context.Background(), GetAccountByOwnerParams{
+		Owner:    InterestExpenseOwner,
+		Currency: arg.Code,
+	})
+	require.NoError(t, err)
+	require.Equal(t, result.InterestExpenseAccount.ID, expenseAccount.ID)
+	require.Equal(t, int64(math.MaxInt64), expenseAccount.OverdraftLimit)
+}
@@ -7,33 +7,95 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (account_id,
-                     amount)
-VALUES ($1, $2) RETURNING id, account_id, amount, created_at
+                     amount,
+                     transfer_id,
+                     balance_after)
+VALUES ($1, $2, $3, $4) RETURNING id, account_id, amount, created_at, transfer_id, balance_after
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID    int64         `json:"account_id"`
+	Amount       int64         `json:"amount"`
+	TransferID   sql.NullInt64 `json:"transfer_id"`
+	BalanceAfter int64         `json:"balance_after"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.TransferID,
+		arg.BalanceAfter,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.BalanceAfter,
 	)
 	return i, err
 }
 
+const getBalanceAsOf = `-- name: GetBalanceAsOf :one
+SELECT COALESCE(
+               (SELECT balance_after
+                FROM entries
+                WHERE account_id = $1
+                  AND created_at <= $2
+                ORDER BY id DESC LIMIT 1),
+               (SELECT balance_after - amount
+                FROM entries
+                WHERE account_id = $1
+                ORDER BY id LIMIT 1),
+               (SELECT balance
+                FROM accounts
+                WHERE id = $1)
+       )::bigint AS balance
+`
+
+type GetBalanceAsOfParams struct {
+	AccountID int64     `json:"account_id"`
+	AsOf      time.Time `json:"as_of"`
+}
+
+func (q *Queries) GetBalanceAsOf(ctx context.Context, arg GetBalanceAsOfParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getBalanceAsOf, arg.AccountID, arg.AsOf)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const getEntriesTotalSince = `-- name: GetEntriesTotalSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = $1
+  AND created_at >= $2
+`
+
+type GetEntriesTotalSinceParams struct {
+	AccountID int64     `json:"account_id"`
+	Since     time.Time `json:"since"`
+}
+
+func (q *Queries) GetEntriesTotalSince(ctx context.Context, arg GetEntriesTotalSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getEntriesTotalSince, arg.AccountID, arg.Since)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at
+SELECT id, account_id, amount, created_at, transfer_id, balance_after
 FROM entries
 WHERE id = $1 LIMIT 1
 `
@@ -46,12 +108,175 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.BalanceAfter,
 	)
 	return i, err
 }
 
+const listAccountHistory = `-- name: ListAccountHistory :many
+SELECT e.id,
+       e.account_id,
+       e.amount,
+       e.created_at,
+       e.transfer_id,
+       e.balance_after,
+       c.id                              AS counterparty_account_id,
+       c.owner                           AS counterparty_owner,
+       COALESCE(t.memo, '')::varchar     AS memo,
+       t.client_reference,
+       COALESCE(t.metadata, '{}')::jsonb AS metadata
+FROM entries e
+         LEFT JOIN transfers t ON t.id = e.transfer_id
+         LEFT JOIN accounts c ON c.id = CASE
+                                            WHEN t.from_account_id = e.account_id THEN t.to_account_id
+                                            ELSE t.from_account_id END
+WHERE e.account_id = $1
+  AND ($2::bigint IS NULL OR e.id < $2)
+  AND ($3::timestamptz IS NULL OR e.created_at >= $3)
+  AND ($4::timestamptz IS NULL OR e.created_at < $4)
+  AND ($5::bigint IS NULL OR abs(e.amount) >= $5)
+  AND ($6::bigint IS NULL OR abs(e.amount) <= $6)
+  AND ($7::varchar IS NULL
+    OR ($7 = 'in' AND e.amount > 0)
+    OR ($7 = 'out' AND e.amount < 0))
+  AND ($8::bigint IS NULL OR c.id = $8)
+  AND ($9::varchar IS NULL OR t.client_reference = $9)
+ORDER BY e.id DESC LIMIT $10
+`
+
+type ListAccountHistoryParams struct {
+	AccountID             int64          `json:"account_id"`
+	BeforeID              sql.NullInt64  `json:"before_id"`
+	FromTime              sql.NullTime   `json:"from_time"`
+	ToTime                sql.NullTime   `json:"to_time"`
+	MinAmount             sql.NullInt64  `json:"min_amount"`
+	MaxAmount             sql.NullInt64  `json:"max_amount"`
+	Direction             sql.NullString `json:"direction"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	ClientReference       sql.NullString `json:"client_reference"`
+	LimitCount            int32          `json:"limit_count"`
+}
+
+type ListAccountHistoryRow struct {
+	ID                    int64           `json:"id"`
+	AccountID             int64           `json:"account_id"`
+	Amount                int64           `json:"amount"`
+	CreatedAt             time.Time       `json:"created_at"`
+	TransferID            sql.NullInt64   `json:"transfer_id"`
+	BalanceAfter          int64           `json:"balance_after"`
+	CounterpartyAccountID sql.NullInt64   `json:"counterparty_account_id"`
+	CounterpartyOwner     sql.NullString  `json:"counterparty_owner"`
+	Memo                  string          `json:"memo"`
+	ClientReference       sql.NullString  `json:"client_reference"`
+	Metadata              json.RawMessage `json:"metadata"`
+}
+
+func (q *Queries) ListAccountHistory(ctx context.Context, arg ListAccountHistoryParams) ([]ListAccountHistoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountHistory,
+		arg.AccountID,
+		arg.BeforeID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Direction,
+		arg.CounterpartyAccountID,
+		arg.ClientReference,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountHistoryRow{}
+	for rows.Next() {
+		var i ListAccountHistoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.BalanceAfter,
+			&i.CounterpartyAccountID,
+			&i.CounterpartyOwner,
+			&i.Memo,
+			&i.ClientReference,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDailyBalances = `-- name: ListDailyBalances :many
+SELECT d.day::date AS day,
+       COALESCE(
+               (SELECT balance_after
+                FROM entries
+                WHERE account_id = $1
+                  AND created_at < (d.day + interval '1 day') AT TIME ZONE 'UTC'
+                ORDER BY id DESC LIMIT 1),
+               (SELECT balance_after - amount
+                FROM entries
+                WHERE account_id = $1
+                ORDER BY id LIMIT 1),
+               (SELECT balance
+                FROM accounts
+                WHERE id = $1)
+       )::bigint AS balance
+FROM generate_series($2::date, $3::date, interval '1 day') AS d(day)
+ORDER BY d.day
+`
+
+type ListDailyBalancesParams struct {
+	AccountID int64     `json:"account_id"`
+	FromDay   time.Time `json:"from_day"`
+	ToDay     time.Time `json:"to_day"`
+}
+
+type ListDailyBalancesRow struct {
+	Day     time.Time `json:"day"`
+	Balance int64     `json:"balance"`
+}
+
+func (q *Queries) ListDailyBalances(ctx context.Context, arg ListDailyBalancesParams) ([]ListDailyBalancesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDailyBalances, arg.AccountID, arg.FromDay, arg.ToDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDailyBalancesRow{}
+	for rows.Next() {
+		var i ListDailyBalancesRow
+		if err := rows.Scan(
+			&i.Day,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at
+SELECT id, account_id, amount, created_at, transfer_id, balance_after
 FROM entries
 WHERE account_id = $1
 ORDER BY id LIMIT $2
@@ -78,6 +303,121 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.TransferID,
+			&i.BalanceAfter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntriesAfter = `-- name: ListEntriesAfter :many
+SELECT id, account_id, amount, created_at, transfer_id, balance_after
+FROM entries
+WHERE account_id = $1
+  AND id > $2
+ORDER BY id LIMIT $3
+`
+
+type ListEntriesAfterParams struct {
+	AccountID  int64 `json:"account_id"`
+	AfterID    int64 `json:"after_id"`
+	LimitCount int32 `json:"limit_count"`
+}
+
+func (q *Queries) ListEntriesAfter(ctx context.Context, arg ListEntriesAfterParams) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listEntriesAfter, arg.AccountID, arg.AfterID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.BalanceAfter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatementEntries = `-- name: ListStatementEntries :many
+SELECT e.id,
+       e.account_id,
+       e.amount,
+       e.created_at,
+       e.transfer_id,
+       t.from_account_id,
+       t.to_account_id,
+       c.owner AS counterparty_owner
+FROM entries e
+         LEFT JOIN transfers t ON t.id = e.transfer_id
+         LEFT JOIN accounts c ON c.id = CASE
+                                            WHEN t.from_account_id = e.account_id THEN t.to_account_id
+                                            ELSE t.from_account_id END
+WHERE e.account_id = $1
+  AND e.created_at >= $2
+  AND e.created_at < $3
+ORDER BY e.created_at, e.id
+`
+
+type ListStatementEntriesParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+type ListStatementEntriesRow struct {
+	ID                int64          `json:"id"`
+	AccountID         int64          `json:"account_id"`
+	Amount            int64          `json:"amount"`
+	CreatedAt         time.Time      `json:"created_at"`
+	TransferID        sql.NullInt64  `json:"transfer_id"`
+	FromAccountID     sql.NullInt64  `json:"from_account_id"`
+	ToAccountID       sql.NullInt64  `json:"to_account_id"`
+	CounterpartyOwner sql.NullString `json:"counterparty_owner"`
+}
+
+func (q *Queries) ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listStatementEntries, arg.AccountID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStatementEntriesRow{}
+	for rows.Next() {
+		var i ListStatementEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.CounterpartyOwner,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrInsufficientFunds is returned when a debit would take an account past its overdraft limit
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrFxRateNotFound is returned when no exchange rate is configured for a currency pair
+	ErrFxRateNotFound = errors.New("exchange rate not found")
+	// ErrIdempotencyKeyReused is returned when an idempotency key is replayed with a different request
+	ErrIdempotencyKeyReused = errors.New("idempotency key has already been used for a different request")
+	// ErrTransferAlreadyReversed is returned when a transfer that has been reversed is reversed again
+	ErrTransferAlreadyReversed = errors.New("transfer has already been reversed")
+	// ErrTransferNotReversible is returned when a transfer can't be reversed by the requested amount
+	ErrTransferNotReversible = errors.New("transfer can't be reversed")
+	// ErrHoldNotActive is returned when a hold that was captured, voided or has expired is captured or voided
+	ErrHoldNotActive = errors.New("hold is not active")
+	// ErrHoldAmountExceeded is returned when a capture is larger than the held amount
+	ErrHoldAmountExceeded = errors.New("capture amount exceeds the held amount")
+	// ErrStaleStandingOrderRun is returned when a standing order run no longer matches the order's next run
+	ErrStaleStandingOrderRun = errors.New("standing order run is stale")
+	// ErrInterestAlreadyPosted is returned when interest for a month has already been paid to an account
+	ErrInterestAlreadyPosted = errors.New("interest has already been posted for the period")
+	// ErrTransferLimitExceeded is matched by a *TransferLimitError when a transfer would breach a velocity limit
+	ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+	// ErrAccountFrozen is returned when money is moved in or out of a frozen account
+	ErrAccountFrozen = errors.New("account is frozen")
+	// ErrAccountClosed is returned when money is moved in or out of a closed account
+	ErrAccountClosed = errors.New("account is closed")
+	// ErrAccountDormant is returned when money is moved out of a dormant account, it must be reactivated first
+	ErrAccountDormant = errors.New("account is dormant")
+	// ErrAccountStatusTransition is returned when an account can't move from its status to the requested one
+	ErrAccountStatusTransition = errors.New("account status can't be changed")
+	// ErrAccountUnfreeze is returned when a user other than the one who froze an account, and not an officer, unfreezes it
+	ErrAccountUnfreeze = errors.New("account can only be unfrozen by the user who froze it or an officer")
+	// ErrInvalidSweepAccount is returned when the balance of a closing account can't be swept to the given account
+	ErrInvalidSweepAccount = errors.New("invalid sweep account")
+	// ErrTransferRequestNotPending is returned when a transfer request that was decided or has expired is decided
+	ErrTransferRequestNotPending = errors.New("transfer request is not pending")
+	// ErrTransferRequestSelfDecision is returned when the requester of a transfer tries to approve or reject it
+	ErrTransferRequestSelfDecision = errors.New("transfer request can't be decided by its requester")
+	// ErrTransferRequestDecider is returned when a user who is neither an officer nor an owner of the from account
+	// tries to approve or reject a transfer request
+	ErrTransferRequestDecider = errors.New("transfer request can only be decided by an officer or an owner of the from account")
+	// ErrDuplicateClientReference is returned when the from account already sent a transfer with the same client reference
+	ErrDuplicateClientReference = errors.New("client reference has already been used by the from account")
+	// ErrPotTransfer is returned when money is moved between a pot and an account other than its parent
+	ErrPotTransfer = errors.New("a pot can only move money to and from its parent account")
+	// ErrInvalidPotParent is returned when a pot is opened under a pot or an account that isn't active
+	ErrInvalidPotParent = errors.New("invalid pot parent account")
+	// ErrRoundUpPotTaken is returned when a second pot of the same account is given a round up rule
+	ErrRoundUpPotTaken = errors.New("another pot already rounds up the payments of the account")
+)
+
+// isUniqueViolation reports whether err is a postgres unique violation on the given constraint
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pq.Error
+	if errors.As(err, &pgErr) {
+		return pgErr.Code.Name() == "unique_violation" && pgErr.Constraint == constraint
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createAccountInCurrency(t *testing.T, balance int64, currency string) Account {
+	user := createRandomUser(t)
+
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  balance,
+		Currency: currency,
+	})
+	require.NoError(t, err)
+	return account
+}
+
+func TestConvertAmount(t *testing.T) {
+	util.SetCurrency(util.Currency{Code: "XEA", Exponent: 0, Enabled: true})
+	util.SetCurrency(util.Currency{Code: "XEC", Exponent: 3, Enabled: true})
+
+	testCases := []struct {
+		name         string
+		amount       int64
+		fromCurrency string
+		toCurrency   string
+		rate         string
+		spreadBps    int32
+		toAmount     int64
+		wantErr      bool
+	}{
+		{name: "NoSpread", amount: 1000, fromCurrency: util.USD, toCurrency: util.EUR, rate: "0.9", spreadBps: 0, toAmount: 900},
+		{name: "WithSpread", amount: 1000, fromCurrency: util.USD, toCurrency: util.EUR, rate: "0.9", spreadBps: 100, toAmount: 891},
+		{name: "RoundsDown", amount: 3, fromCurrency: util.USD, toCurrency: util.EUR, rate: "1.333333", spreadBps: 0, toAmount: 3},
+		// 10.00 USD at 150 per dollar is 1500 units of a currency without minor units
+		{name: "ToNoMinorUnits", amount: 1000, fromCurrency: util.USD, toCurrency: "XEA", rate: "150", spreadBps: 0, toAmount: 1500},
+		// 1500 units at 1/150 is 10.00 USD
+		{name: "FromNoMinorUnits", amount: 1500, fromCurrency: "XEA", toCurrency: util.USD, rate: "0.00666667", spreadBps: 0, toAmount: 1000},
+		// 1.00 USD at 0.3 is 0.300 of a currency with three minor digits
+		{name: "ToThreeMinorUnits", amount: 100, fromCurrency: util.USD, toCurrency: "XEC", rate: "0.3", spreadBps: 0, toAmount: 300},
+		{name: "FromThreeMinorUnits", amount: 1234, fromCurrency: "XEC", toCurrency: util.USD, rate: "3.25", spreadBps: 0, toAmount: 401},
+		{name: "TooSmallAfterScale", amount: 1, fromCurrency: "XEC", toCurrency: util.USD, rate: "3", spreadBps: 0, wantErr: true},
+		{name: "TooSmall", amount: 1, fromCurrency: util.USD, toCurrency: util.EUR, rate: "0.5", spreadBps: 0, wantErr: true},
+		{name: "InvalidRate", amount: 1000, fromCurrency: util.USD, toCurrency: util.EUR, rate: "abc", spreadBps: 0, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			toAmount, err := convertAmount(tc.amount, tc.fromCurrency, tc.toCurrency, tc.rate, tc.spreadBps)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.toAmount, toAmount)
+		})
+	}
+}
+
+func TestExchangeTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountInCurrency(t, 10000, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.EUR)
+
+	fxRate, err := store.CreateFxRate(context.Background(), CreateFxRateParams{
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         "0.9",
+		SpreadBps:    100,
+	})
+	require.NoError(t, err)
+
+	result, err := store.ExchangeTransferTx(context.Background(), ExchangeTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1000,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1000), result.FromAmount)
+	require.Equal(t, int64(891), result.ToAmount)
+	require.Equal(t, fxRate.SpreadBps, result.SpreadBps)
+
+	require.Equal(t, int64(1000), result.Transfer.Amount)
+	require.Equal(t, int64(891), result.Transfer.ToAmount)
+	require.True(t, result.Transfer.FxRate.Valid)
+	require.Equal(t, fxRate.SpreadBps, result.Transfer.FxSpreadBps.Int32)
+
+	require.Equal(t, int64(-1000), result.FromEntry.Amount)
+	require.Equal(t, int64(891), result.ToEntry.Amount)
+	require.Equal(t, account1.Balance-1000, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+891, result.ToAccount.Balance)
+}
+
+func TestExchangeTransferTxRateNotFound(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountInCurrency(t, 10000, util.CAD)
+	account2 := createAccountInCurrency(t, 0, util.EUR)
+
+	_, err := store.ExchangeTransferTx(context.Background(), ExchangeTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1000,
+	})
+	require.ErrorIs(t, err, ErrFxRateNotFound)
+}
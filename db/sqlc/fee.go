@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// FeeIncomeOwner owns the bank accounts that fees are paid into, one per currency
+const FeeIncomeOwner = "bank_fee_income"
+
+// Transfer types that fee schedules are configured for
+const (
+	TransferTypeTransfer      = "transfer"
+	TransferTypeStandingOrder = "standing_order"
+)
+
+// Fee schedule kinds
+const (
+	FeeFlat       = "flat"
+	FeePercentage = "percentage"
+	FeeTiered     = "tiered"
+)
+
+// FeeTier prices the amounts up to UpTo, the last tier has no UpTo and prices every larger amount
+type FeeTier struct {
+	UpTo          int64 `json:"up_to,omitempty"`
+	FlatAmount    int64 `json:"flat_amount"`
+	PercentageBps int64 `json:"percentage_bps"`
+}
+
+type TransferFee struct {
+	ScheduleID int64 `json:"schedule_id"`
+	Amount     int64 `json:"amount"`
+	// Transfer moves the fee from the payer to the fee income account
+	Transfer Transfer `json:"transfer"`
+	// Entry is the fee debit on the payer's account
+	Entry Entry `json:"entry"`
+}
+
+// Fee works out the fee charged on a transfer of amount.
+// A tiered schedule charges the whole amount at the rate of the first tier it fits in,
+// and the result is always kept between MinFee and MaxFee.
+func (schedule FeeSchedule) Fee(amount int64) (int64, error) {
+	var fee int64
+	switch schedule.Kind {
+	case FeeFlat:
+		fee = schedule.FlatAmount
+	case FeePercentage:
+		fee = schedule.FlatAmount + percentageOf(amount, int64(schedule.PercentageBps))
+	case FeeTiered:
+		var tiers []FeeTier
+		if err := json.Unmarshal(schedule.Tiers, &tiers); err != nil {
+			return 0, fmt.Errorf("invalid tiers in fee schedule [%d]: %w", schedule.ID, err)
+		}
+		tier, ok := matchFeeTier(tiers, amount)
+		if !ok {
+			return 0, fmt.Errorf("fee schedule [%d] has no tier for amount %d", schedule.ID, amount)
+		}
+		fee = tier.FlatAmount + percentageOf(amount, tier.PercentageBps)
+	default:
+		return 0, fmt.Errorf("unknown kind %q of fee schedule [%d]", schedule.Kind, schedule.ID)
+	}
+
+	if fee < schedule.MinFee {
+		fee = schedule.MinFee
+	}
+	if schedule.MaxFee.Valid && fee > schedule.MaxFee.Int64 {
+		fee = schedule.MaxFee.Int64
+	}
+	return fee, nil
+}
+
+func matchFeeTier(tiers []FeeTier, amount int64) (FeeTier, bool) {
+	for _, tier := range tiers {
+		if tier.UpTo == 0 || amount <= tier.UpTo {
+			return tier, true
+		}
+	}
+	return FeeTier{}, false
+}
+
+// percentageOf returns amount * bps / 10000, rounded half up
+func percentageOf(amount int64, bps int64) int64 {
+	product := new(big.Int).Mul(big.NewInt(amount), big.NewInt(bps))
+	product.Add(product, big.NewInt(5000))
+	return product.Quo(product, big.NewInt(10000)).Int64()
+}
+
+// transferFee looks up the fee schedule for the currency and transfer type and returns the fee leg to add to the transfer.
+// ok is false when no fee is charged.
+func transferFee(
+	ctx context.Context,
+	queries *Queries,
+	fromAccount Account,
+	transferType string,
+	amount int64,
+) (schedule FeeSchedule, leg TransferLeg, ok bool, err error) {
+	// the bank doesn't charge itself
+	if fromAccount.Owner == FeeIncomeOwner {
+		return
+	}
+
+	schedule, err = queries.GetFeeSchedule(ctx, GetFeeScheduleParams{
+		Currency:     fromAccount.Currency,
+		TransferType: transferType,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil
+		}
+		return
+	}
+
+	fee, err := schedule.Fee(amount)
+	if err != nil || fee <= 0 {
+		return
+	}
+
+	feeAccount, err := queries.GetAccountByOwner(ctx, GetAccountByOwnerParams{
+		Owner:    FeeIncomeOwner,
+		Currency: fromAccount.Currency,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("no fee income account in %s: %w", fromAccount.Currency, err)
+		}
+		return
+	}
+
+	return schedule, TransferLeg{ToAccountID: feeAccount.ID, Amount: fee}, true, nil
+}
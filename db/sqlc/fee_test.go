@@ -0,0 +1,255 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFeeScheduleFee(t *testing.T) {
+	tiers, err := json.Marshal([]FeeTier{
+		{UpTo: 1000, FlatAmount: 50},
+		{UpTo: 10000, PercentageBps: 100},
+		{PercentageBps: 50},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		schedule FeeSchedule
+		amount   int64
+		fee      int64
+	}{
+		{
+			name:     "Flat",
+			schedule: FeeSchedule{Kind: FeeFlat, FlatAmount: 25},
+			amount:   5000,
+			fee:      25,
+		},
+		{
+			name:     "Percentage",
+			schedule: FeeSchedule{Kind: FeePercentage, FlatAmount: 30, PercentageBps: 150},
+			amount:   1000,
+			fee:      45,
+		},
+		{
+			name:     "PercentageRoundsHalfUp",
+			schedule: FeeSchedule{Kind: FeePercentage, PercentageBps: 150},
+			amount:   100,
+			fee:      2,
+		},
+		{
+			name:     "Min",
+			schedule: FeeSchedule{Kind: FeePercentage, PercentageBps: 100, MinFee: 10},
+			amount:   100,
+			fee:      10,
+		},
+		{
+			name:     "Max",
+			schedule: FeeSchedule{Kind: FeePercentage, PercentageBps: 100, MaxFee: sql.NullInt64{Int64: 500, Valid: true}},
+			amount:   1000000,
+			fee:      500,
+		},
+		{
+			name:     "FirstTier",
+			schedule: FeeSchedule{Kind: FeeTiered, Tiers: tiers},
+			amount:   1000,
+			fee:      50,
+		},
+		{
+			name:     "MiddleTier",
+			schedule: FeeSchedule{Kind: FeeTiered, Tiers: tiers},
+			amount:   5000,
+			fee:      50,
+		},
+		{
+			name:     "LastTier",
+			schedule: FeeSchedule{Kind: FeeTiered, Tiers: tiers},
+			amount:   20000,
+			fee:      100,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			fee, err := tc.schedule.Fee(tc.amount)
+			require.NoError(t, err)
+			require.Equal(t, tc.fee, fee)
+		})
+	}
+
+	_, err = FeeSchedule{Kind: FeeTiered, Tiers: json.RawMessage(`[{"up_to": 10}]`)}.Fee(100)
+	require.Error(t, err)
+}
+
+func TestTransferTxWithFee(t *testing.T) {
+	store := NewStore(testDB)
+
+	// a transfer type of its own keeps the schedule away from other tests
+	transferType := util.RandomString(10)
+	schedule, err := testQueries.CreateFeeSchedule(context.Background(), CreateFeeScheduleParams{
+		Currency:      util.USD,
+		TransferType:  transferType,
+		Kind:          FeePercentage,
+		FlatAmount:    1,
+		PercentageBps: 100,
+		Tiers:         json.RawMessage(`[]`),
+	})
+	require.NoError(t, err)
+
+	feeAccount, err := testQueries.GetAccountByOwner(context.Background(), GetAccountByOwnerParams{
+		Owner:    FeeIncomeOwner,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+
+	account1, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Balance:  1000,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	// the fee counts towards the funds check
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        995,
+		TransferType:  transferType,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        500,
+		TransferType:  transferType,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Fee)
+	require.Equal(t, schedule.ID, result.Fee.ScheduleID)
+	require.Equal(t, int64(6), result.Fee.Amount)
+	require.Equal(t, feeAccount.ID, result.Fee.Transfer.ToAccountID)
+	require.Equal(t, int64(-6), result.Fee.Entry.Amount)
+	require.Equal(t, result.Fee.Transfer.ID, result.Fee.Entry.TransferID.Int64)
+	require.Equal(t, int64(494), result.FromAccount.Balance)
+	require.Equal(t, int64(500), result.ToAccount.Balance)
+
+	// without a schedule no fee is charged
+	result, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		TransferType:  util.RandomString(10),
+	})
+	require.NoError(t, err)
+	require.Nil(t, result.Fee)
+	require.Equal(t, int64(484), result.FromAccount.Balance)
+}
+
+func TestMultiTransferTxWithFee(t *testing.T) {
+	store := NewStore(testDB)
+
+	transferType := util.RandomString(10)
+	schedule, err := testQueries.CreateFeeSchedule(context.Background(), CreateFeeScheduleParams{
+		Currency:      util.USD,
+		TransferType:  transferType,
+		Kind:          FeePercentage,
+		FlatAmount:    1,
+		PercentageBps: 100,
+		Tiers:         json.RawMessage(`[]`),
+	})
+	require.NoError(t, err)
+
+	account1, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Balance:  1000,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+	account2 := createRandomAccountWithBalance(t, 0)
+	account3 := createRandomAccountWithBalance(t, 0)
+
+	// the fees of every leg count towards the funds check
+	_, err = store.MultiTransferTx(context.Background(), MultiTransferTxParams{
+		FromAccountID: account1.ID,
+		Legs: []TransferLeg{
+			{ToAccountID: account2.ID, Amount: 495},
+			{ToAccountID: account3.ID, Amount: 495},
+		},
+		TransferType: transferType,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// each leg is charged the fee of a transfer of its amount
+	result, err := store.MultiTransferTx(context.Background(), MultiTransferTxParams{
+		FromAccountID: account1.ID,
+		Legs: []TransferLeg{
+			{ToAccountID: account2.ID, Amount: 500},
+			{ToAccountID: account3.ID, Amount: 100},
+		},
+		TransferType: transferType,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Legs, 2)
+	for i, fee := range []int64{6, 2} {
+		leg := result.Legs[i]
+		require.NotNil(t, leg.Fee)
+		require.Equal(t, schedule.ID, leg.Fee.ScheduleID)
+		require.Equal(t, fee, leg.Fee.Amount)
+		require.Equal(t, -fee, leg.Fee.Entry.Amount)
+	}
+	require.Equal(t, int64(392), result.FromAccount.Balance)
+}
+
+func TestExchangeTransferTxWithFee(t *testing.T) {
+	store := NewStore(testDB)
+
+	transferType := util.RandomString(10)
+	schedule, err := testQueries.CreateFeeSchedule(context.Background(), CreateFeeScheduleParams{
+		Currency:     util.USD,
+		TransferType: transferType,
+		Kind:         FeeFlat,
+		FlatAmount:   25,
+		Tiers:        json.RawMessage(`[]`),
+	})
+	require.NoError(t, err)
+
+	account1 := createAccountInCurrency(t, 1000, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.EUR)
+	_, err = store.CreateFxRate(context.Background(), CreateFxRateParams{
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         "0.9",
+	})
+	require.NoError(t, err)
+
+	_, err = store.ExchangeTransferTx(context.Background(), ExchangeTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        980,
+		TransferType:  transferType,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// the fee is charged in the currency of the from account, on top of the amount
+	result, err := store.ExchangeTransferTx(context.Background(), ExchangeTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        500,
+		TransferType:  transferType,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Fee)
+	require.Equal(t, schedule.ID, result.Fee.ScheduleID)
+	require.Equal(t, int64(25), result.Fee.Amount)
+	require.Equal(t, int64(25), result.Fee.Transfer.ToAmount)
+	require.Equal(t, int64(475), result.FromAccount.Balance)
+	require.Equal(t, result.ToAmount, result.ToAccount.Balance)
+}
@@ -0,0 +1,15 @@
+package db
+
+// Directions of the account history
+const (
+	HistoryIn  = "in"
+	HistoryOut = "out"
+)
+
+// Direction is in when the entry credited the account and out when it debited it
+func (row ListAccountHistoryRow) Direction() string {
+	if row.Amount < 0 {
+		return HistoryOut
+	}
+	return HistoryIn
+}
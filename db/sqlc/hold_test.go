@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestHoldCapture(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	authorized, err := store.AuthorizeHoldTx(context.Background(), AuthorizeHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      80,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldActive, authorized.Hold.Status)
+
+	// the held funds can't be spent by a transfer
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: authorized.Hold.ID,
+		Amount: 90,
+	})
+	require.ErrorIs(t, err, ErrHoldAmountExceeded)
+
+	captured, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: authorized.Hold.ID,
+		Amount: 50,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldCaptured, captured.Hold.Status)
+	require.Equal(t, int64(50), captured.Hold.CapturedAmount)
+	require.Equal(t, captured.Transfer.ID, captured.Hold.TransferID.Int64)
+	require.Equal(t, int64(50), captured.FromAccount.Balance)
+	require.Equal(t, int64(50), captured.ToAccount.Balance)
+
+	// the rest of the hold is released
+	held, err := testQueries.GetHeldAmount(context.Background(), GetHeldAmountParams{AccountID: account1.ID, Now: time.Now()})
+	require.NoError(t, err)
+	require.Zero(t, held)
+
+	_, err = store.VoidHoldTx(context.Background(), authorized.Hold.ID)
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestHoldVoidAndExpire(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	voided, err := store.AuthorizeHoldTx(context.Background(), AuthorizeHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      60,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	hold, err := store.VoidHoldTx(context.Background(), voided.Hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldVoided, hold.Status)
+
+	expired, err := store.AuthorizeHoldTx(context.Background(), AuthorizeHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      100,
+		ExpiresAt:   time.Now().Add(time.Second),
+	})
+	require.NoError(t, err)
+
+	holds, err := testQueries.ExpireHolds(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.NotEmpty(t, holds)
+
+	hold, err = testQueries.GetHold(context.Background(), expired.Hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldExpired, hold.Status)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: expired.Hold.ID,
+		Amount: 100,
+	})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const idempotencyKeyPrimaryKey = "idempotency_keys_pkey"
+
+// IdempotencyParams identifies a client request that must be applied at most once
+type IdempotencyParams struct {
+	Username string
+	Key      string
+}
+
+// hashRequest returns a stable fingerprint of the request the key is used with
+func hashRequest(request any) (string, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// loadIdempotentResponse looks up a key and decodes the stored response into result.
+// It reports whether the key was found.
+func loadIdempotentResponse(ctx context.Context, queries *Queries, arg IdempotencyParams, requestHash string, result any) (bool, error) {
+	stored, err := queries.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Username: arg.Username,
+		Key:      arg.Key,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if stored.RequestHash != requestHash {
+		return true, ErrIdempotencyKeyReused
+	}
+
+	if err = json.Unmarshal(stored.Response, result); err != nil {
+		return true, fmt.Errorf("failed to unmarshal stored response: %w", err)
+	}
+	return true, nil
+}
+
+// saveIdempotentResponse stores the response under the key. A concurrent request with the same key
+// blocks on the primary key until this transaction ends, then fails with a unique violation.
+func saveIdempotentResponse(ctx context.Context, queries *Queries, arg IdempotencyParams, requestHash string, response any) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	_, err = queries.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+		Username:    arg.Username,
+		Key:         arg.Key,
+		RequestHash: requestHash,
+		Response:    data,
+	})
+	return err
+}
+
+// execIdempotentTX executes fn within a database transaction started with opts and saves response under the idempotency key
+// in that same transaction. When the key was seen before, fn is skipped and the saved response is decoded
+// into response instead. It reports whether the response was replayed.
+func (store *SQLStore) execIdempotentTX(
+	ctx context.Context,
+	opts *sql.TxOptions,
+	arg *IdempotencyParams,
+	request any,
+	response any,
+	fn func(queries *Queries) error,
+) (bool, error) {
+	if arg == nil {
+		return false, store.execTXWithOptions(ctx, opts, fn)
+	}
+
+	requestHash, err := hashRequest(request)
+	if err != nil {
+		return false, err
+	}
+
+	var replayed bool
+	err = store.execTXWithOptions(ctx, opts, func(queries *Queries) error {
+		var err error
+		replayed, err = loadIdempotentResponse(ctx, queries, *arg, requestHash, response)
+		if replayed || err != nil {
+			return err
+		}
+
+		err = fn(queries)
+		if err != nil {
+			return err
+		}
+
+		return saveIdempotentResponse(ctx, queries, *arg, requestHash, response)
+	})
+	if isUniqueViolation(err, idempotencyKeyPrimaryKey) {
+		// a concurrent request with the same key committed first, so replay its response
+		replayed, err = loadIdempotentResponse(ctx, store.Queries, *arg, requestHash, response)
+	}
+
+	return replayed, err
+}
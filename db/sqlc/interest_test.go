@@ -0,0 +1,101 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAccrueAndPostInterest(t *testing.T) {
+	store := NewStore(testDB)
+
+	// 36.5% a year is 0.1% a day
+	product, err := testQueries.CreateInterestProduct(context.Background(), CreateInterestProductParams{
+		Name:          util.RandomOwner(),
+		Currency:      util.USD,
+		AnnualRateBps: 3650,
+	})
+	require.NoError(t, err)
+
+	user := createRandomUser(t)
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  1250,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+	account, err = testQueries.UpdateAccountInterestProduct(context.Background(), UpdateAccountInterestProductParams{
+		InterestProductID: sql.NullInt64{Int64: product.ID, Valid: true},
+		ID:                account.ID,
+	})
+	require.NoError(t, err)
+
+	expense, err := testQueries.GetAccountByOwner(context.Background(), GetAccountByOwnerParams{
+		Owner:    InterestExpenseOwner,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+
+	accrue := func(date time.Time) {
+		accruals, err := testQueries.AccrueInterest(context.Background(), AccrueInterestParams{
+			AccrualDate: date,
+			DayEnd:      date.AddDate(0, 0, 1),
+		})
+		require.NoError(t, err)
+
+		var found bool
+		for _, accrual := range accruals {
+			if accrual.AccountID == account.ID {
+				found = true
+				require.Equal(t, int64(1250), accrual.Balance)
+				require.Equal(t, "1.2500000000", accrual.Amount)
+			}
+		}
+		require.True(t, found)
+	}
+
+	january := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	accrue(time.Date(2000, time.January, 30, 0, 0, 0, 0, time.UTC))
+	accrue(time.Date(2000, time.January, 31, 0, 0, 0, 0, time.UTC))
+	accrue(time.Date(2000, time.February, 1, 0, 0, 0, 0, time.UTC))
+
+	result, err := store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Period:    january.AddDate(0, 0, 14),
+	})
+	require.NoError(t, err)
+	require.True(t, january.Equal(result.Posting.Period))
+	require.Equal(t, "2.5000000000", result.Posting.Accrued)
+	require.Equal(t, int64(2), result.Posting.Amount)
+	require.Equal(t, "0.5000000000", result.Posting.Carried)
+	require.Equal(t, expense.ID, result.Transfer.Transfer.FromAccountID)
+	require.Equal(t, int64(1252), result.Transfer.ToAccount.Balance)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Posting.TransferID.Int64)
+
+	_, err = store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Period:    january,
+	})
+	require.ErrorIs(t, err, ErrInterestAlreadyPosted)
+
+	// the half unit left over in january is paid with february's interest
+	result, err = store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Period:    january.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "1.7500000000", result.Posting.Accrued)
+	require.Equal(t, int64(1), result.Posting.Amount)
+	require.Equal(t, "0.7500000000", result.Posting.Carried)
+	require.Equal(t, int64(1253), result.Transfer.ToAccount.Balance)
+
+	postings, err := store.ListInterestPostings(context.Background(), ListInterestPostingsParams{
+		AccountID: account.ID,
+		Limit:     5,
+	})
+	require.NoError(t, err)
+	require.Len(t, postings, 2)
+}
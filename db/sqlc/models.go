@@ -5,6 +5,8 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +18,42 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// how far below zero the balance may go, must be non-negative
+	OverdraftLimit    int64         `json:"overdraft_limit"`
+	InterestProductID sql.NullInt64 `json:"interest_product_id"`
+	// active, frozen, closed or dormant
+	Status          string    `json:"status"`
+	StatusChangedAt time.Time `json:"status_changed_at"`
+	// a joint account can be shared with other users through account_members
+	Joint bool `json:"joint"`
+	// set on savings pots, the account a pot sets money aside from
+	ParentAccountID sql.NullInt64 `json:"parent_account_id"`
+	// the user who froze the account, only they or an officer can unfreeze it
+	FrozenBy sql.NullString `json:"frozen_by"`
+}
+
+// the users a joint account is shared with, the holder in accounts.owner is always an owner and has no row
+type AccountMember struct {
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+	// owner, can_transfer or view_only
+	Role string `json:"role"`
+	// invited until the user accepts, then active
+	Status     string       `json:"status"`
+	InvitedBy  string       `json:"invited_by"`
+	AcceptedAt sql.NullTime `json:"accepted_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type Currency struct {
+	// ISO 4217 alphabetic code
+	Code string `json:"code"`
+	// number of minor unit digits, 2 for USD and 0 for JPY
+	Exponent int32 `json:"exponent"`
+	// new accounts and transfers are only allowed in enabled currencies
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Entry struct {
@@ -24,6 +62,129 @@ type Entry struct {
 	// can be positive or negative
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// the transfer that wrote this entry
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	// balance of the account right after the entry was booked
+	BalanceAfter int64 `json:"balance_after"`
+}
+
+type FeeSchedule struct {
+	ID       int64  `json:"id"`
+	Currency string `json:"currency"`
+	// transfer or standing_order
+	TransferType string `json:"transfer_type"`
+	// flat, percentage or tiered
+	Kind       string `json:"kind"`
+	FlatAmount int64  `json:"flat_amount"`
+	// share of the amount charged on top of flat_amount, in basis points
+	PercentageBps int32 `json:"percentage_bps"`
+	// for tiered fees, [{"up_to", "flat_amount", "percentage_bps"}] in ascending up_to order, the last tier has no up_to
+	Tiers  json.RawMessage `json:"tiers"`
+	MinFee int64           `json:"min_fee"`
+	// no cap when null
+	MaxFee    sql.NullInt64 `json:"max_fee"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type FxRate struct {
+	ID           int64  `json:"id"`
+	FromCurrency string `json:"from_currency"`
+	ToCurrency   string `json:"to_currency"`
+	// units of to_currency for one unit of from_currency, must be positive
+	Rate string `json:"rate"`
+	// margin taken off the converted amount, in basis points
+	SpreadBps int32     `json:"spread_bps"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Hold struct {
+	ID          int64 `json:"id"`
+	AccountID   int64 `json:"account_id"`
+	ToAccountID int64 `json:"to_account_id"`
+	// reserved on account_id until the hold is captured, voided or expires
+	Amount         int64 `json:"amount"`
+	CapturedAmount int64 `json:"captured_amount"`
+	// active, captured, voided or expired
+	Status string `json:"status"`
+	// the transfer made when the hold was captured
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	// the user who authorized the hold, the capture counts against their transfer limits
+	InitiatedBy sql.NullString `json:"initiated_by"`
+}
+
+type IdempotencyKey struct {
+	Username string `json:"username"`
+	Key      string `json:"key"`
+	// sha256 of the request the key was first used with
+	RequestHash string          `json:"request_hash"`
+	Response    json.RawMessage `json:"response"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+type InterestAccrual struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"account_id"`
+	AccrualDate time.Time `json:"accrual_date"`
+	// end of day balance the interest was computed on
+	Balance       int64 `json:"balance"`
+	AnnualRateBps int32 `json:"annual_rate_bps"`
+	// interest earned on the day, kept below the smallest currency unit
+	Amount string `json:"amount"`
+	// the monthly posting that paid this accrual
+	PostingID sql.NullInt64 `json:"posting_id"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type InterestPosting struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// first day of the month the interest was accrued in
+	Period time.Time `json:"period"`
+	// interest accrued in the period plus the fraction carried from the previous posting
+	Accrued string `json:"accrued"`
+	// whole units of accrued that were paid
+	Amount int64 `json:"amount"`
+	// fraction of accrued left over for the next posting
+	Carried string `json:"carried"`
+	// the transfer from the interest expense account, null when nothing was paid
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type InterestProduct struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+	// yearly interest rate in basis points, accrued daily on a 365 day year
+	AnnualRateBps int32     `json:"annual_rate_bps"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// the savings goal of a pot account, its balance is kept in accounts like any other account
+type Pot struct {
+	AccountID  int64         `json:"account_id"`
+	Name       string        `json:"name"`
+	GoalAmount sql.NullInt64 `json:"goal_amount"`
+	TargetDate sql.NullTime  `json:"target_date"`
+	// payments from the parent account are rounded up to a multiple of it and the difference is moved into the pot
+	RoundUpTo sql.NullInt64 `json:"round_up_to"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+type ReconciliationRun struct {
+	ID int64 `json:"id"`
+	// ok or drift
+	Status              string          `json:"status"`
+	DriftedAccounts     int32           `json:"drifted_accounts"`
+	OrphanedEntries     int32           `json:"orphaned_entries"`
+	UnbalancedTransfers int32           `json:"unbalanced_transfers"`
+	Report              json.RawMessage `json:"report"`
+	StartedAt           time.Time       `json:"started_at"`
+	FinishedAt          time.Time       `json:"finished_at"`
 }
 
 type Session struct {
@@ -37,6 +198,39 @@ type Session struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+type StandingOrder struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	// once, daily, weekly, monthly or cron
+	Schedule       string       `json:"schedule"`
+	CronExpression string       `json:"cron_expression"`
+	StartAt        time.Time    `json:"start_at"`
+	EndAt          sql.NullTime `json:"end_at"`
+	NextRunAt      time.Time    `json:"next_run_at"`
+	// what to do when funds are insufficient: retry or skip
+	FailurePolicy string `json:"failure_policy"`
+	MaxRetries    int32  `json:"max_retries"`
+	// active, paused, completed or cancelled
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type StandingOrderRun struct {
+	ID              int64     `json:"id"`
+	StandingOrderID int64     `json:"standing_order_id"`
+	ScheduledAt     time.Time `json:"scheduled_at"`
+	Attempt         int32     `json:"attempt"`
+	// succeeded, failed or skipped
+	Status     string        `json:"status"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	Error      string        `json:"error"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
 type Transfer struct {
 	ID            int64 `json:"id"`
 	FromAccountID int64 `json:"from_account_id"`
@@ -44,6 +238,72 @@ type Transfer struct {
 	// must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// amount credited in the destination currency, must be positive
+	ToAmount int64 `json:"to_amount"`
+	// rate applied to a cross-currency transfer
+	FxRate      sql.NullString `json:"fx_rate"`
+	FxSpreadBps sql.NullInt32  `json:"fx_spread_bps"`
+	// the transfer this one reverses
+	ReversalOf sql.NullInt64 `json:"reversal_of"`
+	// part of amount that has been reversed
+	ReversedAmount int64 `json:"reversed_amount"`
+	// free text from the sender, shown to both parties
+	Memo string `json:"memo"`
+	// the sender's own id for the transfer, unique per from account
+	ClientReference sql.NullString `json:"client_reference"`
+	// a JSON object attached by the sender
+	Metadata json.RawMessage `json:"metadata"`
+	// the user who made the transfer, null for the ones the bank makes; user transfer limits count against them
+	InitiatedBy sql.NullString `json:"initiated_by"`
+}
+
+type TransferLimit struct {
+	ID int64 `json:"id"`
+	// account limits apply to each account on its own, user limits to all accounts of the user in the currency
+	Scope    string `json:"scope"`
+	Currency string `json:"currency"`
+	// null for the default limits, set for a user override
+	Username sql.NullString `json:"username"`
+	// null means no limit, or the default limit for an override
+	MaxSingle    sql.NullInt64 `json:"max_single"`
+	DailyTotal   sql.NullInt64 `json:"daily_total"`
+	MonthlyTotal sql.NullInt64 `json:"monthly_total"`
+	HourlyCount  sql.NullInt32 `json:"hourly_count"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+type TransferRequest struct {
+	ID            int64 `json:"id"`
+	FromAccountID int64 `json:"from_account_id"`
+	// null when the request pays several accounts, see transfer_request_legs
+	ToAccountID sql.NullInt64 `json:"to_account_id"`
+	// debited in the currency of the from account, must be positive
+	Amount      int64  `json:"amount"`
+	RequestedBy string `json:"requested_by"`
+	// pending, approved, rejected or expired
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// the user who approved or rejected the request, null while pending or once expired
+	DecidedBy sql.NullString `json:"decided_by"`
+	DecidedAt sql.NullTime   `json:"decided_at"`
+	Reason    string         `json:"reason"`
+	// the transfer made when the request was approved
+	TransferID      sql.NullInt64   `json:"transfer_id"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Memo            string          `json:"memo"`
+	ClientReference sql.NullString  `json:"client_reference"`
+	Metadata        json.RawMessage `json:"metadata"`
+}
+
+// the accounts a multi transfer waiting for approval pays, in the order of the request
+type TransferRequestLeg struct {
+	ID                int64 `json:"id"`
+	TransferRequestID int64 `json:"transfer_request_id"`
+	ToAccountID       int64 `json:"to_account_id"`
+	Amount            int64 `json:"amount"`
+	// the transfer made for the leg when the request was approved
+	TransferID sql.NullInt64 `json:"transfer_id"`
 }
 
 type User struct {
@@ -53,4 +313,6 @@ type User struct {
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	Email             string    `json:"email"`
 	CreatedAt         time.Time `json:"created_at"`
+	// customer, officer or admin, officers can approve transfer requests and admins manage currencies
+	Role string `json:"role"`
 }
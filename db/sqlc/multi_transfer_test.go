@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMultiTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	fromAccount := createRandomAccountWithBalance(t, 1000)
+	toAccount1 := createRandomAccount(t)
+	toAccount2 := createRandomAccount(t)
+
+	result, err := store.MultiTransferTx(context.Background(), MultiTransferTxParams{
+		FromAccountID: fromAccount.ID,
+		Legs: []TransferLeg{
+			{ToAccountID: toAccount1.ID, Amount: 100},
+			{ToAccountID: toAccount2.ID, Amount: 200},
+			{ToAccountID: toAccount1.ID, Amount: 50},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Legs, 3)
+	require.Equal(t, int64(1000-350), result.FromAccount.Balance)
+
+	for i, leg := range result.Legs {
+		require.Equal(t, fromAccount.ID, leg.Transfer.FromAccountID)
+		require.Equal(t, leg.Transfer.Amount, -leg.FromEntry.Amount)
+		require.Equal(t, leg.Transfer.Amount, leg.ToEntry.Amount)
+		require.NotZero(t, leg.Transfer.ID, i)
+	}
+
+	updated1, err := store.GetAccount(context.Background(), toAccount1.ID)
+	require.NoError(t, err)
+	require.Equal(t, toAccount1.Balance+150, updated1.Balance)
+
+	updated2, err := store.GetAccount(context.Background(), toAccount2.ID)
+	require.NoError(t, err)
+	require.Equal(t, toAccount2.Balance+200, updated2.Balance)
+}
+
+func TestMultiTransferTxAllOrNothing(t *testing.T) {
+	store := NewStore(testDB)
+
+	fromAccount := createRandomAccountWithBalance(t, 100)
+	toAccount1 := createRandomAccount(t)
+	toAccount2 := createRandomAccount(t)
+
+	_, err := store.MultiTransferTx(context.Background(), MultiTransferTxParams{
+		FromAccountID: fromAccount.ID,
+		Legs: []TransferLeg{
+			{ToAccountID: toAccount1.ID, Amount: 60},
+			{ToAccountID: toAccount2.ID, Amount: 60},
+		},
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	for _, account := range []Account{fromAccount, toAccount1, toAccount2} {
+		updated, err := store.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Equal(t, account.Balance, updated.Balance)
+	}
+}
+
+func TestMultiTransferTxDeadlock(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithBalance(t, 1000)
+	account3 := createRandomAccountWithBalance(t, 1000)
+	accounts := []Account{account1, account2, account3}
+
+	n := 9
+	errs := make(chan error)
+
+	// every account pays the other two, concurrently
+	for i := 0; i < n; i++ {
+		from := accounts[i%3]
+		legs := []TransferLeg{
+			{ToAccountID: accounts[(i+1)%3].ID, Amount: 10},
+			{ToAccountID: accounts[(i+2)%3].ID, Amount: 10},
+		}
+		go func() {
+			_, err := store.MultiTransferTx(context.Background(), MultiTransferTxParams{
+				FromAccountID: from.ID,
+				Legs:          legs,
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	for _, account := range accounts {
+		updated, err := store.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Equal(t, account.Balance, updated.Balance)
+	}
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// PotProgress reports how far a pot is from its goal
+type PotProgress struct {
+	Saved int64 `json:"saved"`
+	// Remaining is what is left to save, zero once the goal is reached
+	Remaining int64 `json:"remaining"`
+	// Percent is the share of the goal saved so far, rounded down and capped at 100
+	Percent int64 `json:"percent"`
+	// MonthlyTarget is what has to be saved each month to reach the goal by the target date,
+	// it is set when the pot has both a goal and a target date
+	MonthlyTarget int64 `json:"monthly_target,omitempty"`
+}
+
+// Progress works out the progress of a pot towards its goal at now, a pot without a goal has no progress
+func (pot ListPotsRow) Progress(now time.Time) *PotProgress {
+	if !pot.GoalAmount.Valid {
+		return nil
+	}
+	goal := pot.GoalAmount.Int64
+
+	progress := &PotProgress{Saved: pot.Balance}
+	if pot.Balance >= goal {
+		progress.Percent = 100
+		return progress
+	}
+	progress.Remaining = goal - pot.Balance
+	if pot.Balance > 0 {
+		percent := new(big.Int).Mul(big.NewInt(pot.Balance), big.NewInt(100))
+		progress.Percent = percent.Quo(percent, big.NewInt(goal)).Int64()
+	}
+
+	if pot.TargetDate.Valid {
+		months := monthsUntil(now, pot.TargetDate.Time)
+		progress.MonthlyTarget = (progress.Remaining + months - 1) / months
+	}
+	return progress
+}
+
+// monthsUntil counts the months left to save in, the current month included, at least 1
+func monthsUntil(now time.Time, target time.Time) int64 {
+	months := int64(target.Year()-now.Year())*12 + int64(target.Month()-now.Month()) + 1
+	if months < 1 {
+		return 1
+	}
+	return months
+}
+
+// roundUpAmount returns what rounds amount up to the next multiple of to, zero when it already is one
+func roundUpAmount(amount int64, to int64) int64 {
+	if to <= 0 {
+		return 0
+	}
+	if rest := amount % to; rest != 0 {
+		return to - rest
+	}
+	return 0
+}
+
+// isPotMove tells if the money moves between a pot and its parent account
+func isPotMove(fromAccount, toAccount Account) bool {
+	return (fromAccount.ParentAccountID.Valid && fromAccount.ParentAccountID.Int64 == toAccount.ID) ||
+		(toAccount.ParentAccountID.Valid && toAccount.ParentAccountID.Int64 == fromAccount.ID)
+}
+
+// checkPotTransfer makes sure money only moves between a pot and its parent account.
+// The bank's own accounts can still pay interest into a pot.
+func checkPotTransfer(fromAccount, toAccount Account) error {
+	for _, pair := range [][2]Account{{fromAccount, toAccount}, {toAccount, fromAccount}} {
+		pot, other := pair[0], pair[1]
+		if !pot.ParentAccountID.Valid || pot.ParentAccountID.Int64 == other.ID {
+			continue
+		}
+		if other.Owner == FeeIncomeOwner || other.Owner == InterestExpenseOwner {
+			continue
+		}
+		return fmt.Errorf("%w: pot [%d] and account [%d]", ErrPotTransfer, pot.ID, other.ID)
+	}
+	return nil
+}
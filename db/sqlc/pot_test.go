@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createRandomPot(t *testing.T, store Store, parent Account, roundUpTo int64) PotTxResult {
+	result, err := store.CreatePotTx(context.Background(), CreatePotTxParams{
+		ParentAccountID: parent.ID,
+		Name:            "holiday",
+		GoalAmount:      sql.NullInt64{Int64: 1000, Valid: true},
+		RoundUpTo:       sql.NullInt64{Int64: roundUpTo, Valid: roundUpTo > 0},
+	})
+	require.NoError(t, err)
+	require.Equal(t, parent.Owner, result.Account.Owner)
+	require.Equal(t, parent.Currency, result.Account.Currency)
+	require.Equal(t, parent.ID, result.Account.ParentAccountID.Int64)
+	require.Equal(t, result.Account.ID, result.Pot.AccountID)
+
+	return result
+}
+
+func TestMovePotTx(t *testing.T) {
+	store := NewStore(testDB)
+	parent := createRandomAccountWithBalance(t, 1000)
+	pot := createRandomPot(t, store, parent, 0)
+
+	result, err := store.MovePotTx(context.Background(), MovePotTxParams{PotAccountID: pot.Account.ID, Amount: 300})
+	require.NoError(t, err)
+	require.Equal(t, int64(700), result.FromAccount.Balance)
+	require.Equal(t, int64(300), result.ToAccount.Balance)
+
+	result, err = store.MovePotTx(context.Background(), MovePotTxParams{PotAccountID: pot.Account.ID, Amount: 100, Withdraw: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(200), result.FromAccount.Balance)
+	require.Equal(t, int64(800), result.ToAccount.Balance)
+
+	_, err = store.MovePotTx(context.Background(), MovePotTxParams{PotAccountID: pot.Account.ID, Amount: 201, Withdraw: true})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	pots, err := store.ListPots(context.Background(), sql.NullInt64{Int64: parent.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, pots, 1)
+	progress := pots[0].Progress(time.Now())
+	require.Equal(t, int64(200), progress.Saved)
+	require.Equal(t, int64(800), progress.Remaining)
+	require.Equal(t, int64(20), progress.Percent)
+
+	// a pot only moves money with its parent
+	other := createRandomAccountWithBalance(t, 1000)
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: other.ID,
+		ToAccountID:   pot.Account.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrPotTransfer)
+
+	// the parent can't be closed while the pot is open
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: parent.ID})
+	require.ErrorIs(t, err, ErrAccountStatusTransition)
+
+	closed, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:      pot.Account.ID,
+		SweepAccountID: parent.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountClosed, closed.Account.Status)
+	require.Equal(t, int64(1000), closed.Sweep.ToAccount.Balance)
+}
+
+func TestTransferTxRoundUp(t *testing.T) {
+	store := NewStore(testDB)
+	parent := createRandomAccountWithBalance(t, 10000)
+	payee := createRandomAccountWithBalance(t, 0)
+	pot := createRandomPot(t, store, parent, 100)
+
+	_, err := store.CreatePotTx(context.Background(), CreatePotTxParams{
+		ParentAccountID: parent.ID,
+		Name:            "car",
+		RoundUpTo:       sql.NullInt64{Int64: 50, Valid: true},
+	})
+	require.ErrorIs(t, err, ErrRoundUpPotTaken)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: parent.ID,
+		ToAccountID:   payee.ID,
+		Amount:        1234,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.RoundUp)
+	require.Equal(t, int64(66), result.RoundUp.Transfer.Amount)
+	require.Equal(t, pot.Account.ID, result.RoundUp.ToAccount.ID)
+	require.Equal(t, result.RoundUp.FromAccount.Balance, result.FromAccount.Balance)
+
+	// an amount that is already round isn't rounded up
+	result, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: parent.ID,
+		ToAccountID:   payee.ID,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+	require.Nil(t, result.RoundUp)
+}
+
+func TestPotProgress(t *testing.T) {
+	now := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	pot := ListPotsRow{
+		Balance:    250,
+		GoalAmount: sql.NullInt64{Int64: 1000, Valid: true},
+		TargetDate: sql.NullTime{Time: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	progress := pot.Progress(now)
+	require.Equal(t, int64(25), progress.Percent)
+	require.Equal(t, int64(750), progress.Remaining)
+	// March, April and May are left
+	require.Equal(t, int64(250), progress.MonthlyTarget)
+
+	pot.Balance = 1200
+	progress = pot.Progress(now)
+	require.Equal(t, int64(100), progress.Percent)
+	require.Zero(t, progress.Remaining)
+
+	pot.GoalAmount = sql.NullInt64{}
+	require.Nil(t, pot.Progress(now))
+
+	require.Equal(t, int64(66), roundUpAmount(1234, 100))
+	require.Zero(t, roundUpAmount(1200, 100))
+}
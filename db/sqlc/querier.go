@@ -6,29 +6,126 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AcceptAccountMember(ctx context.Context, arg AcceptAccountMemberParams) (AccountMember, error)
+	AccrueInterest(ctx context.Context, arg AccrueInterestParams) ([]InterestAccrual, error)
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AdvanceStandingOrder(ctx context.Context, arg AdvanceStandingOrderParams) (StandingOrder, error)
+	BlockOtherSessions(ctx context.Context, arg BlockOtherSessionsParams) ([]Session, error)
+	BlockSession(ctx context.Context, arg BlockSessionParams) (Session, error)
+	CancelAccountStandingOrders(ctx context.Context, account_id int64) ([]StandingOrder, error)
+	CloseAccount(ctx context.Context, id int64) (Account, error)
+	CountOpenPots(ctx context.Context, parentAccountID sql.NullInt64) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountMember(ctx context.Context, arg CreateAccountMemberParams) (AccountMember, error)
+	CreateCurrency(ctx context.Context, arg CreateCurrencyParams) (Currency, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error)
+	CreateFxRate(ctx context.Context, arg CreateFxRateParams) (FxRate, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error)
+	CreateInterestProduct(ctx context.Context, arg CreateInterestProductParams) (InterestProduct, error)
+	CreatePot(ctx context.Context, arg CreatePotParams) (Pot, error)
+	CreateReconciliationRun(ctx context.Context, arg CreateReconciliationRunParams) (ReconciliationRun, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateStandingOrder(ctx context.Context, arg CreateStandingOrderParams) (StandingOrder, error)
+	CreateStandingOrderRun(ctx context.Context, arg CreateStandingOrderRunParams) (StandingOrderRun, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferLimit(ctx context.Context, arg CreateTransferLimitParams) (TransferLimit, error)
+	CreateTransferRequest(ctx context.Context, arg CreateTransferRequestParams) (TransferRequest, error)
+	CreateTransferRequestLeg(ctx context.Context, arg CreateTransferRequestLegParams) (TransferRequestLeg, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DecideTransferRequest(ctx context.Context, arg DecideTransferRequestParams) (TransferRequest, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteAccountMember(ctx context.Context, arg DeleteAccountMemberParams) (AccountMember, error)
+	DeleteTransferLimit(ctx context.Context, id int64) (TransferLimit, error)
+	ExpireHolds(ctx context.Context, now time.Time) ([]Hold, error)
+	ExpireTransferRequests(ctx context.Context, now time.Time) ([]TransferRequest, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountByOwner(ctx context.Context, arg GetAccountByOwnerParams) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountMember(ctx context.Context, arg GetAccountMemberParams) (AccountMember, error)
+	GetAccountTransferUsage(ctx context.Context, arg GetAccountTransferUsageParams) (GetAccountTransferUsageRow, error)
+	GetBalanceAsOf(ctx context.Context, arg GetBalanceAsOfParams) (int64, error)
+	GetCurrency(ctx context.Context, code string) (Currency, error)
+	GetEntriesTotalSince(ctx context.Context, arg GetEntriesTotalSinceParams) (int64, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error)
+	GetHeldAmount(ctx context.Context, arg GetHeldAmountParams) (int64, error)
+	GetHold(ctx context.Context, id int64) (Hold, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (Hold, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetInterestProduct(ctx context.Context, id int64) (InterestProduct, error)
+	GetLastInterestPosting(ctx context.Context, accountID int64) (InterestPosting, error)
+	GetLatestFxRate(ctx context.Context, arg GetLatestFxRateParams) (FxRate, error)
+	GetPot(ctx context.Context, accountID int64) (Pot, error)
+	GetRoundUpPot(ctx context.Context, parentAccountID sql.NullInt64) (Pot, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetStandingOrder(ctx context.Context, id int64) (StandingOrder, error)
+	GetStandingOrderForUpdate(ctx context.Context, id int64) (StandingOrder, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
+	GetTransferLimit(ctx context.Context, id int64) (TransferLimit, error)
+	GetTransferRequest(ctx context.Context, id int64) (TransferRequest, error)
+	GetTransferRequestForUpdate(ctx context.Context, id int64) (TransferRequest, error)
+	GetUnpostedInterest(ctx context.Context, arg GetUnpostedInterestParams) (string, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	GetUserTransferUsage(ctx context.Context, arg GetUserTransferUsageParams) (GetUserTransferUsageRow, error)
+	ListAccountHistory(ctx context.Context, arg ListAccountHistoryParams) ([]ListAccountHistoryRow, error)
+	ListAccountInvitations(ctx context.Context, username string) ([]AccountMember, error)
+	ListAccountMembers(ctx context.Context, accountID int64) ([]AccountMember, error)
+	ListAccountPendingTransferRequestsAfter(ctx context.Context, arg ListAccountPendingTransferRequestsAfterParams) ([]TransferRequest, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAccountsAfter(ctx context.Context, arg ListAccountsAfterParams) ([]Account, error)
+	ListAccountsWithUnpostedInterest(ctx context.Context, before time.Time) ([]int64, error)
+	ListActiveSessions(ctx context.Context, username string) ([]Session, error)
+	ListAllTransferLimits(ctx context.Context) ([]TransferLimit, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
+	ListDailyBalances(ctx context.Context, arg ListDailyBalancesParams) ([]ListDailyBalancesRow, error)
+	ListDriftedAccounts(ctx context.Context) ([]ListDriftedAccountsRow, error)
+	ListDueStandingOrders(ctx context.Context, arg ListDueStandingOrdersParams) ([]StandingOrder, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListEntriesAfter(ctx context.Context, arg ListEntriesAfterParams) ([]Entry, error)
+	ListHolds(ctx context.Context, arg ListHoldsParams) ([]Hold, error)
+	ListInterestPostings(ctx context.Context, arg ListInterestPostingsParams) ([]InterestPosting, error)
+	ListInterestProducts(ctx context.Context, currency string) ([]InterestProduct, error)
+	ListOrphanedEntries(ctx context.Context) ([]Entry, error)
+	ListPendingTransferRequests(ctx context.Context, arg ListPendingTransferRequestsParams) ([]TransferRequest, error)
+	ListPots(ctx context.Context, parentAccountID sql.NullInt64) ([]ListPotsRow, error)
+	ListReconciliationRuns(ctx context.Context, arg ListReconciliationRunsParams) ([]ReconciliationRun, error)
+	ListStandingOrderRuns(ctx context.Context, arg ListStandingOrderRunsParams) ([]StandingOrderRun, error)
+	ListStandingOrders(ctx context.Context, arg ListStandingOrdersParams) ([]StandingOrder, error)
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
+	ListTransferLimits(ctx context.Context, arg ListTransferLimitsParams) ([]TransferLimit, error)
+	ListTransferRequestLegs(ctx context.Context, transferRequestID int64) ([]TransferRequestLeg, error)
+	ListTransferRequests(ctx context.Context, arg ListTransferRequestsParams) ([]TransferRequest, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListTransfersAfter(ctx context.Context, arg ListTransfersAfterParams) ([]Transfer, error)
+	ListUnbalancedTransfers(ctx context.Context) ([]ListUnbalancedTransfersRow, error)
+	MarkDormantAccounts(ctx context.Context, arg MarkDormantAccountsParams) ([]Account, error)
+	MarkInterestPosted(ctx context.Context, arg MarkInterestPostedParams) error
+	MarkTransferReversed(ctx context.Context, arg MarkTransferReversedParams) (Transfer, error)
+	RotateSessionRefreshToken(ctx context.Context, arg RotateSessionRefreshTokenParams) (Session, error)
+	SetTransferRequestLegTransfer(ctx context.Context, arg SetTransferRequestLegTransferParams) (TransferRequestLeg, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountInterestProduct(ctx context.Context, arg UpdateAccountInterestProductParams) (Account, error)
+	UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdateCurrency(ctx context.Context, arg UpdateCurrencyParams) (Currency, error)
+	UpdateHold(ctx context.Context, arg UpdateHoldParams) (Hold, error)
+	UpdatePot(ctx context.Context, arg UpdatePotParams) (Pot, error)
+	UpdateStandingOrder(ctx context.Context, arg UpdateStandingOrderParams) (StandingOrder, error)
+	UpdateTransferLimit(ctx context.Context, arg UpdateTransferLimitParams) (TransferLimit, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReconcileTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	// balance seeded without any entries
+	drifted := createRandomAccountWithBalance(t, 100)
+	account := createRandomAccountWithBalance(t, 0)
+
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: drifted.ID,
+		ToAccountID:   account.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, transfer.Transfer.ID, transfer.FromEntry.TransferID.Int64)
+	require.Equal(t, transfer.Transfer.ID, transfer.ToEntry.TransferID.Int64)
+
+	result, err := store.ReconcileTx(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, ReconciliationDrift, result.Run.Status)
+	require.Equal(t, int32(len(result.Report.DriftedAccounts)), result.Run.DriftedAccounts)
+
+	var found bool
+	for _, row := range result.Report.DriftedAccounts {
+		require.NotEqual(t, account.ID, row.AccountID)
+		if row.AccountID == drifted.ID {
+			found = true
+			require.Equal(t, int64(90), row.Balance)
+			require.Equal(t, int64(-10), row.EntriesTotal)
+		}
+	}
+	require.True(t, found)
+
+	for _, row := range result.Report.UnbalancedTransfers {
+		require.NotEqual(t, transfer.Transfer.ID, row.TransferID)
+	}
+
+	var report ReconciliationReport
+	require.NoError(t, json.Unmarshal(result.Run.Report, &report))
+	require.Len(t, report.DriftedAccounts, len(result.Report.DriftedAccounts))
+
+	runs, err := store.ListReconciliationRuns(context.Background(), ListReconciliationRunsParams{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.GreaterOrEqual(t, runs[0].ID, result.Run.ID)
+}
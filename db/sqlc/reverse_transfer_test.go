@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReversalDebit(t *testing.T) {
+	original := Transfer{Amount: 1000, ToAmount: 915}
+
+	require.Equal(t, int64(915), reversalDebit(original, 1000))
+	require.Equal(t, int64(457), reversalDebit(original, 500))
+	require.Equal(t, int64(0), reversalDebit(original, 1))
+}
+
+func TestReverseTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithBalance(t, 1000)
+
+	original, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Amount:     40,
+	})
+	require.NoError(t, err)
+
+	reversal := result.Transfer
+	require.Equal(t, account2.ID, reversal.FromAccountID)
+	require.Equal(t, account1.ID, reversal.ToAccountID)
+	require.Equal(t, int64(40), reversal.Amount)
+	require.True(t, reversal.ReversalOf.Valid)
+	require.Equal(t, original.Transfer.ID, reversal.ReversalOf.Int64)
+	require.Equal(t, int64(40), result.OriginalTransfer.ReversedAmount)
+
+	require.Equal(t, int64(-40), result.FromEntry.Amount)
+	require.Equal(t, int64(40), result.ToEntry.Amount)
+	require.Equal(t, int64(1000-100+40), result.ToAccount.Balance)
+	require.Equal(t, int64(1000+100-40), result.FromAccount.Balance)
+
+	// the remaining 60 can't be reversed with a second reversal
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Amount:     60,
+	})
+	require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+
+	// nor can the reversal itself
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: reversal.ID,
+		Amount:     40,
+	})
+	require.ErrorIs(t, err, ErrTransferNotReversible)
+}
@@ -0,0 +1,10 @@
+package db
+
+// User roles
+const (
+	RoleCustomer = "customer"
+	// RoleOfficer is a bank officer, who can approve or reject any transfer request
+	RoleOfficer = "officer"
+	// RoleAdmin manages the reference data of the bank, like the currency registry
+	RoleAdmin = "admin"
+)
@@ -0,0 +1,28 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+// Errors returned by Session.Check, the token that named the session must not be accepted
+var (
+	ErrSessionBlocked = errors.New("session is blocked")
+	ErrSessionUser    = errors.New("incorrect session user")
+	ErrSessionExpired = errors.New("expired session")
+)
+
+// Check makes sure a token of the given user can still be used with the session,
+// it fails once the session is revoked or has expired
+func (session Session) Check(username string, now time.Time) error {
+	if session.IsBlocked {
+		return ErrSessionBlocked
+	}
+	if session.Username != username {
+		return ErrSessionUser
+	}
+	if now.After(session.ExpiredAt) {
+		return ErrSessionExpired
+	}
+	return nil
+}
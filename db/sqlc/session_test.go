@@ -0,0 +1,116 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createRandomSession(t *testing.T, username string, duration time.Duration) Session {
+	arg := CreateSessionParams{
+		ID:           uuid.New(),
+		Username:     username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    "Mozilla/5.0",
+		ClientIp:     "127.0.0.1",
+		ExpiredAt:    time.Now().Add(duration),
+	}
+
+	session, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, session.ID)
+	require.False(t, session.IsBlocked)
+
+	return session
+}
+
+func TestListActiveSessions(t *testing.T) {
+	user := createRandomUser(t)
+	older := createRandomSession(t, user.Username, time.Hour)
+	newer := createRandomSession(t, user.Username, time.Hour)
+	createRandomSession(t, user.Username, -time.Minute)
+	blocked := createRandomSession(t, user.Username, time.Hour)
+	_, err := testQueries.BlockSession(context.Background(), BlockSessionParams{ID: blocked.ID, Username: user.Username})
+	require.NoError(t, err)
+
+	sessions, err := testQueries.ListActiveSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.Equal(t, newer.ID, sessions[0].ID)
+	require.Equal(t, older.ID, sessions[1].ID)
+}
+
+func TestBlockSession(t *testing.T) {
+	user := createRandomUser(t)
+	other := createRandomUser(t)
+	session := createRandomSession(t, user.Username, time.Hour)
+
+	// a user can't revoke the session of someone else
+	_, err := testQueries.BlockSession(context.Background(), BlockSessionParams{ID: session.ID, Username: other.Username})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	blocked, err := testQueries.BlockSession(context.Background(), BlockSessionParams{ID: session.ID, Username: user.Username})
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+	require.ErrorIs(t, blocked.Check(user.Username, time.Now()), ErrSessionBlocked)
+}
+
+func TestBlockOtherSessions(t *testing.T) {
+	user := createRandomUser(t)
+	current := createRandomSession(t, user.Username, time.Hour)
+	other1 := createRandomSession(t, user.Username, time.Hour)
+	other2 := createRandomSession(t, user.Username, time.Hour)
+
+	revoked, err := testQueries.BlockOtherSessions(context.Background(), BlockOtherSessionsParams{
+		Username: user.Username,
+		KeepID:   current.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, revoked, 2)
+	require.ElementsMatch(t, []uuid.UUID{other1.ID, other2.ID}, []uuid.UUID{revoked[0].ID, revoked[1].ID})
+
+	sessions, err := testQueries.ListActiveSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, current.ID, sessions[0].ID)
+}
+
+func TestSessionCheck(t *testing.T) {
+	session := Session{Username: "alice", ExpiredAt: time.Now().Add(time.Minute)}
+	require.NoError(t, session.Check("alice", time.Now()))
+	require.ErrorIs(t, session.Check("bob", time.Now()), ErrSessionUser)
+	require.ErrorIs(t, session.Check("alice", time.Now().Add(time.Hour)), ErrSessionExpired)
+
+	session.IsBlocked = true
+	require.ErrorIs(t, session.Check("alice", time.Now()), ErrSessionBlocked)
+}
+
+func TestRotateSessionRefreshToken(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username, time.Hour)
+
+	arg := RotateSessionRefreshTokenParams{
+		NewRefreshToken: util.RandomString(32),
+		ID:              session.ID,
+		OldRefreshToken: session.RefreshToken,
+	}
+	rotated, err := testQueries.RotateSessionRefreshToken(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.NewRefreshToken, rotated.RefreshToken)
+	require.Equal(t, session.ExpiredAt.Unix(), rotated.ExpiredAt.Unix())
+
+	// the retired token can't rotate the session again
+	arg.NewRefreshToken = util.RandomString(32)
+	_, err = testQueries.RotateSessionRefreshToken(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.BlockSession(context.Background(), BlockSessionParams{ID: session.ID, Username: user.Username})
+	require.NoError(t, err)
+	arg.OldRefreshToken = rotated.RefreshToken
+	_, err = testQueries.RotateSessionRefreshToken(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createRandomStandingOrder(t *testing.T, schedule string, endAt sql.NullTime) StandingOrder {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	startAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	arg := CreateStandingOrderParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        util.RandomMoney(),
+		Schedule:      schedule,
+		StartAt:       startAt,
+		EndAt:         endAt,
+		NextRunAt:     startAt,
+		FailurePolicy: StandingOrderRetry,
+		MaxRetries:    3,
+	}
+
+	order, err := testQueries.CreateStandingOrder(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, order)
+
+	require.Equal(t, arg.Owner, order.Owner)
+	require.Equal(t, arg.FromAccountID, order.FromAccountID)
+	require.Equal(t, arg.ToAccountID, order.ToAccountID)
+	require.Equal(t, arg.Amount, order.Amount)
+	require.Equal(t, arg.Schedule, order.Schedule)
+	require.WithinDuration(t, arg.NextRunAt, order.NextRunAt, time.Second)
+	require.Equal(t, StandingOrderActive, order.Status)
+	require.NotZero(t, order.CreatedAt)
+
+	return order
+}
+
+func TestRecordStandingOrderRunTx(t *testing.T) {
+	store := NewStore(testDB)
+	order := createRandomStandingOrder(t, util.ScheduleDaily, sql.NullTime{})
+
+	// a failed attempt that will be retried keeps the order on the same run
+	result, err := store.RecordStandingOrderRunTx(context.Background(), RecordStandingOrderRunTxParams{
+		StandingOrderID: order.ID,
+		ScheduledAt:     order.NextRunAt,
+		Attempt:         1,
+		Status:          StandingOrderRunFailed,
+		Error:           ErrInsufficientFunds.Error(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, StandingOrderRunFailed, result.Run.Status)
+	require.True(t, order.NextRunAt.Equal(result.StandingOrder.NextRunAt))
+
+	result, err = store.RecordStandingOrderRunTx(context.Background(), RecordStandingOrderRunTxParams{
+		StandingOrderID: order.ID,
+		ScheduledAt:     order.NextRunAt,
+		Attempt:         2,
+		Status:          StandingOrderRunSkipped,
+		Advance:         true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, StandingOrderActive, result.StandingOrder.Status)
+	require.True(t, order.NextRunAt.AddDate(0, 0, 1).Equal(result.StandingOrder.NextRunAt))
+
+	// the old run is stale once the order has moved on
+	_, err = store.RecordStandingOrderRunTx(context.Background(), RecordStandingOrderRunTxParams{
+		StandingOrderID: order.ID,
+		ScheduledAt:     order.NextRunAt,
+		Attempt:         3,
+		Status:          StandingOrderRunSucceeded,
+		Advance:         true,
+	})
+	require.ErrorIs(t, err, ErrStaleStandingOrderRun)
+
+	runs, err := testQueries.ListStandingOrderRuns(context.Background(), ListStandingOrderRunsParams{
+		StandingOrderID: order.ID,
+		Limit:           5,
+		Offset:          0,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+}
+
+func TestRecordStandingOrderRunTxCompletes(t *testing.T) {
+	store := NewStore(testDB)
+	order := createRandomStandingOrder(t, util.ScheduleOnce, sql.NullTime{})
+
+	result, err := store.RecordStandingOrderRunTx(context.Background(), RecordStandingOrderRunTxParams{
+		StandingOrderID: order.ID,
+		ScheduledAt:     order.NextRunAt,
+		Attempt:         1,
+		Status:          StandingOrderRunSucceeded,
+		Advance:         true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, StandingOrderCompleted, result.StandingOrder.Status)
+
+	endAt := sql.NullTime{Time: time.Now().Add(2 * time.Hour), Valid: true}
+	order = createRandomStandingOrder(t, util.ScheduleDaily, endAt)
+
+	result, err = store.RecordStandingOrderRunTx(context.Background(), RecordStandingOrderRunTxParams{
+		StandingOrderID: order.ID,
+		ScheduledAt:     order.NextRunAt,
+		Attempt:         1,
+		Status:          StandingOrderRunSucceeded,
+		Advance:         true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, StandingOrderCompleted, result.StandingOrder.Status)
+}
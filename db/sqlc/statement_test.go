@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAccountStatementTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	from := time.Now().Add(-time.Minute)
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+	})
+	require.NoError(t, err)
+	to := time.Now()
+
+	// entries after the period are excluded from the closing balance
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        20,
+	})
+	require.NoError(t, err)
+
+	result, err := store.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: account1.ID,
+		From:      from,
+		To:        to,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(100), result.OpeningBalance)
+	require.Equal(t, int64(70), result.ClosingBalance)
+	require.Len(t, result.Entries, 1)
+
+	entry := result.Entries[0]
+	require.Equal(t, transfer.FromEntry.ID, entry.ID)
+	require.Equal(t, int64(-30), entry.Amount)
+	require.Equal(t, transfer.Transfer.ID, entry.TransferID.Int64)
+	require.Equal(t, account2.ID, entry.ToAccountID.Int64)
+	require.Equal(t, account2.Owner, entry.CounterpartyOwner.String)
+}
@@ -11,26 +11,73 @@ import (
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	MultiTransferTx(ctx context.Context, arg MultiTransferTxParams) (MultiTransferTxResult, error)
+	ExchangeTransferTx(ctx context.Context, arg ExchangeTransferTxParams) (ExchangeTransferTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	AuthorizeHoldTx(ctx context.Context, arg AuthorizeHoldTxParams) (AuthorizeHoldTxResult, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	VoidHoldTx(ctx context.Context, holdID int64) (Hold, error)
+	AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	ReconcileTx(ctx context.Context) (ReconcileTxResult, error)
+	RecordStandingOrderRunTx(ctx context.Context, arg RecordStandingOrderRunTxParams) (RecordStandingOrderRunTxResult, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (Account, error)
+	CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error)
+	CreateTransferRequestTx(ctx context.Context, arg CreateTransferRequestTxParams) (CreateTransferRequestTxResult, error)
+	ApproveTransferRequestTx(ctx context.Context, arg ApproveTransferRequestTxParams) (ApproveTransferRequestTxResult, error)
+	RejectTransferRequestTx(ctx context.Context, arg RejectTransferRequestTxParams) (TransferRequest, error)
+	CreatePotTx(ctx context.Context, arg CreatePotTxParams) (PotTxResult, error)
+	UpdatePotTx(ctx context.Context, arg UpdatePotTxParams) (Pot, error)
+	MovePotTx(ctx context.Context, arg MovePotTxParams) (TransferTxResult, error)
+	CreateCurrencyTx(ctx context.Context, arg CreateCurrencyParams) (CreateCurrencyTxResult, error)
+	TxStats() TxStats
 }
 
 // SQLStore provides all functions execute db queries and transactions
 type SQLStore struct {
 	*Queries
-	db *sql.DB
+	db         *sql.DB
+	txRetry    TxRetryPolicy
+	txCounters txCounters
 }
 
 // NewStore returns a new store
-func NewStore(db *sql.DB) Store {
-	return &SQLStore{
+func NewStore(db *sql.DB, options ...StoreOption) Store {
+	store := &SQLStore{
 		Queries: New(db),
 		db:      db,
+		txRetry: DefaultTxRetryPolicy(),
 	}
+	for _, option := range options {
+		option(store)
+	}
+	return store
+}
+
+// the options each Tx method starts its transaction with
+var (
+	// moneyTxOptions is for the transactions that move or reserve money. Concurrent ones that read the same balances,
+	// holds and limits fail with a serialization failure and are retried, instead of both passing the checks
+	moneyTxOptions = &sql.TxOptions{Isolation: sql.LevelSerializable}
+	// recordTxOptions is for the transactions that only change records they lock first
+	recordTxOptions = &sql.TxOptions{Isolation: sql.LevelReadCommitted}
+)
+
+// execSnapshotTX executes a read-only function that sees a single consistent snapshot of the database
+func (store *SQLStore) execSnapshotTX(ctx context.Context, fn func(queries *Queries) error) error {
+	return store.execTXWithOptions(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}, fn)
+}
+
+// execTXWithOptions executes a function within a transaction started with opts.
+// fn runs again when the transaction fails on a serialization failure or a deadlock,
+// so it must not have side effects outside of the transaction
+func (store *SQLStore) execTXWithOptions(ctx context.Context, opts *sql.TxOptions, fn func(queries *Queries) error) error {
+	return store.execTXWithRetry(ctx, opts, fn)
 }
 
-// execTx executes a function within a database transaction
-func (store *SQLStore) execTX(ctx context.Context, fn func(queries *Queries) error) error {
-	tx, err := store.db.BeginTx(ctx, nil)
+func (store *SQLStore) runTX(ctx context.Context, opts *sql.TxOptions, fn func(queries *Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, opts)
 	if err != nil {
 		return err
 	}
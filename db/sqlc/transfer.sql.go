@@ -7,23 +7,53 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 )
 
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (from_account_id,
                        to_account_id,
-                       amount)
-VALUES ($1, $2, $3) RETURNING id, from_account_id, to_account_id, amount, created_at
+                       amount,
+                       to_amount,
+                       fx_rate,
+                       fx_spread_bps,
+                       reversal_of,
+                       memo,
+                       client_reference,
+                       metadata,
+                       initiated_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, fx_rate, fx_spread_bps, reversal_of, reversed_amount, memo, client_reference, metadata, initiated_by
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID   int64           `json:"from_account_id"`
+	ToAccountID     int64           `json:"to_account_id"`
+	Amount          int64           `json:"amount"`
+	ToAmount        int64           `json:"to_amount"`
+	FxRate          sql.NullString  `json:"fx_rate"`
+	FxSpreadBps     sql.NullInt32   `json:"fx_spread_bps"`
+	ReversalOf      sql.NullInt64   `json:"reversal_of"`
+	Memo            string          `json:"memo"`
+	ClientReference sql.NullString  `json:"client_reference"`
+	Metadata        json.RawMessage `json:"metadata"`
+	InitiatedBy     sql.NullString  `json:"initiated_by"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ToAmount,
+		arg.FxRate,
+		arg.FxSpreadBps,
+		arg.ReversalOf,
+		arg.Memo,
+		arg.ClientReference,
+		arg.Metadata,
+		arg.InitiatedBy,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -31,12 +61,21 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.FxRate,
+		&i.FxSpreadBps,
+		&i.ReversalOf,
+		&i.ReversedAmount,
+		&i.Memo,
+		&i.ClientReference,
+		&i.Metadata,
+		&i.InitiatedBy,
 	)
 	return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, fx_rate, fx_spread_bps, reversal_of, reversed_amount, memo, client_reference, metadata, initiated_by
 FROM transfers
 WHERE id = $1 LIMIT 1
 `
@@ -50,12 +89,51 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.FxRate,
+		&i.FxSpreadBps,
+		&i.ReversalOf,
+		&i.ReversedAmount,
+		&i.Memo,
+		&i.ClientReference,
+		&i.Metadata,
+		&i.InitiatedBy,
+	)
+	return i, err
+}
+
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, fx_rate, fx_spread_bps, reversal_of, reversed_amount, memo, client_reference, metadata, initiated_by
+FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY
+UPDATE
+`
+
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.FxRate,
+		&i.FxSpreadBps,
+		&i.ReversalOf,
+		&i.ReversedAmount,
+		&i.Memo,
+		&i.ClientReference,
+		&i.Metadata,
+		&i.InitiatedBy,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, fx_rate, fx_spread_bps, reversal_of, reversed_amount, memo, client_reference, metadata, initiated_by
 FROM transfers
 WHERE from_account_id = $1
    OR to_account_id = $2
@@ -90,6 +168,67 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ToAmount,
+			&i.FxRate,
+			&i.FxSpreadBps,
+			&i.ReversalOf,
+			&i.ReversedAmount,
+			&i.Memo,
+			&i.ClientReference,
+			&i.Metadata,
+			&i.InitiatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfersAfter = `-- name: ListTransfersAfter :many
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, fx_rate, fx_spread_bps, reversal_of, reversed_amount, memo, client_reference, metadata, initiated_by
+FROM transfers
+WHERE (from_account_id = $1 OR to_account_id = $1)
+  AND id > $2
+ORDER BY id LIMIT $3
+`
+
+type ListTransfersAfterParams struct {
+	AccountID  int64 `json:"account_id"`
+	AfterID    int64 `json:"after_id"`
+	LimitCount int32 `json:"limit_count"`
+}
+
+func (q *Queries) ListTransfersAfter(ctx context.Context, arg ListTransfersAfterParams) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, listTransfersAfter, arg.AccountID, arg.AfterID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.ToAmount,
+			&i.FxRate,
+			&i.FxSpreadBps,
+			&i.ReversalOf,
+			&i.ReversedAmount,
+			&i.Memo,
+			&i.ClientReference,
+			&i.Metadata,
+			&i.InitiatedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -103,3 +242,36 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 	}
 	return items, nil
 }
+
+const markTransferReversed = `-- name: MarkTransferReversed :one
+UPDATE transfers
+SET reversed_amount = $1
+WHERE id = $2 RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, fx_rate, fx_spread_bps, reversal_of, reversed_amount, memo, client_reference, metadata, initiated_by
+`
+
+type MarkTransferReversedParams struct {
+	ReversedAmount int64 `json:"reversed_amount"`
+	ID             int64 `json:"id"`
+}
+
+func (q *Queries) MarkTransferReversed(ctx context.Context, arg MarkTransferReversedParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, markTransferReversed, arg.ReversedAmount, arg.ID)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.FxRate,
+		&i.FxSpreadBps,
+		&i.ReversalOf,
+		&i.ReversedAmount,
+		&i.Memo,
+		&i.ClientReference,
+		&i.Metadata,
+		&i.InitiatedBy,
+	)
+	return i, err
+}
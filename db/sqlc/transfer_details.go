@@ -0,0 +1,38 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+const transferClientReferenceKey = "transfer_client_reference_key"
+
+// TransferDetails is what the sender says about a transfer, every field is optional
+type TransferDetails struct {
+	// Memo is free text shown to both parties
+	Memo string `json:"memo,omitempty"`
+	// ClientReference is the sender's own id for the transfer, it is unique among the transfers of the from account
+	ClientReference string `json:"client_reference,omitempty"`
+	// Metadata is a JSON object kept as it was sent
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+}
+
+func (details TransferDetails) clientReference() sql.NullString {
+	return sql.NullString{String: details.ClientReference, Valid: details.ClientReference != ""}
+}
+
+func (details TransferDetails) metadata() json.RawMessage {
+	if len(details.Metadata) == 0 {
+		return json.RawMessage(`{}`)
+	}
+	return details.Metadata
+}
+
+// Details returns the details the requester attached to the transfer request
+func (request TransferRequest) Details() TransferDetails {
+	return TransferDetails{
+		Memo:            request.Memo,
+		ClientReference: request.ClientReference.String,
+		Metadata:        request.Metadata,
+	}
+}
@@ -0,0 +1,243 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Transfer limit scopes
+const (
+	TransferLimitAccount = "account"
+	TransferLimitUser    = "user"
+)
+
+// Limits a transfer can hit
+const (
+	LimitMaxSingle    = "max_single"
+	LimitDailyTotal   = "daily_total"
+	LimitMonthlyTotal = "monthly_total"
+	LimitHourlyCount  = "hourly_count"
+)
+
+// TransferLimitError says which velocity limit an outgoing transfer would breach, errors.Is matches ErrTransferLimitExceeded
+type TransferLimitError struct {
+	Scope     string `json:"scope"`
+	Limit     string `json:"limit"`
+	Max       int64  `json:"max"`
+	Used      int64  `json:"used"`
+	Requested int64  `json:"requested"`
+	// ResetsAt is when enough of the usage falls out of the window for the transfer to go through, nil for max_single
+	ResetsAt *time.Time `json:"resets_at,omitempty"`
+}
+
+func (e *TransferLimitError) Error() string {
+	msg := fmt.Sprintf("%s: %s %s limit is %d, used %d, requested %d",
+		ErrTransferLimitExceeded, e.Scope, e.Limit, e.Max, e.Used, e.Requested)
+	if e.ResetsAt != nil {
+		msg += ", resets at " + e.ResetsAt.Format(time.RFC3339)
+	}
+	return msg
+}
+
+func (e *TransferLimitError) Is(target error) bool {
+	return target == ErrTransferLimitExceeded
+}
+
+// effectiveTransferLimit merges the default limits with the user's override, the override wins field by field
+type effectiveTransferLimit struct {
+	MaxSingle    sql.NullInt64
+	DailyTotal   sql.NullInt64
+	MonthlyTotal sql.NullInt64
+	HourlyCount  sql.NullInt32
+}
+
+func mergeTransferLimits(limits []TransferLimit) (limit effectiveTransferLimit) {
+	// defaults come first
+	for _, l := range limits {
+		if l.MaxSingle.Valid {
+			limit.MaxSingle = l.MaxSingle
+		}
+		if l.DailyTotal.Valid {
+			limit.DailyTotal = l.DailyTotal
+		}
+		if l.MonthlyTotal.Valid {
+			limit.MonthlyTotal = l.MonthlyTotal
+		}
+		if l.HourlyCount.Valid {
+			limit.HourlyCount = l.HourlyCount
+		}
+	}
+	return
+}
+
+// transferUsage is the outgoing money in each limit window
+type transferUsage struct {
+	DailyTotal    int64
+	MonthlyTotal  int64
+	HourlyCount   int64
+	HourlyFirstAt time.Time
+}
+
+type transferWindows struct {
+	now        time.Time
+	dayStart   time.Time
+	monthStart time.Time
+	hourStart  time.Time
+}
+
+// Daily and monthly limits are calendar windows in UTC, the hourly count is a rolling window
+func newTransferWindows(now time.Time) transferWindows {
+	now = now.UTC()
+	return transferWindows{
+		now:        now,
+		dayStart:   time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		monthStart: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+		hourStart:  now.Add(-time.Hour),
+	}
+}
+
+// transferInitiator is the user recorded as making a transfer from the account: initiatedBy,
+// or the owner of the account when it is empty. The transfers of the bank's own accounts have none.
+func transferInitiator(fromAccount Account, initiatedBy string) sql.NullString {
+	if fromAccount.Owner == FeeIncomeOwner || fromAccount.Owner == InterestExpenseOwner {
+		return sql.NullString{}
+	}
+	if initiatedBy == "" {
+		initiatedBy = fromAccount.Owner
+	}
+	return sql.NullString{String: initiatedBy, Valid: true}
+}
+
+// checkTransferLimits makes sure paying amounts from the account stays within the account velocity limits
+// and the user velocity limits of initiatedBy, the user making the payment, who can be a member of a joint account.
+// It locks the owner and the initiator first, in username order, so concurrent transfers are checked one after another.
+// It must be called before any account is locked, to keep the users then accounts lock order.
+func checkTransferLimits(ctx context.Context, queries *Queries, fromAccount Account, initiatedBy string, amounts []int64) error {
+	// system accounts aren't limited
+	if fromAccount.Owner == FeeIncomeOwner || fromAccount.Owner == InterestExpenseOwner {
+		return nil
+	}
+	usernames := []string{fromAccount.Owner, initiatedBy}
+	sort.Strings(usernames)
+	for i, username := range usernames {
+		if i > 0 && username == usernames[i-1] {
+			continue
+		}
+		if _, err := queries.GetUserForUpdate(ctx, username); err != nil {
+			return err
+		}
+	}
+
+	windows := newTransferWindows(time.Now())
+	for _, scope := range []string{TransferLimitAccount, TransferLimitUser} {
+		// the overrides of an account limit belong to the owner of the account, those of a user limit to the initiator
+		username := fromAccount.Owner
+		if scope == TransferLimitUser {
+			username = initiatedBy
+		}
+		limits, err := queries.ListTransferLimits(ctx, ListTransferLimitsParams{
+			Scope:    scope,
+			Currency: fromAccount.Currency,
+			Username: sql.NullString{String: username, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		if len(limits) == 0 {
+			continue
+		}
+
+		var usage transferUsage
+		if scope == TransferLimitAccount {
+			row, err := queries.GetAccountTransferUsage(ctx, GetAccountTransferUsageParams{
+				DayStart:   windows.dayStart,
+				MonthStart: windows.monthStart,
+				HourStart:  windows.hourStart,
+				Now:        windows.now,
+				AccountID:  fromAccount.ID,
+				FeeOwner:   FeeIncomeOwner,
+			})
+			if err != nil {
+				return err
+			}
+			usage = transferUsage(row)
+		} else {
+			row, err := queries.GetUserTransferUsage(ctx, GetUserTransferUsageParams{
+				DayStart:    windows.dayStart,
+				MonthStart:  windows.monthStart,
+				HourStart:   windows.hourStart,
+				Now:         windows.now,
+				InitiatedBy: sql.NullString{String: initiatedBy, Valid: true},
+				Currency:    fromAccount.Currency,
+				FeeOwner:    FeeIncomeOwner,
+			})
+			if err != nil {
+				return err
+			}
+			usage = transferUsage(row)
+		}
+
+		if err = mergeTransferLimits(limits).check(scope, windows, usage, amounts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (limit effectiveTransferLimit) check(scope string, windows transferWindows, usage transferUsage, amounts []int64) error {
+	var total int64
+	for _, amount := range amounts {
+		if limit.MaxSingle.Valid && amount > limit.MaxSingle.Int64 {
+			return &TransferLimitError{
+				Scope:     scope,
+				Limit:     LimitMaxSingle,
+				Max:       limit.MaxSingle.Int64,
+				Requested: amount,
+			}
+		}
+		total += amount
+	}
+
+	if limit.DailyTotal.Valid && usage.DailyTotal+total > limit.DailyTotal.Int64 {
+		resetsAt := windows.dayStart.AddDate(0, 0, 1)
+		return &TransferLimitError{
+			Scope:     scope,
+			Limit:     LimitDailyTotal,
+			Max:       limit.DailyTotal.Int64,
+			Used:      usage.DailyTotal,
+			Requested: total,
+			ResetsAt:  &resetsAt,
+		}
+	}
+
+	if limit.MonthlyTotal.Valid && usage.MonthlyTotal+total > limit.MonthlyTotal.Int64 {
+		resetsAt := windows.monthStart.AddDate(0, 1, 0)
+		return &TransferLimitError{
+			Scope:     scope,
+			Limit:     LimitMonthlyTotal,
+			Max:       limit.MonthlyTotal.Int64,
+			Used:      usage.MonthlyTotal,
+			Requested: total,
+			ResetsAt:  &resetsAt,
+		}
+	}
+
+	count := int64(len(amounts))
+	if limit.HourlyCount.Valid && usage.HourlyCount+count > int64(limit.HourlyCount.Int32) {
+		// the oldest transfer in the window is the first to drop out of it
+		resetsAt := usage.HourlyFirstAt.Add(time.Hour).UTC()
+		return &TransferLimitError{
+			Scope:     scope,
+			Limit:     LimitHourlyCount,
+			Max:       int64(limit.HourlyCount.Int32),
+			Used:      usage.HourlyCount,
+			Requested: count,
+			ResetsAt:  &resetsAt,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,287 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMergeTransferLimits(t *testing.T) {
+	limit := mergeTransferLimits([]TransferLimit{
+		{
+			MaxSingle:  sql.NullInt64{Int64: 100, Valid: true},
+			DailyTotal: sql.NullInt64{Int64: 500, Valid: true},
+		},
+		{
+			Username:    sql.NullString{String: util.RandomOwner(), Valid: true},
+			MaxSingle:   sql.NullInt64{Int64: 1000, Valid: true},
+			HourlyCount: sql.NullInt32{Int32: 3, Valid: true},
+		},
+	})
+	require.Equal(t, int64(1000), limit.MaxSingle.Int64)
+	require.Equal(t, int64(500), limit.DailyTotal.Int64)
+	require.False(t, limit.MonthlyTotal.Valid)
+	require.Equal(t, int32(3), limit.HourlyCount.Int32)
+}
+
+func TestTransferLimitCheck(t *testing.T) {
+	windows := newTransferWindows(time.Date(2024, time.February, 10, 15, 30, 0, 0, time.UTC))
+	limit := effectiveTransferLimit{
+		MaxSingle:    sql.NullInt64{Int64: 100, Valid: true},
+		DailyTotal:   sql.NullInt64{Int64: 200, Valid: true},
+		MonthlyTotal: sql.NullInt64{Int64: 1000, Valid: true},
+		HourlyCount:  sql.NullInt32{Int32: 2, Valid: true},
+	}
+	firstAt := windows.now.Add(-10 * time.Minute)
+
+	testCases := []struct {
+		name     string
+		usage    transferUsage
+		amounts  []int64
+		limit    string
+		resetsAt time.Time
+	}{
+		{
+			name:    "OK",
+			usage:   transferUsage{DailyTotal: 100, MonthlyTotal: 100, HourlyCount: 1, HourlyFirstAt: firstAt},
+			amounts: []int64{100},
+		},
+		{
+			name:    "MaxSingle",
+			amounts: []int64{50, 101},
+			limit:   LimitMaxSingle,
+		},
+		{
+			name:     "DailyTotal",
+			usage:    transferUsage{DailyTotal: 150, MonthlyTotal: 150},
+			amounts:  []int64{51},
+			limit:    LimitDailyTotal,
+			resetsAt: time.Date(2024, time.February, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "MonthlyTotal",
+			usage:    transferUsage{MonthlyTotal: 950},
+			amounts:  []int64{51},
+			limit:    LimitMonthlyTotal,
+			resetsAt: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "HourlyCount",
+			usage:    transferUsage{HourlyCount: 1, HourlyFirstAt: firstAt},
+			amounts:  []int64{10, 10},
+			limit:    LimitHourlyCount,
+			resetsAt: firstAt.Add(time.Hour),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := limit.check(TransferLimitAccount, windows, tc.usage, tc.amounts)
+			if tc.limit == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrTransferLimitExceeded)
+			limitErr, ok := err.(*TransferLimitError)
+			require.True(t, ok)
+			require.Equal(t, TransferLimitAccount, limitErr.Scope)
+			require.Equal(t, tc.limit, limitErr.Limit)
+			if tc.resetsAt.IsZero() {
+				require.Nil(t, limitErr.ResetsAt)
+			} else {
+				require.Equal(t, tc.resetsAt, *limitErr.ResetsAt)
+			}
+		})
+	}
+}
+
+func TestTransferTxWithLimits(t *testing.T) {
+	store := NewStore(testDB)
+
+	// an override for a user of its own keeps the limits away from other tests
+	user := createRandomUser(t)
+	account1, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  1000,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	_, err = testQueries.CreateTransferLimit(context.Background(), CreateTransferLimitParams{
+		Scope:       TransferLimitAccount,
+		Currency:    util.USD,
+		Username:    sql.NullString{String: user.Username, Valid: true},
+		MaxSingle:   sql.NullInt64{Int64: 100, Valid: true},
+		DailyTotal:  sql.NullInt64{Int64: 150, Valid: true},
+		HourlyCount: sql.NullInt32{Int32: 3, Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        101,
+	})
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        60,
+	})
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitDailyTotal, limitErr.Limit)
+	require.Equal(t, int64(100), limitErr.Used)
+	require.NotNil(t, limitErr.ResetsAt)
+
+	// the legs of a multi transfer each count towards the hourly count
+	_, err = store.MultiTransferTx(context.Background(), MultiTransferTxParams{
+		FromAccountID: account1.ID,
+		Legs: []TransferLeg{
+			{ToAccountID: account2.ID, Amount: 10},
+			{ToAccountID: account2.ID, Amount: 10},
+			{ToAccountID: account2.ID, Amount: 10},
+		},
+	})
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitHourlyCount, limitErr.Limit)
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(900), account1.Balance)
+}
+
+func TestTransferTxUserLimitsOfJointMember(t *testing.T) {
+	store := NewStore(testDB)
+
+	owner := createRandomUser(t)
+	member := createRandomUser(t)
+	account1, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    owner.Username,
+		Balance:  1000,
+		Currency: util.USD,
+		Joint:    true,
+	})
+	require.NoError(t, err)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	_, err = testQueries.CreateAccountMember(context.Background(), CreateAccountMemberParams{
+		AccountID: account1.ID,
+		Username:  member.Username,
+		Role:      AccountRoleCanTransfer,
+		InvitedBy: owner.Username,
+	})
+	require.NoError(t, err)
+
+	for _, username := range []string{owner.Username, member.Username} {
+		_, err = testQueries.CreateTransferLimit(context.Background(), CreateTransferLimitParams{
+			Scope:      TransferLimitUser,
+			Currency:   util.USD,
+			Username:   sql.NullString{String: username, Valid: true},
+			DailyTotal: sql.NullInt64{Int64: 100, Valid: true},
+		})
+		require.NoError(t, err)
+	}
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        80,
+		InitiatedBy:   member.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sql.NullString{String: member.Username, Valid: true}, result.Transfer.InitiatedBy)
+
+	// the member's transfers count against the member's limits, not the owner's
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+		InitiatedBy:   member.Username,
+	})
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, TransferLimitUser, limitErr.Scope)
+	require.Equal(t, int64(80), limitErr.Used)
+
+	result, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sql.NullString{String: owner.Username, Valid: true}, result.Transfer.InitiatedBy)
+}
+
+func TestHoldWithLimits(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	account1, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  1000,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	_, err = testQueries.CreateTransferLimit(context.Background(), CreateTransferLimitParams{
+		Scope:      TransferLimitAccount,
+		Currency:   util.USD,
+		Username:   sql.NullString{String: user.Username, Valid: true},
+		MaxSingle:  sql.NullInt64{Int64: 100, Valid: true},
+		DailyTotal: sql.NullInt64{Int64: 150, Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = store.AuthorizeHoldTx(context.Background(), AuthorizeHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      101,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitMaxSingle, limitErr.Limit)
+
+	hold, err := store.AuthorizeHoldTx(context.Background(), AuthorizeHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      100,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, sql.NullString{String: user.Username, Valid: true}, hold.Hold.InitiatedBy)
+
+	capture, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: hold.Hold.ID,
+		Amount: 80,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sql.NullString{String: user.Username, Valid: true}, capture.Transfer.InitiatedBy)
+
+	// the captured amount counts towards the daily usage
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        71,
+	})
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitDailyTotal, limitErr.Limit)
+	require.Equal(t, int64(80), limitErr.Used)
+}
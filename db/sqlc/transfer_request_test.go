@@ -0,0 +1,254 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createRandomTransferRequest(t *testing.T, fromAccount, toAccount Account, amount int64, expiresAt time.Time) TransferRequest {
+	store := NewStore(testDB)
+
+	result, err := store.CreateTransferRequestTx(context.Background(), CreateTransferRequestTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount,
+		RequestedBy:   fromAccount.Owner,
+		ExpiresAt:     expiresAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferRequestPending, result.TransferRequest.Status)
+	require.False(t, result.TransferRequest.DecidedBy.Valid)
+
+	return result.TransferRequest
+}
+
+func createRandomOfficer(t *testing.T) User {
+	officer, err := testQueries.UpdateUserRole(context.Background(), UpdateUserRoleParams{
+		Username: createRandomUser(t).Username,
+		Role:     RoleOfficer,
+	})
+	require.NoError(t, err)
+	return officer
+}
+
+func TestApproveTransferRequestTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createAccountInCurrency(t, 0, account1.Currency)
+	officer := createRandomOfficer(t)
+	request := createRandomTransferRequest(t, account1, account2, 600, time.Now().Add(time.Hour))
+
+	// the requester can't approve their own transfer
+	_, err := store.ApproveTransferRequestTx(context.Background(), ApproveTransferRequestTxParams{
+		RequestID:  request.ID,
+		ApprovedBy: account1.Owner,
+	})
+	require.ErrorIs(t, err, ErrTransferRequestSelfDecision)
+
+	result, err := store.ApproveTransferRequestTx(context.Background(), ApproveTransferRequestTxParams{
+		RequestID:  request.ID,
+		ApprovedBy: officer.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferRequestApproved, result.TransferRequest.Status)
+	require.Equal(t, officer.Username, result.TransferRequest.DecidedBy.String)
+	require.True(t, result.TransferRequest.DecidedAt.Valid)
+	require.Equal(t, result.Transfer.Transfer.ID, result.TransferRequest.TransferID.Int64)
+	require.Equal(t, int64(400), result.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(600), result.Transfer.ToAccount.Balance)
+
+	// a request is decided once
+	_, err = store.ApproveTransferRequestTx(context.Background(), ApproveTransferRequestTxParams{
+		RequestID:  request.ID,
+		ApprovedBy: officer.Username,
+	})
+	require.ErrorIs(t, err, ErrTransferRequestNotPending)
+
+	// a failed transfer leaves the request pending
+	request = createRandomTransferRequest(t, account1, account2, 600, time.Now().Add(time.Hour))
+	_, err = store.ApproveTransferRequestTx(context.Background(), ApproveTransferRequestTxParams{
+		RequestID:  request.ID,
+		ApprovedBy: officer.Username,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	request, err = testQueries.GetTransferRequest(context.Background(), request.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferRequestPending, request.Status)
+}
+
+func TestApproveMultiTransferRequestTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createAccountInCurrency(t, 0, account1.Currency)
+	account3 := createAccountInCurrency(t, 0, account1.Currency)
+	officer := createRandomOfficer(t)
+
+	created, err := store.CreateTransferRequestTx(context.Background(), CreateTransferRequestTxParams{
+		FromAccountID: account1.ID,
+		RequestedBy:   account1.Owner,
+		Legs: []TransferLeg{
+			{ToAccountID: account2.ID, Amount: 300},
+			{ToAccountID: account3.ID, Amount: 200},
+		},
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.False(t, created.TransferRequest.ToAccountID.Valid)
+	require.Equal(t, int64(500), created.TransferRequest.Amount)
+	require.Len(t, created.Legs, 2)
+
+	result, err := store.ApproveTransferRequestTx(context.Background(), ApproveTransferRequestTxParams{
+		RequestID:  created.TransferRequest.ID,
+		ApprovedBy: officer.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferRequestApproved, result.TransferRequest.Status)
+	require.Nil(t, result.Transfer)
+	require.NotNil(t, result.MultiTransfer)
+	require.Equal(t, int64(500), result.MultiTransfer.FromAccount.Balance)
+	require.Len(t, result.Legs, 2)
+	for i, leg := range result.Legs {
+		require.Equal(t, result.MultiTransfer.Legs[i].Transfer.ID, leg.TransferID.Int64)
+	}
+}
+
+func TestRejectTransferRequestTxCoOwner(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomJointAccount(t)
+	account2 := createAccountInCurrency(t, 0, account1.Currency)
+	addMember := func(role string) User {
+		user := createRandomUser(t)
+		_, err := testQueries.CreateAccountMember(context.Background(), CreateAccountMemberParams{
+			AccountID: account1.ID,
+			Username:  user.Username,
+			Role:      role,
+			InvitedBy: account1.Owner,
+		})
+		require.NoError(t, err)
+		_, err = testQueries.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+			AccountID: account1.ID,
+			Username:  user.Username,
+		})
+		require.NoError(t, err)
+		return user
+	}
+	coOwner := addMember(AccountRoleOwner)
+	spender := addMember(AccountRoleCanTransfer)
+	request := createRandomTransferRequest(t, account1, account2, 1, time.Now().Add(time.Hour))
+
+	// a member who can only move money is not enough, nor is a stranger
+	for _, username := range []string{spender.Username, createRandomUser(t).Username} {
+		_, err := store.RejectTransferRequestTx(context.Background(), RejectTransferRequestTxParams{
+			RequestID:  request.ID,
+			RejectedBy: username,
+		})
+		require.ErrorIs(t, err, ErrTransferRequestDecider)
+	}
+
+	rejected, err := store.RejectTransferRequestTx(context.Background(), RejectTransferRequestTxParams{
+		RequestID:  request.ID,
+		RejectedBy: coOwner.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferRequestRejected, rejected.Status)
+	require.Equal(t, coOwner.Username, rejected.DecidedBy.String)
+}
+
+func TestRejectTransferRequestTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createAccountInCurrency(t, 0, account1.Currency)
+	officer := createRandomOfficer(t)
+	request := createRandomTransferRequest(t, account1, account2, 600, time.Now().Add(time.Hour))
+
+	rejected, err := store.RejectTransferRequestTx(context.Background(), RejectTransferRequestTxParams{
+		RequestID:  request.ID,
+		RejectedBy: officer.Username,
+		Reason:     util.RandomString(20),
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferRequestRejected, rejected.Status)
+	require.Equal(t, officer.Username, rejected.DecidedBy.String)
+	require.NotEmpty(t, rejected.Reason)
+	require.False(t, rejected.TransferID.Valid)
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), account1.Balance)
+}
+
+func TestExpireTransferRequests(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createAccountInCurrency(t, 0, account1.Currency)
+	officer := createRandomOfficer(t)
+	request := createRandomTransferRequest(t, account1, account2, 100, time.Now().Add(-time.Minute))
+
+	// an expired request can't be approved even before the expiry task runs
+	_, err := store.ApproveTransferRequestTx(context.Background(), ApproveTransferRequestTxParams{
+		RequestID:  request.ID,
+		ApprovedBy: officer.Username,
+	})
+	require.ErrorIs(t, err, ErrTransferRequestNotPending)
+
+	expired, err := testQueries.ExpireTransferRequests(context.Background(), time.Now())
+	require.NoError(t, err)
+
+	var found bool
+	for _, r := range expired {
+		if r.ID == request.ID {
+			found = true
+			require.Equal(t, TransferRequestExpired, r.Status)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestListAccountPendingTransferRequestsAfter(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createAccountInCurrency(t, 0, account1.Currency)
+	officer := createRandomOfficer(t)
+
+	pending := createRandomTransferRequest(t, account1, account2, 100, time.Now().Add(time.Hour))
+	createRandomTransferRequest(t, account1, account2, 100, time.Now().Add(-time.Minute))
+	rejected := createRandomTransferRequest(t, account1, account2, 100, time.Now().Add(time.Hour))
+	_, err := store.RejectTransferRequestTx(context.Background(), RejectTransferRequestTxParams{
+		RequestID:  rejected.ID,
+		RejectedBy: officer.Username,
+	})
+	require.NoError(t, err)
+	last := createRandomTransferRequest(t, account1, account2, 100, time.Now().Add(time.Hour))
+	// a request from another account isn't listed
+	createRandomTransferRequest(t, account2, account1, 100, time.Now().Add(time.Hour))
+
+	requests, err := testQueries.ListAccountPendingTransferRequestsAfter(context.Background(), ListAccountPendingTransferRequestsAfterParams{
+		FromAccountID: account1.ID,
+		Now:           time.Now(),
+		LimitCount:    5,
+	})
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	require.Equal(t, pending.ID, requests[0].ID)
+	require.Equal(t, last.ID, requests[1].ID)
+
+	requests, err = testQueries.ListAccountPendingTransferRequestsAfter(context.Background(), ListAccountPendingTransferRequestsAfterParams{
+		FromAccountID: account1.ID,
+		Now:           time.Now(),
+		AfterID:       pending.ID,
+		LimitCount:    5,
+	})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	require.Equal(t, last.ID, requests[0].ID)
+}
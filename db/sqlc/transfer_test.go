@@ -0,0 +1,207 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestQueries_ListTransfersAndEntriesAfter(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithBalance(t, 1000)
+
+	// one transfer out of account1 and one into it
+	_, err := store.TransferTx(context.Background(), TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 10})
+	require.NoError(t, err)
+	_, err = store.TransferTx(context.Background(), TransferTxParams{FromAccountID: account2.ID, ToAccountID: account1.ID, Amount: 20})
+	require.NoError(t, err)
+
+	transfers, err := testQueries.ListTransfersAfter(context.Background(), ListTransfersAfterParams{
+		AccountID:  account1.ID,
+		AfterID:    0,
+		LimitCount: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, account1.ID, transfers[0].FromAccountID)
+
+	transfers, err = testQueries.ListTransfersAfter(context.Background(), ListTransfersAfterParams{
+		AccountID:  account1.ID,
+		AfterID:    transfers[0].ID,
+		LimitCount: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, account1.ID, transfers[0].ToAccountID)
+
+	entries, err := testQueries.ListEntriesAfter(context.Background(), ListEntriesAfterParams{
+		AccountID:  account1.ID,
+		AfterID:    0,
+		LimitCount: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, int64(-10), entries[0].Amount)
+	require.Equal(t, int64(20), entries[1].Amount)
+}
+
+func TestQueries_ListAccountHistory(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithBalance(t, 1000)
+	account3 := createRandomAccountWithBalance(t, 1000)
+
+	for _, arg := range []TransferTxParams{
+		{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 10},
+		{FromAccountID: account3.ID, ToAccountID: account1.ID, Amount: 20},
+		{FromAccountID: account1.ID, ToAccountID: account3.ID, Amount: 30},
+	} {
+		_, err := store.TransferTx(context.Background(), arg)
+		require.NoError(t, err)
+	}
+
+	// newest first
+	rows, err := testQueries.ListAccountHistory(context.Background(), ListAccountHistoryParams{
+		AccountID:  account1.ID,
+		LimitCount: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, int64(-30), rows[0].Amount)
+	require.Equal(t, account3.ID, rows[0].CounterpartyAccountID.Int64)
+	require.Equal(t, account3.Owner, rows[0].CounterpartyOwner.String)
+	require.Equal(t, HistoryIn, rows[1].Direction())
+	require.Equal(t, int64(-10), rows[2].Amount)
+
+	rows, err = testQueries.ListAccountHistory(context.Background(), ListAccountHistoryParams{
+		AccountID:             account1.ID,
+		Direction:             sql.NullString{String: HistoryOut, Valid: true},
+		CounterpartyAccountID: sql.NullInt64{Int64: account3.ID, Valid: true},
+		LimitCount:            10,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, int64(-30), rows[0].Amount)
+
+	rows, err = testQueries.ListAccountHistory(context.Background(), ListAccountHistoryParams{
+		AccountID:  account1.ID,
+		MinAmount:  sql.NullInt64{Int64: 15, Valid: true},
+		MaxAmount:  sql.NullInt64{Int64: 25, Valid: true},
+		LimitCount: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, int64(20), rows[0].Amount)
+
+	rows, err = testQueries.ListAccountHistory(context.Background(), ListAccountHistoryParams{
+		AccountID:  account1.ID,
+		BeforeID:   sql.NullInt64{Int64: rows[0].ID, Valid: true},
+		FromTime:   sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+		ToTime:     sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true},
+		LimitCount: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, int64(-10), rows[0].Amount)
+}
+
+func TestEntryBalanceAfterAndBalanceAsOf(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithBalance(t, 1000)
+
+	before := time.Now().Add(-time.Second)
+	result1, err := store.TransferTx(context.Background(), TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 100})
+	require.NoError(t, err)
+	require.Equal(t, int64(900), result1.FromEntry.BalanceAfter)
+	require.Equal(t, int64(1100), result1.ToEntry.BalanceAfter)
+
+	result2, err := store.TransferTx(context.Background(), TransferTxParams{FromAccountID: account2.ID, ToAccountID: account1.ID, Amount: 40})
+	require.NoError(t, err)
+	require.Equal(t, int64(940), result2.ToEntry.BalanceAfter)
+
+	// before the first entry the balance is the one the account was opened with
+	balance, err := testQueries.GetBalanceAsOf(context.Background(), GetBalanceAsOfParams{AccountID: account1.ID, AsOf: before})
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), balance)
+
+	balance, err = testQueries.GetBalanceAsOf(context.Background(), GetBalanceAsOfParams{AccountID: account1.ID, AsOf: time.Now()})
+	require.NoError(t, err)
+	require.Equal(t, int64(940), balance)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	days, err := testQueries.ListDailyBalances(context.Background(), ListDailyBalancesParams{
+		AccountID: account1.ID,
+		FromDay:   today.AddDate(0, 0, -1),
+		ToDay:     today,
+	})
+	require.NoError(t, err)
+	require.Len(t, days, 2)
+	require.Equal(t, int64(1000), days[0].Balance)
+	require.Equal(t, int64(940), days[1].Balance)
+}
+
+func TestTransferTxDetails(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithBalance(t, 1000)
+
+	details := TransferDetails{
+		Memo:            "rent for March",
+		ClientReference: util.RandomString(12),
+		Metadata:        json.RawMessage(`{"order_id": "A1"}`),
+	}
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account2.ID,
+		Amount:          10,
+		TransferDetails: details,
+	})
+	require.NoError(t, err)
+	require.Equal(t, details.Memo, result.Transfer.Memo)
+	require.Equal(t, details.ClientReference, result.Transfer.ClientReference.String)
+	require.JSONEq(t, string(details.Metadata), string(result.Transfer.Metadata))
+
+	// the reference is unique per from account only
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account2.ID,
+		Amount:          10,
+		TransferDetails: TransferDetails{ClientReference: details.ClientReference},
+	})
+	require.ErrorIs(t, err, ErrDuplicateClientReference)
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account2.ID,
+		ToAccountID:     account1.ID,
+		Amount:          10,
+		TransferDetails: TransferDetails{ClientReference: details.ClientReference},
+	})
+	require.NoError(t, err)
+
+	// a transfer without details still gets an empty object
+	plain, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.False(t, plain.Transfer.ClientReference.Valid)
+	require.JSONEq(t, `{}`, string(plain.Transfer.Metadata))
+
+	rows, err := testQueries.ListAccountHistory(context.Background(), ListAccountHistoryParams{
+		AccountID:       account1.ID,
+		Direction:       sql.NullString{String: HistoryOut, Valid: true},
+		ClientReference: sql.NullString{String: details.ClientReference, Valid: true},
+		LimitCount:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, result.Transfer.ID, rows[0].TransferID.Int64)
+	require.Equal(t, details.Memo, rows[0].Memo)
+	require.JSONEq(t, string(details.Metadata), string(rows[0].Metadata))
+}
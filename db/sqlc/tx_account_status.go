@@ -0,0 +1,193 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type UpdateAccountStatusTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Status    string `json:"status"`
+	// Username is the user changing the status, recorded as the one who froze the account
+	Username string `json:"username"`
+}
+
+// UpdateAccountStatusTx moves an account to a new status if the transition is allowed.
+// A frozen account can only be unfrozen by the user who froze it or an officer.
+// Accounts are closed with CloseAccountTx, which deals with the remaining balance.
+func (store *SQLStore) UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (Account, error) {
+	var result Account
+
+	err := store.execTXWithOptions(ctx, recordTxOptions, func(queries *Queries) error {
+		account, err := queries.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if arg.Status == AccountClosed || !CanTransitionAccountStatus(account.Status, arg.Status) {
+			return fmt.Errorf("%w: account [%d] from %s to %s", ErrAccountStatusTransition, account.ID, account.Status, arg.Status)
+		}
+		if account.Status == AccountFrozen {
+			if err = checkCanUnfreeze(ctx, queries, account, arg.Username); err != nil {
+				return err
+			}
+		}
+
+		result, err = queries.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:       account.ID,
+			Status:   arg.Status,
+			FrozenBy: sql.NullString{String: arg.Username, Valid: arg.Status == AccountFrozen && arg.Username != ""},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// checkCanUnfreeze makes sure username can lift the freeze of an account, so an owner can't undo a freeze the bank put on.
+// Accounts frozen before the freezer was recorded can be unfrozen by anyone allowed to change their status.
+func checkCanUnfreeze(ctx context.Context, queries *Queries, account Account, username string) error {
+	if !account.FrozenBy.Valid || account.FrozenBy.String == username {
+		return nil
+	}
+	user, err := queries.GetUser(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user.Role == RoleOfficer {
+		return nil
+	}
+	return fmt.Errorf("%w: account [%d] was frozen by %s", ErrAccountUnfreeze, account.ID, account.FrozenBy.String)
+}
+
+type CloseAccountTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// SweepAccountID receives the remaining balance, the user closing the account must be an owner of it.
+	// It can be empty when the balance is zero.
+	SweepAccountID int64 `json:"sweep_account_id"`
+	// Username is the user closing the account, it defaults to the owner of the account
+	Username string `json:"username"`
+}
+
+type CloseAccountTxResult struct {
+	Account Account `json:"account"`
+	// Sweep is nil when there was no balance left to move
+	Sweep                   *TransferTxResult `json:"sweep,omitempty"`
+	CancelledStandingOrders []StandingOrder   `json:"cancelled_standing_orders"`
+}
+
+// CloseAccountTx sweeps the balance of an account to another account the closing user owns, converting it when the
+// currencies differ, cancels the standing orders from and to the account, then closes it.
+// Accounts with active holds, a negative balance or open pots can't be closed.
+func (store *SQLStore) CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error) {
+	var result CloseAccountTxResult
+
+	err := store.execTXWithOptions(ctx, moneyTxOptions, func(queries *Queries) error {
+		var account, sweep Account
+		var err error
+		if arg.SweepAccountID != 0 && arg.SweepAccountID != arg.AccountID {
+			account, sweep, err = lockAccounts(ctx, queries, arg.AccountID, arg.SweepAccountID)
+		} else {
+			account, err = queries.GetAccountForUpdate(ctx, arg.AccountID)
+		}
+		if err != nil {
+			return err
+		}
+		if !CanTransitionAccountStatus(account.Status, AccountClosed) {
+			return fmt.Errorf("%w: account [%d] from %s to %s", ErrAccountStatusTransition, account.ID, account.Status, AccountClosed)
+		}
+
+		held, err := queries.GetHeldAmount(ctx, GetHeldAmountParams{
+			AccountID: account.ID,
+			Now:       time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		if held > 0 {
+			return fmt.Errorf("%w: account [%d] has %d held by active holds", ErrAccountStatusTransition, account.ID, held)
+		}
+		if account.Balance < 0 {
+			return fmt.Errorf("%w: account [%d] is overdrawn by %d", ErrAccountStatusTransition, account.ID, -account.Balance)
+		}
+		pots, err := queries.CountOpenPots(ctx, sql.NullInt64{Int64: account.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+		if pots > 0 {
+			return fmt.Errorf("%w: account [%d] has %d open pots", ErrAccountStatusTransition, account.ID, pots)
+		}
+
+		if account.Balance > 0 {
+			result.Sweep, err = sweepAccount(ctx, queries, account, sweep, arg.Username)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.CancelledStandingOrders, err = queries.CancelAccountStandingOrders(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = queries.CloseAccount(ctx, account.ID)
+		return err
+	})
+
+	return result, err
+}
+
+// sweepAccount moves the whole balance of a locked account to another locked account username has owner rights on,
+// so a co-owner of a joint account can close it into their own account. sweep is empty when no sweep account was given.
+func sweepAccount(ctx context.Context, queries *Queries, account Account, sweep Account, username string) (*TransferTxResult, error) {
+	if sweep.ID == 0 {
+		return nil, fmt.Errorf("%w: account [%d] has a balance of %d to sweep", ErrInvalidSweepAccount, account.ID, account.Balance)
+	}
+	if username == "" {
+		username = account.Owner
+	}
+	role, err := AccountRole(ctx, queries, sweep, username)
+	if err != nil {
+		return nil, err
+	}
+	if !AccountRoleGrants(role, AccountRoleOwner) {
+		return nil, fmt.Errorf("%w: %s isn't an owner of account [%d]", ErrInvalidSweepAccount, username, sweep.ID)
+	}
+	if err := checkCanCredit(sweep); err != nil {
+		return nil, err
+	}
+
+	arg := CreateTransferParams{
+		FromAccountID: account.ID,
+		ToAccountID:   sweep.ID,
+		Amount:        account.Balance,
+		ToAmount:      account.Balance,
+	}
+	if sweep.Currency != account.Currency {
+		fxRate, err := queries.GetLatestFxRate(ctx, GetLatestFxRateParams{
+			FromCurrency: account.Currency,
+			ToCurrency:   sweep.Currency,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("%w: %s to %s", ErrFxRateNotFound, account.Currency, sweep.Currency)
+			}
+			return nil, err
+		}
+
+		arg.ToAmount, err = convertAmount(account.Balance, account.Currency, sweep.Currency, fxRate.Rate, fxRate.SpreadBps)
+		if err != nil {
+			return nil, err
+		}
+		arg.FxRate = sql.NullString{String: fxRate.Rate, Valid: true}
+		arg.FxSpreadBps = sql.NullInt32{Int32: fxRate.SpreadBps, Valid: true}
+	}
+
+	result, err := transfer(ctx, queries, arg)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
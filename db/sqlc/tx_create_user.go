@@ -4,6 +4,7 @@ import "context"
 
 type CreateUserTxParams struct {
 	CreateUserParams
+	// AfterCreate runs inside the transaction, it runs again if the transaction is retried
 	AfterCreate func(user User) error
 }
 
@@ -14,7 +15,7 @@ type CreateUserTxResult struct {
 func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error) {
 	var result CreateUserTxResult
 
-	err := store.execTX(ctx, func(queries *Queries) error {
+	err := store.execTXWithOptions(ctx, recordTxOptions, func(queries *Queries) error {
 		var err error
 		result.User, err = queries.CreateUser(ctx, arg.CreateUserParams)
 		if err != nil {
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"math"
+)
+
+type CreateCurrencyTxResult struct {
+	Currency Currency `json:"currency"`
+	// FeeIncomeAccount and InterestExpenseAccount are the bank accounts the new currency needs
+	// before fees can be charged or interest paid in it
+	FeeIncomeAccount       Account `json:"fee_income_account"`
+	InterestExpenseAccount Account `json:"interest_expense_account"`
+}
+
+// CreateCurrencyTx registers a currency together with its fee income and interest expense accounts.
+// Like those of the seeded currencies, the interest expense account has no overdraft limit.
+func (store *SQLStore) CreateCurrencyTx(ctx context.Context, arg CreateCurrencyParams) (CreateCurrencyTxResult, error) {
+	var result CreateCurrencyTxResult
+
+	err := store.execTXWithOptions(ctx, recordTxOptions, func(queries *Queries) error {
+		var err error
+		result.Currency, err = queries.CreateCurrency(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		result.FeeIncomeAccount, err = queries.CreateAccount(ctx, CreateAccountParams{
+			Owner:    FeeIncomeOwner,
+			Currency: arg.Code,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.InterestExpenseAccount, err = queries.CreateAccount(ctx, CreateAccountParams{
+			Owner:    InterestExpenseOwner,
+			Currency: arg.Code,
+		})
+		if err != nil {
+			return err
+		}
+		result.InterestExpenseAccount, err = queries.UpdateAccountOverdraftLimit(ctx, UpdateAccountOverdraftLimitParams{
+			ID:             result.InterestExpenseAccount.ID,
+			OverdraftLimit: math.MaxInt64,
+		})
+		return err
+	})
+
+	return result, err
+}
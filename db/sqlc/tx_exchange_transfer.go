@@ -0,0 +1,187 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+const basisPointsPerUnit = 10000
+
+type ExchangeTransferTxParams struct {
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	// Amount is debited in the currency of the from account
+	Amount int64 `json:"amount"`
+	// TransferType picks the fee schedule, it defaults to TransferTypeTransfer
+	TransferType string `json:"transfer_type"`
+	TransferDetails
+	// InitiatedBy is the user making the transfer, it defaults to the owner of the from account
+	InitiatedBy string `json:"-"`
+	// Idempotency is optional, when set a retried request returns the first result instead of moving money again
+	Idempotency *IdempotencyParams `json:"-"`
+}
+
+type ExchangeTransferTxResult struct {
+	TransferTxResult
+	FromAmount int64  `json:"from_amount"`
+	ToAmount   int64  `json:"to_amount"`
+	Rate       string `json:"rate"`
+	SpreadBps  int32  `json:"spread_bps"`
+}
+
+// ExchangeTransferTx moves money between accounts in different currencies. It debits the amount in the
+// currency of the from account and credits the converted amount, at the latest rate less its spread,
+// in the currency of the to account.
+// The fee of a transfer of the amount is charged in the currency of the from account, on top of the amount.
+func (store *SQLStore) ExchangeTransferTx(ctx context.Context, arg ExchangeTransferTxParams) (ExchangeTransferTxResult, error) {
+	var result ExchangeTransferTxResult
+
+	replayed, err := store.execIdempotentTX(ctx, moneyTxOptions, arg.Idempotency, arg, &result, func(queries *Queries) error {
+		var err error
+		result, err = exchangeTransferTx(ctx, queries, arg)
+		return err
+	})
+	result.Replayed = replayed
+
+	return result, err
+}
+
+// exchangeTransferTx is the body of ExchangeTransferTx, it runs inside the caller's transaction
+func exchangeTransferTx(ctx context.Context, queries *Queries, arg ExchangeTransferTxParams) (result ExchangeTransferTxResult, err error) {
+	account, err := queries.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return
+	}
+	initiatedBy := transferInitiator(account, arg.InitiatedBy)
+	if err = checkTransferLimits(ctx, queries, account, initiatedBy.String, []int64{arg.Amount}); err != nil {
+		return
+	}
+
+	transferType := arg.TransferType
+	if transferType == "" {
+		transferType = TransferTypeTransfer
+	}
+	schedule, feeLeg, charged, err := transferFee(ctx, queries, account, transferType, arg.Amount)
+	if err != nil {
+		return
+	}
+
+	debit := arg.Amount
+	accountIDs := []int64{arg.FromAccountID, arg.ToAccountID}
+	if charged {
+		if feeLeg.Amount > math.MaxInt64-arg.Amount {
+			err = fmt.Errorf("invalid amount %d for account [%d]", arg.Amount, arg.ToAccountID)
+			return
+		}
+		debit += feeLeg.Amount
+		accountIDs = append(accountIDs, feeLeg.ToAccountID)
+	}
+	accounts, err := lockAccountIDs(ctx, queries, accountIDs)
+	if err != nil {
+		return
+	}
+	fromAccount, toAccount := accounts[arg.FromAccountID], accounts[arg.ToAccountID]
+	if fromAccount.Currency == toAccount.Currency {
+		err = fmt.Errorf("accounts [%d] and [%d] are both in %s, no exchange needed",
+			fromAccount.ID, toAccount.ID, fromAccount.Currency)
+		return
+	}
+	if err = checkCanTransfer(fromAccount, toAccount); err != nil {
+		return
+	}
+	if err = checkFunds(ctx, queries, fromAccount, debit); err != nil {
+		return
+	}
+
+	fxRate, err := queries.GetLatestFxRate(ctx, GetLatestFxRateParams{
+		FromCurrency: fromAccount.Currency,
+		ToCurrency:   toAccount.Currency,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %s to %s", ErrFxRateNotFound, fromAccount.Currency, toAccount.Currency)
+		}
+		return
+	}
+
+	toAmount, err := convertAmount(arg.Amount, fromAccount.Currency, toAccount.Currency, fxRate.Rate, fxRate.SpreadBps)
+	if err != nil {
+		return
+	}
+
+	result.TransferTxResult, err = transfer(ctx, queries, CreateTransferParams{
+		FromAccountID:   arg.FromAccountID,
+		ToAccountID:     arg.ToAccountID,
+		Amount:          arg.Amount,
+		ToAmount:        toAmount,
+		FxRate:          sql.NullString{String: fxRate.Rate, Valid: true},
+		FxSpreadBps:     sql.NullInt32{Int32: fxRate.SpreadBps, Valid: true},
+		Memo:            arg.Memo,
+		ClientReference: arg.clientReference(),
+		Metadata:        arg.metadata(),
+		InitiatedBy:     initiatedBy,
+	})
+	if err != nil {
+		return
+	}
+
+	if charged {
+		var fee TransferTxResult
+		fee, err = transfer(ctx, queries, CreateTransferParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   feeLeg.ToAccountID,
+			Amount:        feeLeg.Amount,
+			ToAmount:      feeLeg.Amount,
+			InitiatedBy:   initiatedBy,
+		})
+		if err != nil {
+			return
+		}
+		result.FromAccount = fee.FromAccount
+		result.Fee = &TransferFee{
+			ScheduleID: schedule.ID,
+			Amount:     feeLeg.Amount,
+			Transfer:   fee.Transfer,
+			Entry:      fee.FromEntry,
+		}
+	}
+
+	result.FromAmount = arg.Amount
+	result.ToAmount = toAmount
+	result.Rate = fxRate.Rate
+	result.SpreadBps = fxRate.SpreadBps
+	return
+}
+
+// convertAmount converts amount of minor units of fromCurrency to minor units of toCurrency at rate,
+// takes the spread off the result, and rounds down so the bank never credits more than it received.
+// The rate is per major unit, so the result is scaled by the difference of the currency exponents.
+func convertAmount(amount int64, fromCurrency, toCurrency string, rate string, spreadBps int32) (int64, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid exchange rate %q", rate)
+	}
+
+	converted := new(big.Rat).Mul(r, new(big.Rat).SetInt64(amount))
+	converted.Mul(converted, big.NewRat(basisPointsPerUnit-int64(spreadBps), basisPointsPerUnit))
+	converted.Mul(converted, exponentScale(util.CurrencyExponent(toCurrency)-util.CurrencyExponent(fromCurrency)))
+
+	toAmount := new(big.Int).Quo(converted.Num(), converted.Denom())
+	if !toAmount.IsInt64() || toAmount.Sign() <= 0 {
+		return 0, fmt.Errorf("amount %d converts to %s, which cannot be credited", amount, toAmount.String())
+	}
+	return toAmount.Int64(), nil
+}
+
+// exponentScale is 10^exponent, a negative exponent gives a fraction
+func exponentScale(exponent int) *big.Rat {
+	if exponent < 0 {
+		return new(big.Rat).Inv(exponentScale(-exponent))
+	}
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil))
+}
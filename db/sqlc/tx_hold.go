@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Hold statuses
+const (
+	HoldActive   = "active"
+	HoldCaptured = "captured"
+	HoldVoided   = "voided"
+	HoldExpired  = "expired"
+)
+
+type AuthorizeHoldTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// Idempotency is optional, when set a retried request returns the first result instead of holding funds again
+	Idempotency *IdempotencyParams `json:"-"`
+	// InitiatedBy is the user authorizing the hold, it defaults to the owner of the account
+	InitiatedBy string `json:"-"`
+}
+
+type AuthorizeHoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+	// Replayed is true when the result was saved by an earlier request with the same idempotency key
+	Replayed bool `json:"-"`
+}
+
+// AuthorizeHoldTx reserves funds on an account without moving them.
+// The reserved amount can't be spent until the hold is captured, voided or expires.
+// The hold is checked against the transfer limits as a transfer of its amount, its capture counts towards the usage.
+func (store *SQLStore) AuthorizeHoldTx(ctx context.Context, arg AuthorizeHoldTxParams) (AuthorizeHoldTxResult, error) {
+	var result AuthorizeHoldTxResult
+
+	replayed, err := store.execIdempotentTX(ctx, moneyTxOptions, arg.Idempotency, arg, &result, func(queries *Queries) error {
+		account, err := queries.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		initiatedBy := transferInitiator(account, arg.InitiatedBy)
+		if err = checkTransferLimits(ctx, queries, account, initiatedBy.String, []int64{arg.Amount}); err != nil {
+			return err
+		}
+
+		account, err = queries.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if err = checkCanDebit(account); err != nil {
+			return err
+		}
+		if err = checkFunds(ctx, queries, account, arg.Amount); err != nil {
+			return err
+		}
+
+		result.Hold, err = queries.CreateHold(ctx, CreateHoldParams{
+			AccountID:   arg.AccountID,
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			ExpiresAt:   arg.ExpiresAt,
+			InitiatedBy: initiatedBy,
+		})
+		result.Account = account
+		return err
+	})
+	result.Replayed = replayed
+
+	return result, err
+}
+
+type CaptureHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+	// Amount may be less than the held amount, the rest is released
+	Amount int64 `json:"amount"`
+	// Idempotency is optional, when set a retried request returns the first result instead of moving money again
+	Idempotency *IdempotencyParams `json:"-"`
+}
+
+type CaptureHoldTxResult struct {
+	TransferTxResult
+	Hold Hold `json:"hold"`
+}
+
+// CaptureHoldTx moves all or part of the held funds to the to account of the hold and closes it
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	replayed, err := store.execIdempotentTX(ctx, moneyTxOptions, arg.Idempotency, arg, &result, func(queries *Queries) error {
+		now := time.Now()
+		hold, err := lockActiveHold(ctx, queries, arg.HoldID, now)
+		if err != nil {
+			return err
+		}
+		if arg.Amount <= 0 || arg.Amount > hold.Amount {
+			return fmt.Errorf("%w: capture amount %d is not between 1 and %d", ErrHoldAmountExceeded, arg.Amount, hold.Amount)
+		}
+
+		account, toAccount, err := lockAccounts(ctx, queries, hold.AccountID, hold.ToAccountID)
+		if err != nil {
+			return err
+		}
+		if err = checkCanTransfer(account, toAccount); err != nil {
+			return err
+		}
+		held, err := queries.GetHeldAmount(ctx, GetHeldAmountParams{
+			AccountID: hold.AccountID,
+			Now:       now,
+		})
+		if err != nil {
+			return err
+		}
+		// the funds of this hold are already reserved for the capture
+		if err = checkAvailableBalance(account, held-hold.Amount, arg.Amount); err != nil {
+			return err
+		}
+
+		result.TransferTxResult, err = transfer(ctx, queries, CreateTransferParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   hold.ToAccountID,
+			Amount:        arg.Amount,
+			ToAmount:      arg.Amount,
+			InitiatedBy:   hold.InitiatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = queries.UpdateHold(ctx, UpdateHoldParams{
+			ID:             hold.ID,
+			Status:         HoldCaptured,
+			CapturedAmount: arg.Amount,
+			TransferID:     sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+	result.Replayed = replayed
+
+	return result, err
+}
+
+// VoidHoldTx releases the held funds without moving them
+func (store *SQLStore) VoidHoldTx(ctx context.Context, holdID int64) (Hold, error) {
+	var result Hold
+
+	err := store.execTXWithOptions(ctx, moneyTxOptions, func(queries *Queries) error {
+		hold, err := lockActiveHold(ctx, queries, holdID, time.Now())
+		if err != nil {
+			return err
+		}
+
+		result, err = queries.UpdateHold(ctx, UpdateHoldParams{
+			ID:     hold.ID,
+			Status: HoldVoided,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// lockActiveHold locks a hold and makes sure it can still be captured or voided
+func lockActiveHold(ctx context.Context, queries *Queries, holdID int64, now time.Time) (Hold, error) {
+	hold, err := queries.GetHoldForUpdate(ctx, holdID)
+	if err != nil {
+		return hold, err
+	}
+	if hold.Status != HoldActive {
+		return hold, fmt.Errorf("%w: hold [%d] is %s", ErrHoldNotActive, hold.ID, hold.Status)
+	}
+	if !hold.ExpiresAt.After(now) {
+		return hold, fmt.Errorf("%w: hold [%d] expired at %s", ErrHoldNotActive, hold.ID, hold.ExpiresAt)
+	}
+	return hold, nil
+}
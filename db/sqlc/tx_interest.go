@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// InterestExpenseOwner owns the bank accounts that interest is paid from, one per currency
+const InterestExpenseOwner = "bank_interest_expense"
+
+type PostInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Period is any time in the month being posted, accruals before the end of that month are paid
+	Period time.Time `json:"period"`
+}
+
+type PostInterestTxResult struct {
+	Posting InterestPosting `json:"posting"`
+	// Transfer is empty when the accrued interest was less than one unit
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// PostInterestTx pays the whole units of the interest accrued on an account from the interest expense account.
+// The fraction that is left over is carried into the next posting.
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+
+	period := time.Date(arg.Period.Year(), arg.Period.Month(), 1, 0, 0, 0, 0, time.UTC)
+	before := period.AddDate(0, 1, 0)
+
+	err := store.execTXWithOptions(ctx, moneyTxOptions, func(queries *Queries) error {
+		account, err := queries.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		expense, err := queries.GetAccountByOwner(ctx, GetAccountByOwnerParams{
+			Owner:    InterestExpenseOwner,
+			Currency: account.Currency,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("no interest expense account in %s: %w", account.Currency, err)
+			}
+			return err
+		}
+
+		// postings for the account are serialized by its row lock
+		_, account, err = lockAccounts(ctx, queries, expense.ID, account.ID)
+		if err != nil {
+			return err
+		}
+		// frozen and dormant accounts still earn interest
+		if account.Status == AccountClosed {
+			return fmt.Errorf("%w: account [%d]", ErrAccountClosed, account.ID)
+		}
+
+		carried := new(big.Rat)
+		last, err := queries.GetLastInterestPosting(ctx, account.ID)
+		switch {
+		case err == nil:
+			if !last.Period.Before(period) {
+				return fmt.Errorf("%w: account [%d] period %s", ErrInterestAlreadyPosted, account.ID, period.Format("2006-01"))
+			}
+			if _, ok := carried.SetString(last.Carried); !ok {
+				return fmt.Errorf("invalid carried interest %q", last.Carried)
+			}
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+
+		unposted, err := queries.GetUnpostedInterest(ctx, GetUnpostedInterestParams{
+			AccountID: account.ID,
+			Before:    before,
+		})
+		if err != nil {
+			return err
+		}
+		accrued, ok := new(big.Rat).SetString(unposted)
+		if !ok {
+			return fmt.Errorf("invalid accrued interest %q", unposted)
+		}
+		accrued.Add(accrued, carried)
+
+		// accruals are never negative, so truncating rounds down
+		paid := new(big.Int).Quo(accrued.Num(), accrued.Denom())
+		if !paid.IsInt64() {
+			return fmt.Errorf("accrued interest %s overflows", accrued.FloatString(10))
+		}
+		amount := paid.Int64()
+		carried.Sub(accrued, new(big.Rat).SetInt(paid))
+
+		var transferID sql.NullInt64
+		if amount > 0 {
+			result.Transfer, err = transfer(ctx, queries, CreateTransferParams{
+				FromAccountID: expense.ID,
+				ToAccountID:   account.ID,
+				Amount:        amount,
+				ToAmount:      amount,
+			})
+			if err != nil {
+				return err
+			}
+			transferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+		}
+
+		result.Posting, err = queries.CreateInterestPosting(ctx, CreateInterestPostingParams{
+			AccountID:  account.ID,
+			Period:     period,
+			Accrued:    accrued.FloatString(10),
+			Amount:     amount,
+			Carried:    carried.FloatString(10),
+			TransferID: transferID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return queries.MarkInterestPosted(ctx, MarkInterestPostedParams{
+			PostingID: sql.NullInt64{Int64: result.Posting.ID, Valid: true},
+			AccountID: account.ID,
+			Before:    before,
+		})
+	})
+
+	return result, err
+}
@@ -0,0 +1,199 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+)
+
+type TransferLeg struct {
+	ToAccountID int64 `json:"to_account_id"`
+	Amount      int64 `json:"amount"`
+	TransferDetails
+}
+
+type MultiTransferTxParams struct {
+	FromAccountID int64         `json:"from_account_id"`
+	Legs          []TransferLeg `json:"legs"`
+	// TransferType picks the fee schedule of the legs, it defaults to TransferTypeTransfer
+	TransferType string `json:"transfer_type"`
+	// InitiatedBy is the user making the transfer, it defaults to the owner of the from account
+	InitiatedBy string `json:"-"`
+	// Idempotency is optional, when set a retried request returns the first result instead of moving money again
+	Idempotency *IdempotencyParams `json:"-"`
+}
+
+type MultiTransferTxResult struct {
+	// FromAccount is the from account after every leg was debited
+	FromAccount Account `json:"from_account"`
+	// Legs holds one transfer per leg, in the order of the request, with the fee charged on the leg
+	Legs []TransferTxResult `json:"legs"`
+	// Replayed is true when the result was saved by an earlier request with the same idempotency key
+	Replayed bool `json:"-"`
+}
+
+// MultiTransferTx pays every leg from one account in a single transaction, either all legs are paid or none is.
+// Each leg is charged the fee of a transfer of its amount, paid into the fee income account after the legs.
+func (store *SQLStore) MultiTransferTx(ctx context.Context, arg MultiTransferTxParams) (MultiTransferTxResult, error) {
+	var result MultiTransferTxResult
+
+	replayed, err := store.execIdempotentTX(ctx, moneyTxOptions, arg.Idempotency, arg, &result, func(queries *Queries) error {
+		var err error
+		result, err = multiTransferTx(ctx, queries, arg)
+		return err
+	})
+	result.Replayed = replayed
+
+	return result, err
+}
+
+// multiTransferTx is the body of MultiTransferTx, it runs inside the caller's transaction
+func multiTransferTx(ctx context.Context, queries *Queries, arg MultiTransferTxParams) (result MultiTransferTxResult, err error) {
+	fromAccount, err := queries.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return
+	}
+	amounts := make([]int64, len(arg.Legs))
+	for i, leg := range arg.Legs {
+		amounts[i] = leg.Amount
+	}
+	initiatedBy := transferInitiator(fromAccount, arg.InitiatedBy)
+	if err = checkTransferLimits(ctx, queries, fromAccount, initiatedBy.String, amounts); err != nil {
+		return
+	}
+
+	transferType := arg.TransferType
+	if transferType == "" {
+		transferType = TransferTypeTransfer
+	}
+
+	// the details belong to the payments, not to their fees
+	transferLegs := append([]TransferLeg{}, arg.Legs...)
+	var fees []legFee
+	for i, leg := range arg.Legs {
+		var schedule FeeSchedule
+		var feeLeg TransferLeg
+		var charged bool
+		schedule, feeLeg, charged, err = transferFee(ctx, queries, fromAccount, transferType, leg.Amount)
+		if err != nil {
+			return
+		}
+		if charged {
+			transferLegs = append(transferLegs, feeLeg)
+			fees = append(fees, legFee{leg: i, scheduleID: schedule.ID})
+		}
+	}
+
+	var legs []TransferTxResult
+	result.FromAccount, legs, err = multiTransfer(ctx, queries, arg.FromAccountID, initiatedBy, transferLegs)
+	if err != nil {
+		return
+	}
+
+	result.Legs = legs[:len(arg.Legs)]
+	for i, fee := range fees {
+		feeTransfer := legs[len(arg.Legs)+i]
+		result.Legs[fee.leg].Fee = &TransferFee{
+			ScheduleID: fee.scheduleID,
+			Amount:     feeTransfer.Transfer.Amount,
+			Transfer:   feeTransfer.Transfer,
+			Entry:      feeTransfer.FromEntry,
+		}
+	}
+	return
+}
+
+// legFee ties a fee leg to the leg of a multi transfer it was charged on
+type legFee struct {
+	leg        int
+	scheduleID int64
+}
+
+// multiTransfer locks every account involved, checks every account can move money and the from account
+// can pay the total of the legs,
+// then makes one transfer per leg
+func multiTransfer(
+	ctx context.Context,
+	queries *Queries,
+	fromAccountID int64,
+	initiatedBy sql.NullString,
+	legs []TransferLeg,
+) (fromAccount Account, results []TransferTxResult, err error) {
+	if len(legs) == 0 {
+		err = fmt.Errorf("transfer from account [%d] has no legs", fromAccountID)
+		return
+	}
+
+	var total int64
+	accountIDs := []int64{fromAccountID}
+	for _, leg := range legs {
+		if leg.Amount <= 0 || leg.Amount > math.MaxInt64-total {
+			err = fmt.Errorf("invalid amount %d for account [%d]", leg.Amount, leg.ToAccountID)
+			return
+		}
+		total += leg.Amount
+		accountIDs = append(accountIDs, leg.ToAccountID)
+	}
+
+	accounts, err := lockAccountIDs(ctx, queries, accountIDs)
+	if err != nil {
+		return
+	}
+	fromAccount = accounts[fromAccountID]
+	if err = checkCanDebit(fromAccount); err != nil {
+		return
+	}
+	for _, leg := range legs {
+		if err = checkCanCredit(accounts[leg.ToAccountID]); err != nil {
+			return
+		}
+	}
+	if err = checkFunds(ctx, queries, fromAccount, total); err != nil {
+		return
+	}
+
+	results = make([]TransferTxResult, 0, len(legs))
+	for _, leg := range legs {
+		var result TransferTxResult
+		result, err = transfer(ctx, queries, CreateTransferParams{
+			FromAccountID:   fromAccountID,
+			ToAccountID:     leg.ToAccountID,
+			Amount:          leg.Amount,
+			ToAmount:        leg.Amount,
+			Memo:            leg.Memo,
+			ClientReference: leg.clientReference(),
+			Metadata:        leg.metadata(),
+			InitiatedBy:     initiatedBy,
+		})
+		if err != nil {
+			return
+		}
+		fromAccount = result.FromAccount
+		results = append(results, result)
+	}
+	return
+}
+
+// lockAccountIDs locks every account once, in ascending id order like lockAccounts, to avoid deadlock
+func lockAccountIDs(ctx context.Context, queries *Queries, accountIDs []int64) (map[int64]Account, error) {
+	ids := make([]int64, 0, len(accountIDs))
+	accounts := make(map[int64]Account, len(accountIDs))
+	for _, id := range accountIDs {
+		if _, ok := accounts[id]; !ok {
+			accounts[id] = Account{}
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		account, err := queries.GetAccountForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		accounts[id] = account
+	}
+	return accounts, nil
+}
@@ -0,0 +1,212 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type CreatePotTxParams struct {
+	ParentAccountID int64         `json:"parent_account_id"`
+	Name            string        `json:"name"`
+	GoalAmount      sql.NullInt64 `json:"goal_amount"`
+	TargetDate      sql.NullTime  `json:"target_date"`
+	RoundUpTo       sql.NullInt64 `json:"round_up_to"`
+}
+
+type PotTxResult struct {
+	Pot     Pot     `json:"pot"`
+	Account Account `json:"account"`
+}
+
+// CreatePotTx opens a pot under an active account. The pot is an account of the same owner and currency
+// that only moves money to and from its parent.
+func (store *SQLStore) CreatePotTx(ctx context.Context, arg CreatePotTxParams) (PotTxResult, error) {
+	var result PotTxResult
+
+	err := store.execTXWithOptions(ctx, recordTxOptions, func(queries *Queries) error {
+		// locking the parent serializes the round up checks of its pots
+		parent, err := queries.GetAccountForUpdate(ctx, arg.ParentAccountID)
+		if err != nil {
+			return err
+		}
+		if parent.ParentAccountID.Valid {
+			return fmt.Errorf("%w: account [%d] is a pot", ErrInvalidPotParent, parent.ID)
+		}
+		if parent.Status != AccountActive {
+			return fmt.Errorf("%w: account [%d] is %s", ErrInvalidPotParent, parent.ID, parent.Status)
+		}
+		if arg.RoundUpTo.Valid {
+			if err = checkRoundUpPot(ctx, queries, parent.ID, 0); err != nil {
+				return err
+			}
+		}
+
+		result.Account, err = queries.CreateAccount(ctx, CreateAccountParams{
+			Owner:           parent.Owner,
+			Currency:        parent.Currency,
+			ParentAccountID: sql.NullInt64{Int64: parent.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Pot, err = queries.CreatePot(ctx, CreatePotParams{
+			AccountID:  result.Account.ID,
+			Name:       arg.Name,
+			GoalAmount: arg.GoalAmount,
+			TargetDate: arg.TargetDate,
+			RoundUpTo:  arg.RoundUpTo,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type UpdatePotTxParams struct {
+	AccountID  int64         `json:"account_id"`
+	Name       string        `json:"name"`
+	GoalAmount sql.NullInt64 `json:"goal_amount"`
+	TargetDate sql.NullTime  `json:"target_date"`
+	RoundUpTo  sql.NullInt64 `json:"round_up_to"`
+}
+
+// UpdatePotTx replaces the name, goal and round up rule of a pot
+func (store *SQLStore) UpdatePotTx(ctx context.Context, arg UpdatePotTxParams) (Pot, error) {
+	var result Pot
+
+	err := store.execTXWithOptions(ctx, recordTxOptions, func(queries *Queries) error {
+		account, err := queries.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if !account.ParentAccountID.Valid {
+			return sql.ErrNoRows
+		}
+		if _, err = queries.GetAccountForUpdate(ctx, account.ParentAccountID.Int64); err != nil {
+			return err
+		}
+		if arg.RoundUpTo.Valid {
+			if err = checkRoundUpPot(ctx, queries, account.ParentAccountID.Int64, account.ID); err != nil {
+				return err
+			}
+		}
+
+		result, err = queries.UpdatePot(ctx, UpdatePotParams{
+			Name:       arg.Name,
+			GoalAmount: arg.GoalAmount,
+			TargetDate: arg.TargetDate,
+			RoundUpTo:  arg.RoundUpTo,
+			AccountID:  account.ID,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// checkRoundUpPot makes sure no pot of the parent account other than potID rounds up its payments,
+// the parent must be locked
+func checkRoundUpPot(ctx context.Context, queries *Queries, parentAccountID int64, potID int64) error {
+	pot, err := queries.GetRoundUpPot(ctx, sql.NullInt64{Int64: parentAccountID, Valid: true})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if pot.AccountID != potID {
+		return fmt.Errorf("%w: account [%d] rounds up into pot [%d]", ErrRoundUpPotTaken, parentAccountID, pot.AccountID)
+	}
+	return nil
+}
+
+type MovePotTxParams struct {
+	PotAccountID int64 `json:"pot_account_id"`
+	Amount       int64 `json:"amount"`
+	// Withdraw moves the money from the pot back to its parent, otherwise it is moved into the pot
+	Withdraw bool `json:"withdraw"`
+}
+
+// MovePotTx moves money between a pot and its parent account at once. The move is a transfer like any other,
+// but it is free and doesn't count towards the transfer limits.
+func (store *SQLStore) MovePotTx(ctx context.Context, arg MovePotTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.execTXWithOptions(ctx, moneyTxOptions, func(queries *Queries) error {
+		pot, err := queries.GetAccount(ctx, arg.PotAccountID)
+		if err != nil {
+			return err
+		}
+		if !pot.ParentAccountID.Valid {
+			return fmt.Errorf("%w: account [%d] is not a pot", ErrPotTransfer, pot.ID)
+		}
+
+		fromAccountID, toAccountID := pot.ParentAccountID.Int64, pot.ID
+		if arg.Withdraw {
+			fromAccountID, toAccountID = toAccountID, fromAccountID
+		}
+		result, err = movePot(ctx, queries, fromAccountID, toAccountID, arg.Amount)
+		return err
+	})
+
+	return result, err
+}
+
+// movePot is the body of MovePotTx once the direction is known, it runs inside the caller's transaction
+func movePot(ctx context.Context, queries *Queries, fromAccountID, toAccountID, amount int64) (TransferTxResult, error) {
+	_, legs, err := multiTransfer(ctx, queries, fromAccountID, sql.NullString{}, []TransferLeg{{ToAccountID: toAccountID, Amount: amount}})
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+	return legs[0], nil
+}
+
+// roundUp rounds a payment from the account up to the next multiple set by the account's round up pot
+// and moves the difference into the pot. The payment is already made, so the round up is skipped rather
+// than failing the payment when the account can't afford it or the pot can't receive money.
+func roundUp(ctx context.Context, queries *Queries, account Account, payment Transfer) (*TransferTxResult, error) {
+	if account.ParentAccountID.Valid {
+		return nil, nil
+	}
+	pot, err := queries.GetRoundUpPot(ctx, sql.NullInt64{Int64: account.ID, Valid: true})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	amount := roundUpAmount(payment.Amount, pot.RoundUpTo.Int64)
+	if amount == 0 {
+		return nil, nil
+	}
+
+	// pots are opened after their parent, so the pot still comes after the parent in the lock order
+	potAccount, err := queries.GetAccountForUpdate(ctx, pot.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if checkCanCredit(potAccount) != nil {
+		return nil, nil
+	}
+	if err = checkFunds(ctx, queries, account, amount); err != nil {
+		if errors.Is(err, ErrInsufficientFunds) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result, err := transfer(ctx, queries, CreateTransferParams{
+		FromAccountID: account.ID,
+		ToAccountID:   potAccount.ID,
+		Amount:        amount,
+		ToAmount:      amount,
+		Memo:          fmt.Sprintf("round up of transfer %d", payment.ID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
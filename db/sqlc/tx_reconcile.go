@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Reconciliation run statuses
+const (
+	ReconciliationOK    = "ok"
+	ReconciliationDrift = "drift"
+)
+
+// ReconciliationReport lists every ledger invariant that doesn't hold
+type ReconciliationReport struct {
+	// DriftedAccounts have a balance that differs from the sum of their entries
+	DriftedAccounts []ListDriftedAccountsRow `json:"drifted_accounts"`
+	// OrphanedEntries don't belong to any transfer
+	OrphanedEntries []Entry `json:"orphaned_entries"`
+	// UnbalancedTransfers don't have exactly one debit of amount and one credit of to_amount
+	UnbalancedTransfers []ListUnbalancedTransfersRow `json:"unbalanced_transfers"`
+}
+
+type ReconcileTxResult struct {
+	Run    ReconciliationRun    `json:"run"`
+	Report ReconciliationReport `json:"report"`
+}
+
+// ReconcileTx checks the ledger invariants on a consistent snapshot and saves the report as a reconciliation run
+func (store *SQLStore) ReconcileTx(ctx context.Context) (ReconcileTxResult, error) {
+	var result ReconcileTxResult
+	startedAt := time.Now()
+
+	err := store.execSnapshotTX(ctx, func(queries *Queries) error {
+		var err error
+		result.Report, err = reconcile(ctx, queries)
+		return err
+	})
+	if err != nil {
+		return result, err
+	}
+
+	report, err := json.Marshal(result.Report)
+	if err != nil {
+		return result, err
+	}
+	status := ReconciliationOK
+	if len(result.Report.DriftedAccounts) > 0 || len(result.Report.OrphanedEntries) > 0 ||
+		len(result.Report.UnbalancedTransfers) > 0 {
+		status = ReconciliationDrift
+	}
+
+	result.Run, err = store.CreateReconciliationRun(ctx, CreateReconciliationRunParams{
+		Status:              status,
+		DriftedAccounts:     int32(len(result.Report.DriftedAccounts)),
+		OrphanedEntries:     int32(len(result.Report.OrphanedEntries)),
+		UnbalancedTransfers: int32(len(result.Report.UnbalancedTransfers)),
+		Report:              report,
+		StartedAt:           startedAt,
+	})
+	return result, err
+}
+
+func reconcile(ctx context.Context, queries *Queries) (report ReconciliationReport, err error) {
+	report.DriftedAccounts, err = queries.ListDriftedAccounts(ctx)
+	if err != nil {
+		return
+	}
+
+	report.OrphanedEntries, err = queries.ListOrphanedEntries(ctx)
+	if err != nil {
+		return
+	}
+
+	report.UnbalancedTransfers, err = queries.ListUnbalancedTransfers(ctx)
+	return
+}
@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// DefaultTxMaxAttempts is how many times a transaction runs before a serialization failure or a deadlock is returned
+	DefaultTxMaxAttempts = 5
+	defaultTxBaseBackoff = 10 * time.Millisecond
+	defaultTxMaxBackoff  = 500 * time.Millisecond
+)
+
+// the SQLSTATE codes postgres uses for transactions that can succeed when they are run again
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// StoreOption configures a SQLStore
+type StoreOption func(store *SQLStore)
+
+// WithTxRetry sets how transactions that fail on a serialization failure or a deadlock are retried
+func WithTxRetry(policy TxRetryPolicy) StoreOption {
+	return func(store *SQLStore) {
+		store.txRetry = policy
+	}
+}
+
+// TxRetryPolicy bounds the retries of a transaction, each wait is drawn between half
+// and all of BaseBackoff doubled per attempt, up to MaxBackoff
+type TxRetryPolicy struct {
+	// MaxAttempts counts the first run, 1 turns retries off
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// DefaultTxRetryPolicy returns the retry policy a store uses unless WithTxRetry is given
+func DefaultTxRetryPolicy() TxRetryPolicy {
+	return TxRetryPolicy{
+		MaxAttempts: DefaultTxMaxAttempts,
+		BaseBackoff: defaultTxBaseBackoff,
+		MaxBackoff:  defaultTxMaxBackoff,
+	}
+}
+
+// backoff returns how long to wait before the retry that follows the given failed attempt, counted from 1
+func (policy TxRetryPolicy) backoff(attempt int) time.Duration {
+	wait := policy.MaxBackoff
+	if attempt <= 30 {
+		if exp := policy.BaseBackoff << (attempt - 1); exp > 0 && exp < wait {
+			wait = exp
+		}
+	}
+	if wait <= 0 {
+		return 0
+	}
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+// TxStats counts the transaction failures a store has retried since it was created
+type TxStats struct {
+	SerializationFailures int64 `json:"serialization_failures"`
+	Deadlocks             int64 `json:"deadlocks"`
+	// Retries counts the runs that followed a failure
+	Retries int64 `json:"retries"`
+	// Exhausted counts the transactions that still failed after the last attempt or ran out of time
+	Exhausted int64 `json:"exhausted"`
+}
+
+type txCounters struct {
+	serializationFailures atomic.Int64
+	deadlocks             atomic.Int64
+	retries               atomic.Int64
+	exhausted             atomic.Int64
+}
+
+// TxStats returns the retry counters of the store
+func (store *SQLStore) TxStats() TxStats {
+	return TxStats{
+		SerializationFailures: store.txCounters.serializationFailures.Load(),
+		Deadlocks:             store.txCounters.deadlocks.Load(),
+		Retries:               store.txCounters.retries.Load(),
+		Exhausted:             store.txCounters.exhausted.Load(),
+	}
+}
+
+// retryableTxCode returns the SQLSTATE of err when the transaction that returned it can be run again
+func retryableTxCode(err error) (string, bool) {
+	var pgErr *pq.Error
+	if !errors.As(err, &pgErr) {
+		return "", false
+	}
+	switch code := string(pgErr.Code); code {
+	case sqlStateSerializationFailure, sqlStateDeadlockDetected:
+		return code, true
+	}
+	return "", false
+}
+
+// execTXWithRetry runs fn in a transaction until it commits, fails for a reason a retry can't fix,
+// uses up the attempts of the retry policy or the next wait would pass the context deadline
+func (store *SQLStore) execTXWithRetry(ctx context.Context, opts *sql.TxOptions, fn func(queries *Queries) error) error {
+	for attempt := 1; ; attempt++ {
+		err := store.runTX(ctx, opts, fn)
+		code, retryable := retryableTxCode(err)
+		if !retryable {
+			return err
+		}
+
+		switch code {
+		case sqlStateSerializationFailure:
+			store.txCounters.serializationFailures.Add(1)
+		case sqlStateDeadlockDetected:
+			store.txCounters.deadlocks.Add(1)
+		}
+		if attempt >= store.txRetry.MaxAttempts {
+			store.txCounters.exhausted.Add(1)
+			return err
+		}
+
+		wait := store.txRetry.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			store.txCounters.exhausted.Add(1)
+			return err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			store.txCounters.exhausted.Add(1)
+			return err
+		case <-timer.C:
+		}
+		store.txCounters.retries.Add(1)
+	}
+}
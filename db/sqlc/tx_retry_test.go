@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRetryableTxCode(t *testing.T) {
+	code, ok := retryableTxCode(&pq.Error{Code: "40001"})
+	require.True(t, ok)
+	require.Equal(t, sqlStateSerializationFailure, code)
+
+	code, ok = retryableTxCode(fmt.Errorf("transfer: %w", &pq.Error{Code: "40P01"}))
+	require.True(t, ok)
+	require.Equal(t, sqlStateDeadlockDetected, code)
+
+	_, ok = retryableTxCode(&pq.Error{Code: "23505"})
+	require.False(t, ok)
+	_, ok = retryableTxCode(ErrInsufficientFunds)
+	require.False(t, ok)
+	_, ok = retryableTxCode(nil)
+	require.False(t, ok)
+}
+
+func TestTxRetryPolicyBackoff(t *testing.T) {
+	policy := TxRetryPolicy{MaxAttempts: 5, BaseBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+
+	for i := 0; i < 100; i++ {
+		wait := policy.backoff(1)
+		require.GreaterOrEqual(t, wait, 5*time.Millisecond)
+		require.LessOrEqual(t, wait, 10*time.Millisecond)
+
+		wait = policy.backoff(2)
+		require.GreaterOrEqual(t, wait, 10*time.Millisecond)
+		require.LessOrEqual(t, wait, 20*time.Millisecond)
+
+		// the wait stops growing at MaxBackoff, even for attempts that would overflow the shift
+		for _, attempt := range []int{4, 10, 64} {
+			wait = policy.backoff(attempt)
+			require.GreaterOrEqual(t, wait, 25*time.Millisecond)
+			require.LessOrEqual(t, wait, 50*time.Millisecond)
+		}
+	}
+}
+
+func TestExecTXRetry(t *testing.T) {
+	store := NewStore(testDB, WithTxRetry(TxRetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	})).(*SQLStore)
+
+	runs := 0
+	err := store.execTXWithOptions(context.Background(), recordTxOptions, func(queries *Queries) error {
+		runs++
+		if runs < 3 {
+			return &pq.Error{Code: "40001"}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, runs)
+	require.Equal(t, TxStats{SerializationFailures: 2, Retries: 2}, store.TxStats())
+
+	// the last failure is returned once the attempts are used up
+	runs = 0
+	err = store.execTXWithOptions(context.Background(), recordTxOptions, func(queries *Queries) error {
+		runs++
+		return &pq.Error{Code: "40P01"}
+	})
+	var pgErr *pq.Error
+	require.True(t, errors.As(err, &pgErr))
+	require.Equal(t, sqlStateDeadlockDetected, string(pgErr.Code))
+	require.Equal(t, 3, runs)
+	require.Equal(t, TxStats{SerializationFailures: 2, Deadlocks: 3, Retries: 4, Exhausted: 1}, store.TxStats())
+
+	// errors a retry can't fix are returned at once
+	runs = 0
+	err = store.execTXWithOptions(context.Background(), recordTxOptions, func(queries *Queries) error {
+		runs++
+		return ErrInsufficientFunds
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	require.Equal(t, 1, runs)
+}
+
+func TestExecTXRetryDeadline(t *testing.T) {
+	store := NewStore(testDB, WithTxRetry(TxRetryPolicy{
+		MaxAttempts: 10,
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Second,
+	})).(*SQLStore)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	runs := 0
+	err := store.execTXWithOptions(ctx, recordTxOptions, func(queries *Queries) error {
+		runs++
+		return &pq.Error{Code: "40001"}
+	})
+	require.Error(t, err)
+	// the next wait would pass the deadline so there is no second run
+	require.Equal(t, 1, runs)
+	require.Equal(t, int64(1), store.TxStats().Exhausted)
+}
+
+func TestSerializableTransferTx(t *testing.T) {
+	// transfers run serializable and every transfer conflicts with the others, leave room for more retries than the default
+	policy := DefaultTxRetryPolicy()
+	policy.MaxAttempts = 20
+	store := NewStore(testDB, WithTxRetry(policy))
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 100)
+
+	n := 10
+	amount := int64(10)
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		fromAccountID := account1.ID
+		toAccountID := account2.ID
+
+		if i%2 == 1 {
+			fromAccountID = account2.ID
+			toAccountID = account1.ID
+		}
+
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: fromAccountID,
+				ToAccountID:   toAccountID,
+				Amount:        amount,
+			})
+
+			errs <- err
+		}()
+	}
+
+	// conflicting transfers are retried instead of failing
+	for i := 0; i < n; i++ {
+		err := <-errs
+		require.NoError(t, err)
+	}
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	updatedAccount2, err := store.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+)
+
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// Amount is returned to the from account of the original transfer, in its currency.
+	// It may be less than the original amount for a partial reversal.
+	Amount int64 `json:"amount"`
+	// Idempotency is optional, when set a retried request returns the first result instead of moving money again
+	Idempotency *IdempotencyParams `json:"-"`
+}
+
+type ReverseTransferTxResult struct {
+	// TransferTxResult holds the compensating transfer, from the original to account back to the original from account
+	TransferTxResult
+	OriginalTransfer Transfer `json:"original_transfer"`
+}
+
+// ReverseTransferTx undoes all or part of a transfer by moving the money back with a new transfer
+// linked to the original, which is marked as reversed. A transfer can be reversed only once.
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	replayed, err := store.execIdempotentTX(ctx, moneyTxOptions, arg.Idempotency, arg, &result, func(queries *Queries) error {
+		original, err := queries.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+		if original.ReversalOf.Valid {
+			return fmt.Errorf("%w: transfer [%d] is itself a reversal", ErrTransferNotReversible, original.ID)
+		}
+		if original.ReversedAmount > 0 {
+			return fmt.Errorf("%w: transfer [%d]", ErrTransferAlreadyReversed, original.ID)
+		}
+		if arg.Amount <= 0 || arg.Amount > original.Amount {
+			return fmt.Errorf("%w: amount %d is not between 1 and %d", ErrTransferNotReversible, arg.Amount, original.Amount)
+		}
+
+		debit := reversalDebit(original, arg.Amount)
+		if debit <= 0 {
+			return fmt.Errorf("%w: amount %d is too small to convert back", ErrTransferNotReversible, arg.Amount)
+		}
+
+		payer, payee, err := lockAccounts(ctx, queries, original.ToAccountID, original.FromAccountID)
+		if err != nil {
+			return err
+		}
+		if err = checkCanTransfer(payer, payee); err != nil {
+			return err
+		}
+		if err = checkFunds(ctx, queries, payer, debit); err != nil {
+			return err
+		}
+
+		result.TransferTxResult, err = transfer(ctx, queries, CreateTransferParams{
+			FromAccountID: original.ToAccountID,
+			ToAccountID:   original.FromAccountID,
+			Amount:        debit,
+			ToAmount:      arg.Amount,
+			ReversalOf:    sql.NullInt64{Int64: original.ID, Valid: true},
+		})
+		if err != nil {
+			if isUniqueViolation(err, "transfers_reversal_of_key") {
+				return fmt.Errorf("%w: transfer [%d]", ErrTransferAlreadyReversed, original.ID)
+			}
+			return err
+		}
+
+		result.OriginalTransfer, err = queries.MarkTransferReversed(ctx, MarkTransferReversedParams{
+			ID:             original.ID,
+			ReversedAmount: arg.Amount,
+		})
+		return err
+	})
+	result.Replayed = replayed
+
+	return result, err
+}
+
+// reversalDebit returns how much to take back from the to account of the original transfer
+// to return amount to its from account. Cross-currency transfers are unwound at their original rate,
+// rounding down so the to account never gives back more than it received.
+func reversalDebit(original Transfer, amount int64) int64 {
+	if amount == original.Amount {
+		return original.ToAmount
+	}
+
+	debit := new(big.Int).Mul(big.NewInt(amount), big.NewInt(original.ToAmount))
+	debit.Quo(debit, big.NewInt(original.Amount))
+	return debit.Int64()
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"bank/util"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Standing order statuses
+const (
+	StandingOrderActive    = "active"
+	StandingOrderPaused    = "paused"
+	StandingOrderCompleted = "completed"
+	StandingOrderCancelled = "cancelled"
+)
+
+// Standing order failure policies, applied when a run hits insufficient funds
+const (
+	StandingOrderRetry = "retry"
+	StandingOrderSkip  = "skip"
+)
+
+// Standing order run outcomes
+const (
+	StandingOrderRunSucceeded = "succeeded"
+	StandingOrderRunFailed    = "failed"
+	StandingOrderRunSkipped   = "skipped"
+)
+
+type RecordStandingOrderRunTxParams struct {
+	StandingOrderID int64         `json:"standing_order_id"`
+	ScheduledAt     time.Time     `json:"scheduled_at"`
+	Attempt         int32         `json:"attempt"`
+	Status          string        `json:"status"`
+	TransferID      sql.NullInt64 `json:"transfer_id"`
+	Error           string        `json:"error"`
+	// Advance moves the order on to its next run, or completes it when there is none.
+	// It is false when the run will be retried.
+	Advance bool `json:"advance"`
+}
+
+type RecordStandingOrderRunTxResult struct {
+	StandingOrder StandingOrder    `json:"standing_order"`
+	Run           StandingOrderRun `json:"run"`
+}
+
+// RecordStandingOrderRunTx saves the outcome of a standing order run and schedules the next one
+func (store *SQLStore) RecordStandingOrderRunTx(ctx context.Context, arg RecordStandingOrderRunTxParams) (RecordStandingOrderRunTxResult, error) {
+	var result RecordStandingOrderRunTxResult
+
+	err := store.execTXWithOptions(ctx, recordTxOptions, func(queries *Queries) error {
+		order, err := queries.GetStandingOrderForUpdate(ctx, arg.StandingOrderID)
+		if err != nil {
+			return err
+		}
+		if !order.NextRunAt.Equal(arg.ScheduledAt) {
+			return fmt.Errorf("%w: standing order [%d] is due at %s, not %s",
+				ErrStaleStandingOrderRun, order.ID, order.NextRunAt, arg.ScheduledAt)
+		}
+
+		result.Run, err = queries.CreateStandingOrderRun(ctx, CreateStandingOrderRunParams{
+			StandingOrderID: arg.StandingOrderID,
+			ScheduledAt:     arg.ScheduledAt,
+			Attempt:         arg.Attempt,
+			Status:          arg.Status,
+			TransferID:      arg.TransferID,
+			Error:           arg.Error,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.StandingOrder = order
+		if !arg.Advance {
+			return nil
+		}
+
+		next, ok, err := util.NextRunAt(order.Schedule, order.CronExpression, order.StartAt, order.NextRunAt)
+		if err != nil {
+			return err
+		}
+		advance := AdvanceStandingOrderParams{
+			ID:        order.ID,
+			NextRunAt: next,
+			Status:    order.Status,
+		}
+		if !ok || (order.EndAt.Valid && next.After(order.EndAt.Time)) {
+			advance.NextRunAt = order.NextRunAt
+			advance.Status = StandingOrderCompleted
+		}
+
+		result.StandingOrder, err = queries.AdvanceStandingOrder(ctx, advance)
+		return err
+	})
+
+	return result, err
+}
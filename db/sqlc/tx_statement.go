@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+type AccountStatementTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// From is inclusive and To is exclusive
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type AccountStatementTxResult struct {
+	Account        Account                   `json:"account"`
+	OpeningBalance int64                     `json:"opening_balance"`
+	ClosingBalance int64                     `json:"closing_balance"`
+	Entries        []ListStatementEntriesRow `json:"entries"`
+}
+
+// AccountStatementTx loads the entries of an account in a period together with its balance at both ends.
+// The balances are worked back from the current balance so that they match it even if the ledger has drifted.
+func (store *SQLStore) AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error) {
+	var result AccountStatementTxResult
+
+	err := store.execSnapshotTX(ctx, func(queries *Queries) error {
+		var err error
+		result.Account, err = queries.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		laterTotal, err := queries.GetEntriesTotalSince(ctx, GetEntriesTotalSinceParams{
+			AccountID: arg.AccountID,
+			Since:     arg.To,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Entries, err = queries.ListStatementEntries(ctx, ListStatementEntriesParams{
+			AccountID: arg.AccountID,
+			FromTime:  arg.From,
+			ToTime:    arg.To,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ClosingBalance = result.Account.Balance - laterTotal
+		result.OpeningBalance = result.ClosingBalance
+		for _, entry := range result.Entries {
+			result.OpeningBalance -= entry.Amount
+		}
+		return nil
+	})
+
+	return result, err
+}
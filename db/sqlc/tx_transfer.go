@@ -1,11 +1,26 @@
 package db
 
-import "context"
+import (
+	"bank/util"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	Amount        int64 `json:"amount"`
+	// TransferType picks the fee schedule, it defaults to TransferTypeTransfer
+	TransferType string `json:"transfer_type"`
+	TransferDetails
+	// InitiatedBy is the user making the transfer, whose user limits it counts against.
+	// It defaults to the owner of the from account.
+	InitiatedBy string `json:"-"`
+	// Idempotency is optional, when set a retried request returns the first result instead of moving money again
+	Idempotency *IdempotencyParams `json:"-"`
 }
 
 type TransferTxResult struct {
@@ -14,47 +29,182 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	// AmountFormatted and ToAmountFormatted are the amounts debited and credited as decimals
+	// in the currencies of the from and to accounts
+	AmountFormatted   string `json:"amount_formatted"`
+	ToAmountFormatted string `json:"to_amount_formatted"`
+	// Fee is set when a fee was charged on top of the amount
+	Fee *TransferFee `json:"fee,omitempty"`
+	// RoundUp is set when the payment was rounded up into a pot of the from account
+	RoundUp *TransferTxResult `json:"round_up,omitempty"`
+	// Replayed is true when the result was saved by an earlier request with the same idempotency key
+	Replayed bool `json:"-"`
 }
 
+// TransferTx moves money between two accounts in the same currency, it is a MultiTransferTx with a single leg.
+// The fee from the matching fee schedule is paid into the fee income account as a second leg.
+// The transfer is refused with a *TransferLimitError when it would breach a velocity limit.
+// When the from account has a round up pot the payment is rounded up into it.
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
-	err := store.execTX(ctx, func(queries *Queries) error {
+	replayed, err := store.execIdempotentTX(ctx, moneyTxOptions, arg.Idempotency, arg, &result, func(queries *Queries) error {
 		var err error
+		result, err = transferTx(ctx, queries, arg)
+		return err
+	})
+	result.Replayed = replayed
 
-		result.Transfer, err = queries.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
-		result.FromEntry, err = queries.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
+	return result, err
+}
 
-		result.ToEntry, err = queries.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
-		if err != nil {
-			return err
+// transferTx is the body of TransferTx, it runs inside the caller's transaction
+func transferTx(ctx context.Context, queries *Queries, arg TransferTxParams) (result TransferTxResult, err error) {
+	transferType := arg.TransferType
+	if transferType == "" {
+		transferType = TransferTypeTransfer
+	}
+
+	fromAccount, err := queries.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return
+	}
+	initiatedBy := transferInitiator(fromAccount, arg.InitiatedBy)
+	// the fee doesn't count towards the limits
+	if err = checkTransferLimits(ctx, queries, fromAccount, initiatedBy.String, []int64{arg.Amount}); err != nil {
+		return
+	}
+	schedule, feeLeg, charged, err := transferFee(ctx, queries, fromAccount, transferType, arg.Amount)
+	if err != nil {
+		return
+	}
+
+	// the details belong to the payment, not to its fee
+	transferLegs := []TransferLeg{{ToAccountID: arg.ToAccountID, Amount: arg.Amount, TransferDetails: arg.TransferDetails}}
+	if charged {
+		transferLegs = append(transferLegs, feeLeg)
+	}
+	fromAccount, legs, err := multiTransfer(ctx, queries, arg.FromAccountID, initiatedBy, transferLegs)
+	if err != nil {
+		return
+	}
+
+	result = legs[0]
+	if charged {
+		result.FromAccount = fromAccount
+		result.Fee = &TransferFee{
+			ScheduleID: schedule.ID,
+			Amount:     feeLeg.Amount,
+			Transfer:   legs[1].Transfer,
+			Entry:      legs[1].FromEntry,
 		}
+	}
+
+	result.RoundUp, err = roundUp(ctx, queries, fromAccount, result.Transfer)
+	if err != nil {
+		return
+	}
+	if result.RoundUp != nil {
+		result.FromAccount = result.RoundUp.FromAccount
+	}
+	return
+}
 
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, queries, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, queries, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+// transfer creates the transfer record with its two entries and moves the money,
+// debiting arg.Amount and crediting arg.ToAmount. Both accounts must already be locked.
+// The balances are moved first so that each entry records the balance it left the account with.
+func transfer(ctx context.Context, queries *Queries, arg CreateTransferParams) (result TransferTxResult, err error) {
+	if len(arg.Metadata) == 0 {
+		arg.Metadata = json.RawMessage(`{}`)
+	}
+	result.Transfer, err = queries.CreateTransfer(ctx, arg)
+	if err != nil {
+		if isUniqueViolation(err, transferClientReferenceKey) {
+			err = fmt.Errorf("%w: %q from account [%d]", ErrDuplicateClientReference, arg.ClientReference.String, arg.FromAccountID)
 		}
-		return nil
+		return
+	}
+
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(ctx, queries, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.ToAmount)
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(ctx, queries, arg.ToAccountID, arg.ToAmount, arg.FromAccountID, -arg.Amount)
+	}
+	if err != nil {
+		return
+	}
+	if err = checkPotTransfer(result.FromAccount, result.ToAccount); err != nil {
+		return
+	}
+
+	transferID := sql.NullInt64{Int64: result.Transfer.ID, Valid: true}
+	result.FromEntry, err = queries.CreateEntry(ctx, CreateEntryParams{
+		AccountID:    arg.FromAccountID,
+		Amount:       -arg.Amount,
+		TransferID:   transferID,
+		BalanceAfter: result.FromAccount.Balance,
 	})
+	if err != nil {
+		return
+	}
 
-	return result, err
+	result.ToEntry, err = queries.CreateEntry(ctx, CreateEntryParams{
+		AccountID:    arg.ToAccountID,
+		Amount:       arg.ToAmount,
+		TransferID:   transferID,
+		BalanceAfter: result.ToAccount.Balance,
+	})
+	if err != nil {
+		return
+	}
+
+	result.AmountFormatted = util.FormatCurrencyAmount(arg.Amount, result.FromAccount.Currency)
+	result.ToAmountFormatted = util.FormatCurrencyAmount(arg.ToAmount, result.ToAccount.Currency)
+	return
+}
+
+// lockAccounts locks both accounts in ascending id order to avoid deadlock,
+// and returns them in the order they were passed in
+func lockAccounts(
+	ctx context.Context,
+	queries *Queries,
+	accountID1 int64,
+	accountID2 int64,
+) (account1 Account, account2 Account, err error) {
+	if accountID1 > accountID2 {
+		account2, account1, err = lockAccounts(ctx, queries, accountID2, accountID1)
+		return
+	}
+
+	account1, err = queries.GetAccountForUpdate(ctx, accountID1)
+	if err != nil {
+		return
+	}
+
+	account2, err = queries.GetAccountForUpdate(ctx, accountID2)
+	return
+}
+
+// checkFunds makes sure the account can be debited by amount without going past its overdraft limit.
+// Funds reserved by active holds can't be spent.
+func checkFunds(ctx context.Context, queries *Queries, account Account, amount int64) error {
+	held, err := queries.GetHeldAmount(ctx, GetHeldAmountParams{
+		AccountID: account.ID,
+		Now:       time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return checkAvailableBalance(account, held, amount)
+}
+
+// checkAvailableBalance checks the debit against the balance less the held amount
+func checkAvailableBalance(account Account, held int64, amount int64) error {
+	if account.Balance-held-amount < -account.OverdraftLimit {
+		return fmt.Errorf("%w: account [%d] balance %d, held %d, overdraft limit %d, debit %d",
+			ErrInsufficientFunds, account.ID, account.Balance, held, account.OverdraftLimit, amount)
+	}
+	return nil
 }
 
 func addMoney(
@@ -0,0 +1,290 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Transfer request statuses
+const (
+	TransferRequestPending  = "pending"
+	TransferRequestApproved = "approved"
+	TransferRequestRejected = "rejected"
+	TransferRequestExpired  = "expired"
+)
+
+type CreateTransferRequestTxParams struct {
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	RequestedBy   string `json:"requested_by"`
+	TransferDetails
+	// Legs is set instead of ToAccountID and Amount for a multi transfer, the request is for their total
+	Legs []TransferLeg `json:"legs,omitempty"`
+	// ExpiresAt is left out of the request hash, a retry computes a later expiry for the same request
+	ExpiresAt time.Time `json:"-"`
+	// Idempotency is optional, when set a retried request returns the first request instead of creating another
+	Idempotency *IdempotencyParams `json:"-"`
+}
+
+type CreateTransferRequestTxResult struct {
+	TransferRequest TransferRequest `json:"transfer_request"`
+	// Legs is set on a multi transfer request
+	Legs []TransferRequestLeg `json:"legs,omitempty"`
+	// Replayed is true when the result was saved by an earlier request with the same idempotency key
+	Replayed bool `json:"-"`
+}
+
+// CreateTransferRequestTx records a transfer or a multi transfer that has to be approved by a second user before it runs
+func (store *SQLStore) CreateTransferRequestTx(ctx context.Context, arg CreateTransferRequestTxParams) (CreateTransferRequestTxResult, error) {
+	var result CreateTransferRequestTxResult
+
+	replayed, err := store.execIdempotentTX(ctx, recordTxOptions, arg.Idempotency, arg, &result, func(queries *Queries) error {
+		params := CreateTransferRequestParams{
+			FromAccountID:   arg.FromAccountID,
+			ToAccountID:     sql.NullInt64{Int64: arg.ToAccountID, Valid: len(arg.Legs) == 0},
+			Amount:          arg.Amount,
+			RequestedBy:     arg.RequestedBy,
+			ExpiresAt:       arg.ExpiresAt,
+			Memo:            arg.Memo,
+			ClientReference: arg.clientReference(),
+			Metadata:        arg.metadata(),
+		}
+		if len(arg.Legs) > 0 {
+			params.Amount = 0
+			for _, leg := range arg.Legs {
+				if leg.Amount <= 0 || leg.Amount > math.MaxInt64-params.Amount {
+					return fmt.Errorf("invalid amount %d for account [%d]", leg.Amount, leg.ToAccountID)
+				}
+				params.Amount += leg.Amount
+			}
+		}
+
+		var err error
+		result.TransferRequest, err = queries.CreateTransferRequest(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		for _, leg := range arg.Legs {
+			requestLeg, err := queries.CreateTransferRequestLeg(ctx, CreateTransferRequestLegParams{
+				TransferRequestID: result.TransferRequest.ID,
+				ToAccountID:       leg.ToAccountID,
+				Amount:            leg.Amount,
+			})
+			if err != nil {
+				return err
+			}
+			result.Legs = append(result.Legs, requestLeg)
+		}
+		return nil
+	})
+	result.Replayed = replayed
+
+	return result, err
+}
+
+type ApproveTransferRequestTxParams struct {
+	RequestID  int64  `json:"request_id"`
+	ApprovedBy string `json:"approved_by"`
+}
+
+type ApproveTransferRequestTxResult struct {
+	TransferRequest TransferRequest `json:"transfer_request"`
+	// Transfer is set when the request pays a single account, MultiTransfer when it pays several
+	Transfer      *TransferTxResult      `json:"transfer,omitempty"`
+	MultiTransfer *MultiTransferTxResult `json:"multi_transfer,omitempty"`
+	// Legs is set on a multi transfer request, each leg with the transfer made for it
+	Legs []TransferRequestLeg `json:"legs,omitempty"`
+}
+
+// ApproveTransferRequestTx makes the requested transfer and records who approved it, in one transaction.
+// A request between a pot and its parent account is made as a pot move, a multi transfer request pays all its legs.
+// When the transfer fails the request stays pending, so it can be approved again or rejected.
+func (store *SQLStore) ApproveTransferRequestTx(ctx context.Context, arg ApproveTransferRequestTxParams) (ApproveTransferRequestTxResult, error) {
+	var result ApproveTransferRequestTxResult
+
+	err := store.execTXWithOptions(ctx, moneyTxOptions, func(queries *Queries) error {
+		request, err := lockPendingTransferRequest(ctx, queries, arg.RequestID, arg.ApprovedBy)
+		if err != nil {
+			return err
+		}
+
+		// the transfers of a multi transfer request are recorded on its legs
+		var transferID sql.NullInt64
+		if request.ToAccountID.Valid {
+			result.Transfer, err = approveTransferRequest(ctx, queries, request)
+			if err != nil {
+				return err
+			}
+			transferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+		} else {
+			result.MultiTransfer, result.Legs, err = approveMultiTransferRequest(ctx, queries, request)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.TransferRequest, err = queries.DecideTransferRequest(ctx, DecideTransferRequestParams{
+			ID:         request.ID,
+			Status:     TransferRequestApproved,
+			DecidedBy:  sql.NullString{String: arg.ApprovedBy, Valid: true},
+			TransferID: transferID,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// approveTransferRequest makes the transfer of a request that pays a single account
+func approveTransferRequest(ctx context.Context, queries *Queries, request TransferRequest) (*TransferTxResult, error) {
+	fromAccount, err := queries.GetAccount(ctx, request.FromAccountID)
+	if err != nil {
+		return nil, err
+	}
+	toAccount, err := queries.GetAccount(ctx, request.ToAccountID.Int64)
+	if err != nil {
+		return nil, err
+	}
+
+	var result TransferTxResult
+	switch {
+	case isPotMove(fromAccount, toAccount):
+		// a large pot move waits for approval like any transfer, but stays free once approved
+		result, err = movePot(ctx, queries, request.FromAccountID, toAccount.ID, request.Amount)
+	case fromAccount.Currency == toAccount.Currency:
+		result, err = transferTx(ctx, queries, TransferTxParams{
+			FromAccountID:   request.FromAccountID,
+			ToAccountID:     toAccount.ID,
+			Amount:          request.Amount,
+			TransferDetails: request.Details(),
+			InitiatedBy:     request.RequestedBy,
+		})
+	default:
+		var exchange ExchangeTransferTxResult
+		exchange, err = exchangeTransferTx(ctx, queries, ExchangeTransferTxParams{
+			FromAccountID:   request.FromAccountID,
+			ToAccountID:     toAccount.ID,
+			Amount:          request.Amount,
+			TransferDetails: request.Details(),
+			InitiatedBy:     request.RequestedBy,
+		})
+		result = exchange.TransferTxResult
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// approveMultiTransferRequest pays every leg of a request and records the transfer made for each leg
+func approveMultiTransferRequest(ctx context.Context, queries *Queries, request TransferRequest) (*MultiTransferTxResult, []TransferRequestLeg, error) {
+	requestLegs, err := queries.ListTransferRequestLegs(ctx, request.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	legs := make([]TransferLeg, len(requestLegs))
+	for i, leg := range requestLegs {
+		legs[i] = TransferLeg{ToAccountID: leg.ToAccountID, Amount: leg.Amount}
+	}
+	result, err := multiTransferTx(ctx, queries, MultiTransferTxParams{
+		FromAccountID: request.FromAccountID,
+		Legs:          legs,
+		InitiatedBy:   request.RequestedBy,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, leg := range result.Legs {
+		requestLegs[i], err = queries.SetTransferRequestLegTransfer(ctx, SetTransferRequestLegTransferParams{
+			TransferID: sql.NullInt64{Int64: leg.Transfer.ID, Valid: true},
+			ID:         requestLegs[i].ID,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return &result, requestLegs, nil
+}
+
+type RejectTransferRequestTxParams struct {
+	RequestID  int64  `json:"request_id"`
+	RejectedBy string `json:"rejected_by"`
+	Reason     string `json:"reason"`
+}
+
+// RejectTransferRequestTx records who rejected a pending transfer request and why, no money moves
+func (store *SQLStore) RejectTransferRequestTx(ctx context.Context, arg RejectTransferRequestTxParams) (TransferRequest, error) {
+	var result TransferRequest
+
+	err := store.execTXWithOptions(ctx, recordTxOptions, func(queries *Queries) error {
+		request, err := lockPendingTransferRequest(ctx, queries, arg.RequestID, arg.RejectedBy)
+		if err != nil {
+			return err
+		}
+
+		result, err = queries.DecideTransferRequest(ctx, DecideTransferRequestParams{
+			ID:        request.ID,
+			Status:    TransferRequestRejected,
+			DecidedBy: sql.NullString{String: arg.RejectedBy, Valid: true},
+			Reason:    arg.Reason,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// CanDecideTransferRequest reports whether username may approve or reject the request: a bank officer
+// or an owner of the from account, who may be the holder or a co-owner of a joint account.
+// It doesn't look at who made the request.
+func CanDecideTransferRequest(ctx context.Context, q Querier, request TransferRequest, username string) (bool, error) {
+	user, err := q.GetUser(ctx, username)
+	if err != nil {
+		return false, err
+	}
+	if user.Role == RoleOfficer {
+		return true, nil
+	}
+
+	fromAccount, err := q.GetAccount(ctx, request.FromAccountID)
+	if err != nil {
+		return false, err
+	}
+	role, err := AccountRole(ctx, q, fromAccount, username)
+	if err != nil {
+		return false, err
+	}
+	return role == AccountRoleOwner, nil
+}
+
+// lockPendingTransferRequest locks a request that can still be decided by decidedBy,
+// who must be allowed to decide it and can't be its requester
+func lockPendingTransferRequest(ctx context.Context, queries *Queries, requestID int64, decidedBy string) (TransferRequest, error) {
+	request, err := queries.GetTransferRequestForUpdate(ctx, requestID)
+	if err != nil {
+		return request, err
+	}
+	// the expiry task may not have caught up yet
+	if request.Status != TransferRequestPending || !request.ExpiresAt.After(time.Now()) {
+		return request, fmt.Errorf("%w: transfer request [%d] is %s, expires at %s",
+			ErrTransferRequestNotPending, request.ID, request.Status, request.ExpiresAt.Format(time.RFC3339))
+	}
+	if request.RequestedBy == decidedBy {
+		return request, fmt.Errorf("%w: transfer request [%d]", ErrTransferRequestSelfDecision, request.ID)
+	}
+	allowed, err := CanDecideTransferRequest(ctx, queries, request, decidedBy)
+	if err != nil {
+		return request, err
+	}
+	if !allowed {
+		return request, fmt.Errorf("%w: transfer request [%d], account [%d]", ErrTransferRequestDecider, request.ID, request.FromAccountID)
+	}
+	return request, nil
+}
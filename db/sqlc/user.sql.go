@@ -15,7 +15,7 @@ INSERT INTO users (username,
                    hashed_password,
                    full_name,
                    email)
-VALUES ($1, $2, $3, $4) RETURNING username, hashed_password, full_name, password_changed_at, email, created_at
+VALUES ($1, $2, $3, $4) RETURNING username, hashed_password, full_name, password_changed_at, email, created_at, role
 `
 
 type CreateUserParams struct {
@@ -40,12 +40,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.PasswordChangedAt,
 		&i.Email,
 		&i.CreatedAt,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, password_changed_at, email, created_at
+SELECT username, hashed_password, full_name, password_changed_at, email, created_at, role
 FROM users
 WHERE username = $1 LIMIT 1
 `
@@ -60,6 +61,30 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.PasswordChangedAt,
 		&i.Email,
 		&i.CreatedAt,
+		&i.Role,
+	)
+	return i, err
+}
+
+const getUserForUpdate = `-- name: GetUserForUpdate :one
+SELECT username, hashed_password, full_name, password_changed_at, email, created_at, role
+FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY
+UPDATE
+`
+
+func (q *Queries) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserForUpdate, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.PasswordChangedAt,
+		&i.Email,
+		&i.CreatedAt,
+		&i.Role,
 	)
 	return i, err
 }
@@ -70,7 +95,7 @@ SET hashed_password     = COALESCE($1, hashed_password),
     password_changed_at = COALESCE($2, password_changed_at),
     full_name           = COALESCE($3, full_name),
     email               = COALESCE($4, email)
-WHERE username = $5 RETURNING username, hashed_password, full_name, password_changed_at, email, created_at
+WHERE username = $5 RETURNING username, hashed_password, full_name, password_changed_at, email, created_at, role
 `
 
 type UpdateUserParams struct {
@@ -97,6 +122,33 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.PasswordChangedAt,
 		&i.Email,
 		&i.CreatedAt,
+		&i.Role,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET role = $1
+WHERE username = $2 RETURNING username, hashed_password, full_name, password_changed_at, email, created_at, role
+`
+
+type UpdateUserRoleParams struct {
+	Role     string `json:"role"`
+	Username string `json:"username"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserRole, arg.Role, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.PasswordChangedAt,
+		&i.Email,
+		&i.CreatedAt,
+		&i.Role,
 	)
 	return i, err
 }
@@ -4,8 +4,10 @@ import (
 	"bank/token"
 	"context"
 	"fmt"
+	"github.com/google/uuid"
 	"google.golang.org/grpc/metadata"
 	"strings"
+	"time"
 )
 
 const (
@@ -40,6 +42,20 @@ func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error)
 	if err != nil {
 		return nil, fmt.Errorf("invalid access token %s", accessToken)
 	}
+	if payload.Type == token.TokenTypeRefresh {
+		return nil, fmt.Errorf("a refresh token can't authorize a request")
+	}
+
+	// the tokens issued before sessions were tracked have no session and stay valid until they expire
+	if payload.SessionID != uuid.Nil {
+		session, err := server.store.GetSession(ctx, payload.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("can not get session: %w", err)
+		}
+		if err = session.Check(payload.Username, time.Now()); err != nil {
+			return nil, err
+		}
+	}
 
 	return payload, nil
 }
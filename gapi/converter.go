@@ -3,6 +3,11 @@ package gapi
 import (
 	db "bank/db/sqlc"
 	"bank/pb"
+	"bank/util"
+	"encoding/json"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -15,3 +20,153 @@ func ConvertUser(user db.User) *pb.User {
 		CreatedAt:         timestamppb.New(user.CreatedAt),
 	}
 }
+
+func ConvertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:               account.ID,
+		Owner:            account.Owner,
+		Balance:          account.Balance,
+		Currency:         account.Currency,
+		OverdraftLimit:   account.OverdraftLimit,
+		CreatedAt:        timestamppb.New(account.CreatedAt),
+		Status:           account.Status,
+		BalanceFormatted: util.FormatCurrencyAmount(account.Balance, account.Currency),
+		Joint:            account.Joint,
+		ParentAccountId:  account.ParentAccountID.Int64,
+	}
+}
+
+func ConvertAccountMember(member db.AccountMember) *pb.AccountMember {
+	res := &pb.AccountMember{
+		AccountId: member.AccountID,
+		Username:  member.Username,
+		Role:      member.Role,
+		Status:    member.Status,
+		InvitedBy: member.InvitedBy,
+		CreatedAt: timestamppb.New(member.CreatedAt),
+	}
+	if member.AcceptedAt.Valid {
+		res.AcceptedAt = timestamppb.New(member.AcceptedAt.Time)
+	}
+	return res
+}
+
+func ConvertSession(session db.Session, currentID uuid.UUID) *pb.Session {
+	return &pb.Session{
+		Id:        session.ID.String(),
+		UserAgent: session.UserAgent,
+		ClientIp:  session.ClientIp,
+		CreatedAt: timestamppb.New(session.CreatedAt),
+		ExpiredAt: timestamppb.New(session.ExpiredAt),
+		Current:   session.ID == currentID,
+	}
+}
+
+// ConvertEntry needs the currency of the account of the entry to format the amounts
+func ConvertEntry(entry db.Entry, currency string) *pb.Entry {
+	return &pb.Entry{
+		Id:                    entry.ID,
+		AccountId:             entry.AccountID,
+		Amount:                entry.Amount,
+		CreatedAt:             timestamppb.New(entry.CreatedAt),
+		BalanceAfter:          entry.BalanceAfter,
+		AmountFormatted:       util.FormatCurrencyAmount(entry.Amount, currency),
+		BalanceAfterFormatted: util.FormatCurrencyAmount(entry.BalanceAfter, currency),
+	}
+}
+
+// ConvertTransfer needs the currencies of both accounts to format the amounts
+func ConvertTransfer(transfer db.Transfer, fromCurrency, toCurrency string) *pb.Transfer {
+	return &pb.Transfer{
+		Id:                transfer.ID,
+		FromAccountId:     transfer.FromAccountID,
+		ToAccountId:       transfer.ToAccountID,
+		Amount:            transfer.Amount,
+		CreatedAt:         timestamppb.New(transfer.CreatedAt),
+		ToAmount:          transfer.ToAmount,
+		FxRate:            transfer.FxRate.String,
+		FxSpreadBps:       transfer.FxSpreadBps.Int32,
+		ReversalOf:        transfer.ReversalOf.Int64,
+		ReversedAmount:    transfer.ReversedAmount,
+		AmountFormatted:   util.FormatCurrencyAmount(transfer.Amount, fromCurrency),
+		ToAmountFormatted: util.FormatCurrencyAmount(transfer.ToAmount, toCurrency),
+		Memo:              transfer.Memo,
+		ClientReference:   transfer.ClientReference.String,
+		Metadata:          ConvertMetadata(transfer.Metadata),
+	}
+}
+
+// ConvertTransferFee formats the fee in the currency it was paid in, the currency of the paying account
+func ConvertTransferFee(fee *db.TransferFee, currency string) *pb.TransferFee {
+	if fee == nil {
+		return nil
+	}
+	return &pb.TransferFee{
+		ScheduleId: fee.ScheduleID,
+		Amount:     fee.Amount,
+		Transfer:   ConvertTransfer(fee.Transfer, currency, currency),
+		Entry:      ConvertEntry(fee.Entry, currency),
+	}
+}
+
+// ConvertTransferRequest takes the legs of a multi transfer request, they are nil for any other request
+func ConvertTransferRequest(request db.TransferRequest, legs []db.TransferRequestLeg) *pb.TransferRequest {
+	res := &pb.TransferRequest{
+		Id:              request.ID,
+		FromAccountId:   request.FromAccountID,
+		ToAccountId:     request.ToAccountID.Int64,
+		Amount:          request.Amount,
+		RequestedBy:     request.RequestedBy,
+		Status:          request.Status,
+		ExpiresAt:       timestamppb.New(request.ExpiresAt),
+		DecidedBy:       request.DecidedBy.String,
+		Reason:          request.Reason,
+		TransferId:      request.TransferID.Int64,
+		CreatedAt:       timestamppb.New(request.CreatedAt),
+		Memo:            request.Memo,
+		ClientReference: request.ClientReference.String,
+		Metadata:        ConvertMetadata(request.Metadata),
+	}
+	if request.DecidedAt.Valid {
+		res.DecidedAt = timestamppb.New(request.DecidedAt.Time)
+	}
+	for _, leg := range legs {
+		res.Legs = append(res.Legs, &pb.TransferRequestLeg{
+			ToAccountId: leg.ToAccountID,
+			Amount:      leg.Amount,
+			TransferId:  leg.TransferID.Int64,
+		})
+	}
+	return res
+}
+
+// ConvertHistoryEntry formats the amount in the currency of the account the entry belongs to
+func ConvertHistoryEntry(row db.ListAccountHistoryRow, currency string) *pb.HistoryEntry {
+	return &pb.HistoryEntry{
+		EntryId:               row.ID,
+		TransferId:            row.TransferID.Int64,
+		Amount:                row.Amount,
+		AmountFormatted:       util.FormatCurrencyAmount(row.Amount, currency),
+		Direction:             row.Direction(),
+		CounterpartyAccountId: row.CounterpartyAccountID.Int64,
+		CounterpartyOwner:     row.CounterpartyOwner.String,
+		CreatedAt:             timestamppb.New(row.CreatedAt),
+		BalanceAfter:          row.BalanceAfter,
+		BalanceAfterFormatted: util.FormatCurrencyAmount(row.BalanceAfter, currency),
+		Memo:                  row.Memo,
+		ClientReference:       row.ClientReference.String,
+		Metadata:              ConvertMetadata(row.Metadata),
+	}
+}
+
+// ConvertMetadata decodes the JSON object kept as the metadata of a transfer
+func ConvertMetadata(metadata json.RawMessage) *structpb.Struct {
+	if len(metadata) == 0 {
+		return nil
+	}
+	res := &structpb.Struct{}
+	if err := protojson.Unmarshal(metadata, res); err != nil {
+		return nil
+	}
+	return res
+}
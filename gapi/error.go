@@ -1,9 +1,13 @@
 package gapi
 
 import (
+	db "bank/db/sqlc"
+	"errors"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"strconv"
+	"time"
 )
 
 func fieldViolation(field string, err error) *errdetails.BadRequest_FieldViolation {
@@ -27,3 +31,47 @@ func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) er
 func unauthenticatedError(err error) error {
 	return status.Errorf(codes.Unauthenticated, "unauthorized: %s", err)
 }
+
+// transferError maps errors from the money moving transactions to a gRPC status
+func transferError(err error) error {
+	var limitErr *db.TransferLimitError
+	switch {
+	case errors.As(err, &limitErr):
+		return transferLimitError(limitErr)
+	case errors.Is(err, db.ErrInsufficientFunds), errors.Is(err, db.ErrFxRateNotFound),
+		errors.Is(err, db.ErrTransferNotReversible), errors.Is(err, db.ErrAccountFrozen),
+		errors.Is(err, db.ErrAccountClosed), errors.Is(err, db.ErrAccountDormant),
+		errors.Is(err, db.ErrAccountStatusTransition), errors.Is(err, db.ErrInvalidSweepAccount),
+		errors.Is(err, db.ErrPotTransfer):
+		return status.Errorf(codes.FailedPrecondition, "%s ", err.Error())
+	case errors.Is(err, db.ErrIdempotencyKeyReused), errors.Is(err, db.ErrTransferAlreadyReversed),
+		errors.Is(err, db.ErrDuplicateClientReference):
+		return status.Errorf(codes.AlreadyExists, "%s ", err.Error())
+	}
+	return status.Errorf(codes.Internal, "failed to transfer %s ", err.Error())
+}
+
+// transferLimitError carries the limit that was hit and when it resets in the error details
+func transferLimitError(limitErr *db.TransferLimitError) error {
+	metadata := map[string]string{
+		"scope":     limitErr.Scope,
+		"limit":     limitErr.Limit,
+		"max":       strconv.FormatInt(limitErr.Max, 10),
+		"used":      strconv.FormatInt(limitErr.Used, 10),
+		"requested": strconv.FormatInt(limitErr.Requested, 10),
+	}
+	if limitErr.ResetsAt != nil {
+		metadata["resets_at"] = limitErr.ResetsAt.Format(time.RFC3339)
+	}
+
+	statusExhausted := status.New(codes.ResourceExhausted, limitErr.Error())
+	details, err := statusExhausted.WithDetails(&errdetails.ErrorInfo{
+		Reason:   "TRANSFER_LIMIT_EXCEEDED",
+		Metadata: metadata,
+	})
+	if err != nil {
+		return statusExhausted.Err()
+	}
+
+	return details.Err()
+}
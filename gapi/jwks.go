@@ -0,0 +1,28 @@
+package gapi
+
+import (
+	"bank/token"
+	"encoding/json"
+	"net/http"
+)
+
+// JWKSHandler serves the public keys tokens are signed with at /.well-known/jwks.json,
+// so other services can verify them without calling us
+func (server *Server) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodGet && request.Method != http.MethodHead {
+			writer.Header().Set("Allow", "GET, HEAD")
+			http.Error(writer, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		keySet, ok := server.tokenMaker.(token.KeySet)
+		if !ok {
+			http.NotFound(writer, request)
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("Cache-Control", "public, max-age=300")
+		_ = json.NewEncoder(writer).Encode(keySet.JWKS())
+	})
+}
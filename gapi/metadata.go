@@ -1,20 +1,29 @@
 package gapi
 
 import (
+	db "bank/db/sqlc"
+	"bank/val"
 	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"strings"
 )
 
 const (
 	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
 	userAgentHeader            = "user-agent"
 	xForwardedForHeader        = "x-forwarded-for"
+	idempotencyKeyHeader       = "idempotency-key"
+	idempotentReplayedHeader   = "idempotent-replayed"
 )
 
 type Metadata struct {
-	UserAgent string
-	ClientIp  string
+	UserAgent      string
+	ClientIp       string
+	IdempotencyKey string
 }
 
 func (server *Server) extractMetaData(ctx context.Context) *Metadata {
@@ -29,6 +38,9 @@ func (server *Server) extractMetaData(ctx context.Context) *Metadata {
 		if clientIPs := mD.Get(xForwardedForHeader); len(clientIPs) > 0 {
 			metaData.ClientIp = clientIPs[0]
 		}
+		if idempotencyKeys := mD.Get(idempotencyKeyHeader); len(idempotencyKeys) > 0 {
+			metaData.IdempotencyKey = idempotencyKeys[0]
+		}
 	}
 
 	if p, ok := peer.FromContext(ctx); ok {
@@ -37,3 +49,33 @@ func (server *Server) extractMetaData(ctx context.Context) *Metadata {
 
 	return metaData
 }
+
+// HeaderMatcher lets the HTTP gateway forward the idempotency key header as gRPC metadata
+func HeaderMatcher(key string) (string, bool) {
+	if strings.ToLower(key) == idempotencyKeyHeader {
+		return idempotencyKeyHeader, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// idempotencyParams reads the optional idempotency key from the request metadata
+func (server *Server) idempotencyParams(ctx context.Context, username string) (*db.IdempotencyParams, error) {
+	key := server.extractMetaData(ctx).IdempotencyKey
+	if key == "" {
+		return nil, nil
+	}
+	if err := val.ValidateIdempotencyKey(key); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation(idempotencyKeyHeader, err)})
+	}
+
+	return &db.IdempotencyParams{
+		Username: username,
+		Key:      key,
+	}, nil
+}
+
+// setReplayedHeader tells the client the response was saved by an earlier request with the same idempotency key
+func setReplayedHeader(ctx context.Context) {
+	_ = grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedHeader, "true"))
+}
@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/val"
+	"context"
+	"database/sql"
+	"errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AcceptAccountMember makes the authenticated user a member of an account they were invited to
+func (server *Server) AcceptAccountMember(ctx context.Context, req *pb.AcceptAccountMemberRequest) (*pb.AcceptAccountMemberResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAcceptAccountMemberRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	member, err := server.store.AcceptAccountMember(ctx, db.AcceptAccountMemberParams{
+		AccountID: req.GetAccountId(),
+		Username:  payload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "no pending invitation to account [%d]", req.GetAccountId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to accept account member %s ", err.Error())
+	}
+
+	res := &pb.AcceptAccountMemberResponse{
+		Member: ConvertAccountMember(member),
+	}
+
+	return res, nil
+}
+
+func validateAcceptAccountMemberRequest(req *pb.AcceptAccountMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	return violations
+}
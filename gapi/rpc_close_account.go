@@ -0,0 +1,67 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/val"
+	"context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// CloseAccount sweeps the balance of an account to another account the authenticated user owns and closes it
+func (server *Server) CloseAccount(ctx context.Context, req *pb.CloseAccountRequest) (*pb.CloseAccountResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCloseAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.validMemberAccount(ctx, req.GetAccountId(), payload.Username, db.AccountRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if req.SweepAccountId != nil {
+		_, err = server.validMemberAccount(ctx, req.GetSweepAccountId(), payload.Username, db.AccountRoleOwner)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := server.store.CloseAccountTx(ctx, db.CloseAccountTxParams{
+		AccountID:      req.GetAccountId(),
+		SweepAccountID: req.GetSweepAccountId(),
+		Username:       payload.Username,
+	})
+	if err != nil {
+		return nil, transferError(err)
+	}
+
+	res := &pb.CloseAccountResponse{
+		Account:                 ConvertAccount(result.Account),
+		CancelledStandingOrders: int64(len(result.CancelledStandingOrders)),
+	}
+	if result.Sweep != nil {
+		res.SweepTransfer = ConvertTransfer(result.Sweep.Transfer, result.Sweep.FromAccount.Currency, result.Sweep.ToAccount.Currency)
+		res.SweepAccount = ConvertAccount(result.Sweep.ToAccount)
+		res.SweepEntry = ConvertEntry(result.Sweep.ToEntry, result.Sweep.ToAccount.Currency)
+	}
+
+	return res, nil
+}
+
+func validateCloseAccountRequest(req *pb.CloseAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if req.SweepAccountId != nil {
+		if err := val.ValidateID(req.GetSweepAccountId()); err != nil {
+			violations = append(violations, fieldViolation("sweep_account_id", err))
+		}
+	}
+
+	return violations
+}
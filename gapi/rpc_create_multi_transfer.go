@@ -0,0 +1,146 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/val"
+	"context"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"math"
+	"time"
+)
+
+// maxTransferLegs caps how many accounts a multi transfer can pay at once
+const maxTransferLegs = 100
+
+func (server *Server) CreateMultiTransfer(ctx context.Context, req *pb.CreateMultiTransferRequest) (*pb.CreateMultiTransferResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateMultiTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+	if err = server.validAccountRole(ctx, fromAccount, payload.Username, db.AccountRoleCanTransfer); err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, leg := range req.GetLegs() {
+		if leg.GetAmount() > math.MaxInt64-total {
+			total = math.MaxInt64
+			break
+		}
+		total += leg.GetAmount()
+	}
+	legs := make([]db.TransferLeg, len(req.GetLegs()))
+	checked := make(map[int64]bool)
+	for i, leg := range req.GetLegs() {
+		if !checked[leg.GetToAccountId()] {
+			_, err = server.validAccount(ctx, leg.GetToAccountId(), req.GetCurrency())
+			if err != nil {
+				return nil, err
+			}
+			checked[leg.GetToAccountId()] = true
+		}
+		legs[i] = db.TransferLeg{
+			ToAccountID: leg.GetToAccountId(),
+			Amount:      leg.GetAmount(),
+		}
+	}
+
+	idempotency, err := server.idempotencyParams(ctx, payload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	// a large payment waits for a second user to approve it
+	if server.config.RequiresApproval(total, req.GetCurrency()) {
+		request, err := server.store.CreateTransferRequestTx(ctx, db.CreateTransferRequestTxParams{
+			FromAccountID: req.GetFromAccountId(),
+			RequestedBy:   payload.Username,
+			Legs:          legs,
+			ExpiresAt:     time.Now().Add(server.config.TransferApprovalTTL),
+			Idempotency:   idempotency,
+		})
+		if err != nil {
+			return nil, transferError(err)
+		}
+		if request.Replayed {
+			setReplayedHeader(ctx)
+		}
+		return &pb.CreateMultiTransferResponse{
+			TransferRequest: ConvertTransferRequest(request.TransferRequest, request.Legs),
+		}, nil
+	}
+
+	result, err := server.store.MultiTransferTx(ctx, db.MultiTransferTxParams{
+		FromAccountID: req.GetFromAccountId(),
+		Legs:          legs,
+		Idempotency:   idempotency,
+		InitiatedBy:   payload.Username,
+	})
+	if err != nil {
+		return nil, transferError(err)
+	}
+	if result.Replayed {
+		setReplayedHeader(ctx)
+	}
+
+	res := &pb.CreateMultiTransferResponse{
+		FromAccount: ConvertAccount(result.FromAccount),
+		Legs:        convertTransferLegResults(&result),
+	}
+
+	return res, nil
+}
+
+func convertTransferLegResults(result *db.MultiTransferTxResult) []*pb.TransferLegResult {
+	legs := make([]*pb.TransferLegResult, len(result.Legs))
+	for i, leg := range result.Legs {
+		legs[i] = &pb.TransferLegResult{
+			Transfer:  ConvertTransfer(leg.Transfer, result.FromAccount.Currency, leg.ToAccount.Currency),
+			ToAccount: ConvertAccount(leg.ToAccount),
+			FromEntry: ConvertEntry(leg.FromEntry, result.FromAccount.Currency),
+			ToEntry:   ConvertEntry(leg.ToEntry, leg.ToAccount.Currency),
+			Fee:       ConvertTransferFee(leg.Fee, result.FromAccount.Currency),
+		}
+	}
+	return legs
+}
+
+func validateCreateMultiTransferRequest(req *pb.CreateMultiTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := val.ValidateID(req.GetFromAccountId())
+	if err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+	err = val.ValidateCurrency(req.GetCurrency())
+	if err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+	if n := len(req.GetLegs()); n < 1 || n > maxTransferLegs {
+		violations = append(violations, fieldViolation("legs", fmt.Errorf("must have between 1 and %d legs", maxTransferLegs)))
+	}
+	for i, leg := range req.GetLegs() {
+		err = val.ValidateID(leg.GetToAccountId())
+		if err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("legs[%d].to_account_id", i), err))
+		} else if leg.GetToAccountId() == req.GetFromAccountId() {
+			violations = append(violations, fieldViolation(fmt.Sprintf("legs[%d].to_account_id", i), fmt.Errorf("must not be the from account")))
+		}
+		err = val.ValidateAmount(leg.GetAmount())
+		if err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("legs[%d].amount", i), err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,199 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/val"
+	"context"
+	"database/sql"
+	"errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+	if err = server.validAccountRole(ctx, fromAccount, payload.Username, db.AccountRoleCanTransfer); err != nil {
+		return nil, err
+	}
+	toCurrency := req.GetCurrency()
+	if req.ToCurrency != nil {
+		toCurrency = req.GetToCurrency()
+	}
+	_, err = server.validAccount(ctx, req.GetToAccountId(), toCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := transferDetails(req)
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("metadata", err)})
+	}
+
+	idempotency, err := server.idempotencyParams(ctx, payload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if server.config.RequiresApproval(req.GetAmount(), fromAccount.Currency) {
+		return server.createTransferRequest(ctx, req, details, payload.Username, idempotency)
+	}
+
+	var result db.TransferTxResult
+	if toCurrency != req.GetCurrency() {
+		exchangeResult, err := server.store.ExchangeTransferTx(ctx, db.ExchangeTransferTxParams{
+			FromAccountID:   req.GetFromAccountId(),
+			ToAccountID:     req.GetToAccountId(),
+			Amount:          req.GetAmount(),
+			TransferDetails: details,
+			Idempotency:     idempotency,
+			InitiatedBy:     payload.Username,
+		})
+		if err != nil {
+			return nil, transferError(err)
+		}
+		result = exchangeResult.TransferTxResult
+	} else {
+		result, err = server.store.TransferTx(ctx, db.TransferTxParams{
+			FromAccountID:   req.GetFromAccountId(),
+			ToAccountID:     req.GetToAccountId(),
+			Amount:          req.GetAmount(),
+			TransferDetails: details,
+			Idempotency:     idempotency,
+			InitiatedBy:     payload.Username,
+		})
+		if err != nil {
+			return nil, transferError(err)
+		}
+	}
+	if result.Replayed {
+		setReplayedHeader(ctx)
+	}
+
+	res := &pb.CreateTransferResponse{
+		Transfer:    ConvertTransfer(result.Transfer, result.FromAccount.Currency, result.ToAccount.Currency),
+		FromAccount: ConvertAccount(result.FromAccount),
+		ToAccount:   ConvertAccount(result.ToAccount),
+		FromEntry:   ConvertEntry(result.FromEntry, result.FromAccount.Currency),
+		ToEntry:     ConvertEntry(result.ToEntry, result.ToAccount.Currency),
+		Fee:         ConvertTransferFee(result.Fee, result.FromAccount.Currency),
+	}
+
+	return res, nil
+}
+
+// transferDetails reads the details the sender attached to the transfer, the metadata is kept as a JSON object
+func transferDetails(req *pb.CreateTransferRequest) (db.TransferDetails, error) {
+	details := db.TransferDetails{
+		Memo:            req.GetMemo(),
+		ClientReference: req.GetClientReference(),
+	}
+	if req.Metadata == nil {
+		return details, nil
+	}
+
+	metadata, err := protojson.Marshal(req.GetMetadata())
+	if err != nil {
+		return details, err
+	}
+	if err = val.ValidateTransferMetadata(metadata); err != nil {
+		return details, err
+	}
+	details.Metadata = metadata
+	return details, nil
+}
+
+func (server *Server) validAccount(ctx context.Context, accountID int64, currency string) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account %s ", err.Error())
+	}
+
+	if account.Currency != currency {
+		return account, status.Errorf(codes.InvalidArgument, "account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+	}
+
+	return account, nil
+}
+
+// validMemberAccount loads an account and makes sure username has at least the given role on it
+func (server *Server) validMemberAccount(ctx context.Context, accountID int64, username string, role string) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account %s ", err.Error())
+	}
+
+	return account, server.validAccountRole(ctx, account, username, role)
+}
+
+// validAccountRole makes sure username is a member of the account whose role has the rights of the given role
+func (server *Server) validAccountRole(ctx context.Context, account db.Account, username string, role string) error {
+	memberRole, err := db.AccountRole(ctx, server.store, account, username)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get account member %s ", err.Error())
+	}
+	if memberRole == "" {
+		return status.Errorf(codes.PermissionDenied, "account [%d] doesn't belong to the authenticated user", account.ID)
+	}
+	if !db.AccountRoleGrants(memberRole, role) {
+		return status.Errorf(codes.PermissionDenied, "a %s member of account [%d] can't do this", memberRole, account.ID)
+	}
+
+	return nil
+}
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := val.ValidateID(req.GetFromAccountId())
+	if err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+	err = val.ValidateID(req.GetToAccountId())
+	if err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+	err = val.ValidateAmount(req.GetAmount())
+	if err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+	err = val.ValidateCurrency(req.GetCurrency())
+	if err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+	if req.ToCurrency != nil {
+		err = val.ValidateCurrency(req.GetToCurrency())
+		if err != nil {
+			violations = append(violations, fieldViolation("to_currency", err))
+		}
+	}
+	err = val.ValidateMemo(req.GetMemo())
+	if err != nil {
+		violations = append(violations, fieldViolation("memo", err))
+	}
+	err = val.ValidateClientReference(req.GetClientReference())
+	if err != nil {
+		violations = append(violations, fieldViolation("client_reference", err))
+	}
+
+	return violations
+}
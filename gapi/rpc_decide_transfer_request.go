@@ -0,0 +1,134 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/val"
+	"context"
+	"database/sql"
+	"errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// createTransferRequest holds a transfer above the approval threshold until a second user decides on it
+func (server *Server) createTransferRequest(
+	ctx context.Context,
+	req *pb.CreateTransferRequest,
+	details db.TransferDetails,
+	username string,
+	idempotency *db.IdempotencyParams,
+) (*pb.CreateTransferResponse, error) {
+	result, err := server.store.CreateTransferRequestTx(ctx, db.CreateTransferRequestTxParams{
+		FromAccountID:   req.GetFromAccountId(),
+		ToAccountID:     req.GetToAccountId(),
+		Amount:          req.GetAmount(),
+		RequestedBy:     username,
+		TransferDetails: details,
+		ExpiresAt:       time.Now().Add(server.config.TransferApprovalTTL),
+		Idempotency:     idempotency,
+	})
+	if err != nil {
+		return nil, transferError(err)
+	}
+	if result.Replayed {
+		setReplayedHeader(ctx)
+	}
+
+	res := &pb.CreateTransferResponse{
+		TransferRequest: ConvertTransferRequest(result.TransferRequest, nil),
+	}
+
+	return res, nil
+}
+
+// ApproveTransferRequest makes a transfer that is waiting for approval, an officer or an owner of the
+// from account other than the requester can approve it
+func (server *Server) ApproveTransferRequest(ctx context.Context, req *pb.ApproveTransferRequestRequest) (*pb.ApproveTransferRequestResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err = val.ValidateID(req.GetTransferRequestId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("transfer_request_id", err)})
+	}
+
+	result, err := server.store.ApproveTransferRequestTx(ctx, db.ApproveTransferRequestTxParams{
+		RequestID:  req.GetTransferRequestId(),
+		ApprovedBy: payload.Username,
+	})
+	if err != nil {
+		return nil, transferRequestError(err)
+	}
+
+	res := &pb.ApproveTransferRequestResponse{
+		TransferRequest: ConvertTransferRequest(result.TransferRequest, result.Legs),
+	}
+	if result.Transfer != nil {
+		res.Transfer = ConvertTransfer(result.Transfer.Transfer, result.Transfer.FromAccount.Currency, result.Transfer.ToAccount.Currency)
+		res.FromAccount = ConvertAccount(result.Transfer.FromAccount)
+		res.ToAccount = ConvertAccount(result.Transfer.ToAccount)
+		res.FromEntry = ConvertEntry(result.Transfer.FromEntry, result.Transfer.FromAccount.Currency)
+		res.ToEntry = ConvertEntry(result.Transfer.ToEntry, result.Transfer.ToAccount.Currency)
+	}
+	if result.MultiTransfer != nil {
+		res.FromAccount = ConvertAccount(result.MultiTransfer.FromAccount)
+		res.Legs = convertTransferLegResults(result.MultiTransfer)
+	}
+
+	return res, nil
+}
+
+// RejectTransferRequest closes a transfer request without moving money
+func (server *Server) RejectTransferRequest(ctx context.Context, req *pb.RejectTransferRequestRequest) (*pb.RejectTransferRequestResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRejectTransferRequestRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	request, err := server.store.RejectTransferRequestTx(ctx, db.RejectTransferRequestTxParams{
+		RequestID:  req.GetTransferRequestId(),
+		RejectedBy: payload.Username,
+		Reason:     req.GetReason(),
+	})
+	if err != nil {
+		return nil, transferRequestError(err)
+	}
+
+	res := &pb.RejectTransferRequestResponse{
+		TransferRequest: ConvertTransferRequest(request, nil),
+	}
+
+	return res, nil
+}
+
+func validateRejectTransferRequestRequest(req *pb.RejectTransferRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetTransferRequestId()); err != nil {
+		violations = append(violations, fieldViolation("transfer_request_id", err))
+	}
+	if err := val.ValidateString(req.GetReason(), 0, 255); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+
+	return violations
+}
+
+func transferRequestError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Errorf(codes.NotFound, "transfer request not found")
+	case errors.Is(err, db.ErrTransferRequestNotPending):
+		return status.Errorf(codes.FailedPrecondition, "%s ", err.Error())
+	case errors.Is(err, db.ErrTransferRequestSelfDecision), errors.Is(err, db.ErrTransferRequestDecider):
+		return status.Errorf(codes.PermissionDenied, "%s ", err.Error())
+	}
+	return transferError(err)
+}
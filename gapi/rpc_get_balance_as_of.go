@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/util"
+	"bank/val"
+	"context"
+	"errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetBalanceAsOf returns the balance an account of the authenticated user had at a point in time
+func (server *Server) GetBalanceAsOf(ctx context.Context, req *pb.GetBalanceAsOfRequest) (*pb.GetBalanceAsOfResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetBalanceAsOfRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validMemberAccount(ctx, req.GetAccountId(), payload.Username, db.AccountRoleViewOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	asOf := req.GetAsOf().AsTime()
+	balance, err := server.store.GetBalanceAsOf(ctx, db.GetBalanceAsOfParams{
+		AccountID: account.ID,
+		AsOf:      asOf,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get balance: %s", err)
+	}
+
+	res := &pb.GetBalanceAsOfResponse{
+		AccountId:        account.ID,
+		Currency:         account.Currency,
+		AsOf:             timestamppb.New(asOf),
+		Balance:          balance,
+		BalanceFormatted: util.FormatCurrencyAmount(balance, account.Currency),
+	}
+
+	return res, nil
+}
+
+func validateGetBalanceAsOfRequest(req *pb.GetBalanceAsOfRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if req.AsOf == nil {
+		violations = append(violations, fieldViolation("as_of", errors.New("must be set")))
+	}
+
+	return violations
+}
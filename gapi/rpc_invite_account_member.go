@@ -0,0 +1,79 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/val"
+	"context"
+	"errors"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InviteAccountMember invites a user to share a joint account, only its owners can do it
+func (server *Server) InviteAccountMember(ctx context.Context, req *pb.InviteAccountMemberRequest) (*pb.InviteAccountMemberResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateInviteAccountMemberRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validMemberAccount(ctx, req.GetAccountId(), payload.Username, db.AccountRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !account.Joint {
+		return nil, status.Errorf(codes.FailedPrecondition, "account [%d] is not a joint account", account.ID)
+	}
+	if account.Status == db.AccountClosed {
+		return nil, status.Errorf(codes.FailedPrecondition, "account [%d] is closed", account.ID)
+	}
+	if req.GetUsername() == account.Owner {
+		return nil, status.Errorf(codes.AlreadyExists, "%s already holds account [%d]", req.GetUsername(), account.ID)
+	}
+
+	member, err := server.store.CreateAccountMember(ctx, db.CreateAccountMemberParams{
+		AccountID: account.ID,
+		Username:  req.GetUsername(),
+		Role:      req.GetRole(),
+		InvitedBy: payload.Username,
+	})
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code.Name() {
+			case "unique_violation":
+				return nil, status.Errorf(codes.AlreadyExists, "%s is already a member of account [%d]", req.GetUsername(), account.ID)
+			case "foreign_key_violation":
+				return nil, status.Errorf(codes.NotFound, "user %s not found", req.GetUsername())
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "failed to invite account member %s ", err.Error())
+	}
+
+	res := &pb.InviteAccountMemberResponse{
+		Member: ConvertAccountMember(member),
+	}
+
+	return res, nil
+}
+
+func validateInviteAccountMemberRequest(req *pb.InviteAccountMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+	if err := val.ValidateAccountRole(req.GetRole()); err != nil {
+		violations = append(violations, fieldViolation("role", err))
+	}
+
+	return violations
+}
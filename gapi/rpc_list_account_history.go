@@ -0,0 +1,98 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/util"
+	"bank/val"
+	"context"
+	"database/sql"
+	"errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListAccountHistory returns a page of the transactions of an account of the authenticated user, newest first
+func (server *Server) ListAccountHistory(ctx context.Context, req *pb.ListAccountHistoryRequest) (*pb.ListAccountHistoryResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListAccountHistoryRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validMemberAccount(ctx, req.GetAccountId(), payload.Username, db.AccountRoleViewOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeID, _ := util.DecodePageToken(req.GetPageToken())
+	arg := db.ListAccountHistoryParams{
+		AccountID:             account.ID,
+		BeforeID:              sql.NullInt64{Int64: beforeID, Valid: beforeID > 0},
+		FromTime:              sql.NullTime{Time: req.GetFromTime().AsTime(), Valid: req.FromTime != nil},
+		ToTime:                sql.NullTime{Time: req.GetToTime().AsTime(), Valid: req.ToTime != nil},
+		MinAmount:             sql.NullInt64{Int64: req.GetMinAmount(), Valid: req.MinAmount != nil},
+		MaxAmount:             sql.NullInt64{Int64: req.GetMaxAmount(), Valid: req.MaxAmount != nil},
+		Direction:             sql.NullString{String: req.GetDirection(), Valid: req.GetDirection() != ""},
+		CounterpartyAccountID: sql.NullInt64{Int64: req.GetCounterpartyAccountId(), Valid: req.CounterpartyAccountId != nil},
+		ClientReference:       sql.NullString{String: req.GetClientReference(), Valid: req.GetClientReference() != ""},
+		LimitCount:            req.GetPageSize() + 1,
+	}
+	rows, err := server.store.ListAccountHistory(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list account history: %s", err)
+	}
+
+	res := &pb.ListAccountHistoryResponse{}
+	rows, res.NextPageToken = util.NextPage(rows, req.GetPageSize(), func(row db.ListAccountHistoryRow) int64 { return row.ID })
+	for _, row := range rows {
+		res.Entries = append(res.Entries, ConvertHistoryEntry(row, account.Currency))
+	}
+
+	return res, nil
+}
+
+func validateListAccountHistoryRequest(req *pb.ListAccountHistoryRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	if err := val.ValidatePageToken(req.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolation("page_token", err))
+	}
+	if req.FromTime != nil && req.ToTime != nil && req.GetToTime().AsTime().Before(req.GetFromTime().AsTime()) {
+		violations = append(violations, fieldViolation("to_time", errors.New("must not be before from_time")))
+	}
+	if req.MinAmount != nil {
+		if err := val.ValidateAmount(req.GetMinAmount()); err != nil {
+			violations = append(violations, fieldViolation("min_amount", err))
+		}
+	}
+	if req.MaxAmount != nil {
+		if err := val.ValidateAmount(req.GetMaxAmount()); err != nil {
+			violations = append(violations, fieldViolation("max_amount", err))
+		} else if req.MinAmount != nil && req.GetMaxAmount() < req.GetMinAmount() {
+			violations = append(violations, fieldViolation("max_amount", errors.New("must not be less than min_amount")))
+		}
+	}
+	if err := val.ValidateHistoryDirection(req.GetDirection()); err != nil {
+		violations = append(violations, fieldViolation("direction", err))
+	}
+	if req.CounterpartyAccountId != nil {
+		if err := val.ValidateID(req.GetCounterpartyAccountId()); err != nil {
+			violations = append(violations, fieldViolation("counterparty_account_id", err))
+		}
+	}
+	if err := val.ValidateClientReference(req.GetClientReference()); err != nil {
+		violations = append(violations, fieldViolation("client_reference", err))
+	}
+
+	return violations
+}
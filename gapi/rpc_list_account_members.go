@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/val"
+	"context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListAccountMembers shows who an account of the authenticated user is shared with, invitations included
+func (server *Server) ListAccountMembers(ctx context.Context, req *pb.ListAccountMembersRequest) (*pb.ListAccountMembersResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListAccountMembersRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validMemberAccount(ctx, req.GetAccountId(), payload.Username, db.AccountRoleViewOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := server.store.ListAccountMembers(ctx, account.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list account members: %s", err)
+	}
+
+	res := &pb.ListAccountMembersResponse{
+		Holder: account.Owner,
+	}
+	for _, member := range members {
+		res.Members = append(res.Members, ConvertAccountMember(member))
+	}
+
+	return res, nil
+}
+
+// ListAccountInvitations shows the invitations the authenticated user hasn't accepted yet
+func (server *Server) ListAccountInvitations(ctx context.Context, req *pb.ListAccountInvitationsRequest) (*pb.ListAccountInvitationsResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	invitations, err := server.store.ListAccountInvitations(ctx, payload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list account invitations: %s", err)
+	}
+
+	res := &pb.ListAccountInvitationsResponse{}
+	for _, invitation := range invitations {
+		res.Invitations = append(res.Invitations, ConvertAccountMember(invitation))
+	}
+
+	return res, nil
+}
+
+func validateListAccountMembersRequest(req *pb.ListAccountMembersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	return violations
+}
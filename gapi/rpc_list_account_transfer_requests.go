@@ -0,0 +1,72 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/util"
+	"bank/val"
+	"context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// ListAccountTransferRequests returns a page of the requests waiting for a decision on transfers from an account,
+// oldest first. Every owner of the account can see them, so a co-owner knows there is a request to decide on.
+func (server *Server) ListAccountTransferRequests(ctx context.Context, req *pb.ListAccountTransferRequestsRequest) (*pb.ListAccountTransferRequestsResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListAccountTransferRequestsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validMemberAccount(ctx, req.GetAccountId(), payload.Username, db.AccountRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	afterID, _ := util.DecodePageToken(req.GetPageToken())
+	requests, err := server.store.ListAccountPendingTransferRequestsAfter(ctx, db.ListAccountPendingTransferRequestsAfterParams{
+		FromAccountID: account.ID,
+		Now:           time.Now(),
+		AfterID:       afterID,
+		LimitCount:    req.GetPageSize() + 1,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfer requests: %s", err)
+	}
+
+	res := &pb.ListAccountTransferRequestsResponse{}
+	requests, res.NextPageToken = util.NextPage(requests, req.GetPageSize(), func(request db.TransferRequest) int64 { return request.ID })
+	for _, request := range requests {
+		var legs []db.TransferRequestLeg
+		if !request.ToAccountID.Valid {
+			legs, err = server.store.ListTransferRequestLegs(ctx, request.ID)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to list transfer request legs: %s", err)
+			}
+		}
+		res.TransferRequests = append(res.TransferRequests, ConvertTransferRequest(request, legs))
+	}
+
+	return res, nil
+}
+
+func validateListAccountTransferRequestsRequest(req *pb.ListAccountTransferRequestsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	if err := val.ValidatePageToken(req.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolation("page_token", err))
+	}
+
+	return violations
+}
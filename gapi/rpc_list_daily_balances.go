@@ -0,0 +1,86 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/util"
+	"bank/val"
+	"context"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// maxBalanceSeriesDays is the longest daily balance series a single request can ask for
+const maxBalanceSeriesDays = 366
+
+// ListDailyBalances returns the end of day balances of an account of the authenticated user
+func (server *Server) ListDailyBalances(ctx context.Context, req *pb.ListDailyBalancesRequest) (*pb.ListDailyBalancesResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListDailyBalancesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validMemberAccount(ctx, req.GetAccountId(), payload.Username, db.AccountRoleViewOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	// the dates were checked by the validation
+	from, _ := time.Parse(time.DateOnly, req.GetFromDate())
+	to, _ := time.Parse(time.DateOnly, req.GetToDate())
+	rows, err := server.store.ListDailyBalances(ctx, db.ListDailyBalancesParams{
+		AccountID: account.ID,
+		FromDay:   from,
+		ToDay:     to,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list daily balances: %s", err)
+	}
+
+	res := &pb.ListDailyBalancesResponse{
+		AccountId: account.ID,
+		Currency:  account.Currency,
+	}
+	for _, row := range rows {
+		res.Balances = append(res.Balances, &pb.DailyBalance{
+			Date:             row.Day.Format(time.DateOnly),
+			Balance:          row.Balance,
+			BalanceFormatted: util.FormatCurrencyAmount(row.Balance, account.Currency),
+		})
+	}
+
+	return res, nil
+}
+
+func validateListDailyBalancesRequest(req *pb.ListDailyBalancesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	from, err := time.Parse(time.DateOnly, req.GetFromDate())
+	if err != nil {
+		violations = append(violations, fieldViolation("from_date", fmt.Errorf("must be a date like 2006-01-02")))
+	}
+	to, err := time.Parse(time.DateOnly, req.GetToDate())
+	if err != nil {
+		violations = append(violations, fieldViolation("to_date", fmt.Errorf("must be a date like 2006-01-02")))
+	}
+	if violations != nil {
+		return violations
+	}
+
+	if to.Before(from) {
+		violations = append(violations, fieldViolation("to_date", fmt.Errorf("must not be before from_date")))
+	} else if to.Sub(from) >= maxBalanceSeriesDays*24*time.Hour {
+		violations = append(violations, fieldViolation("to_date", fmt.Errorf("the series can't cover more than %d days", maxBalanceSeriesDays)))
+	}
+
+	return violations
+}
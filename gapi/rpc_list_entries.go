@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/util"
+	"bank/val"
+	"context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListEntries returns a page of the ledger entries of an account of the authenticated user, oldest first
+func (server *Server) ListEntries(ctx context.Context, req *pb.ListEntriesRequest) (*pb.ListEntriesResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListEntriesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validMemberAccount(ctx, req.GetAccountId(), payload.Username, db.AccountRoleViewOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	afterID, _ := util.DecodePageToken(req.GetPageToken())
+	entries, err := server.store.ListEntriesAfter(ctx, db.ListEntriesAfterParams{
+		AccountID:  account.ID,
+		AfterID:    afterID,
+		LimitCount: req.GetPageSize() + 1,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list entries: %s", err)
+	}
+
+	res := &pb.ListEntriesResponse{}
+	entries, res.NextPageToken = util.NextPage(entries, req.GetPageSize(), func(entry db.Entry) int64 { return entry.ID })
+	for _, entry := range entries {
+		res.Entries = append(res.Entries, ConvertEntry(entry, account.Currency))
+	}
+
+	return res, nil
+}
+
+func validateListEntriesRequest(req *pb.ListEntriesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	if err := val.ValidatePageToken(req.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolation("page_token", err))
+	}
+
+	return violations
+}
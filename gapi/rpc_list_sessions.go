@@ -0,0 +1,28 @@
+package gapi
+
+import (
+	"bank/pb"
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListSessions shows the sessions of the authenticated user that are neither revoked nor expired, newest first
+func (server *Server) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	sessions, err := server.store.ListActiveSessions(ctx, payload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list sessions: %s", err)
+	}
+
+	res := &pb.ListSessionsResponse{}
+	for _, session := range sessions {
+		res.Sessions = append(res.Sessions, ConvertSession(session, payload.SessionID))
+	}
+
+	return res, nil
+}
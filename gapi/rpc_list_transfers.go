@@ -0,0 +1,88 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/util"
+	"bank/val"
+	"context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListTransfers returns a page of the transfers into or out of an account of the authenticated user, oldest first
+func (server *Server) ListTransfers(ctx context.Context, req *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListTransfersRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validMemberAccount(ctx, req.GetAccountId(), payload.Username, db.AccountRoleViewOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	afterID, _ := util.DecodePageToken(req.GetPageToken())
+	transfers, err := server.store.ListTransfersAfter(ctx, db.ListTransfersAfterParams{
+		AccountID:  account.ID,
+		AfterID:    afterID,
+		LimitCount: req.GetPageSize() + 1,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfers: %s", err)
+	}
+
+	res := &pb.ListTransfersResponse{}
+	transfers, res.NextPageToken = util.NextPage(transfers, req.GetPageSize(), func(transfer db.Transfer) int64 { return transfer.ID })
+	// counterparties of exchange transfers hold another currency, look each one up once
+	currencies := map[int64]string{account.ID: account.Currency}
+	for _, transfer := range transfers {
+		fromCurrency, toCurrency := account.Currency, account.Currency
+		if transfer.FxRate.Valid {
+			fromCurrency, err = server.accountCurrency(ctx, transfer.FromAccountID, currencies)
+			if err != nil {
+				return nil, err
+			}
+			toCurrency, err = server.accountCurrency(ctx, transfer.ToAccountID, currencies)
+			if err != nil {
+				return nil, err
+			}
+		}
+		res.Transfers = append(res.Transfers, ConvertTransfer(transfer, fromCurrency, toCurrency))
+	}
+
+	return res, nil
+}
+
+// accountCurrency returns the currency of an account, reading it from the store the first time
+func (server *Server) accountCurrency(ctx context.Context, accountID int64, currencies map[int64]string) (string, error) {
+	if currency, ok := currencies[accountID]; ok {
+		return currency, nil
+	}
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+	currencies[accountID] = account.Currency
+	return account.Currency, nil
+}
+
+func validateListTransfersRequest(req *pb.ListTransfersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	if err := val.ValidatePageToken(req.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolation("page_token", err))
+	}
+
+	return violations
+}
@@ -3,11 +3,13 @@ package gapi
 import (
 	db "bank/db/sqlc"
 	"bank/pb"
+	"bank/token"
 	"bank/util"
 	"bank/val"
 	"context"
 	"database/sql"
 	"errors"
+	"github.com/google/uuid"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -32,19 +34,25 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 		return nil, status.Errorf(codes.Unauthenticated, "invalid password %s ", err.Error())
 	}
 
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.AccessTokenDuration)
+	// both tokens carry the session so they stop working once it is revoked
+	sessionID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create session id %s ", err.Error())
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, sessionID, token.TokenTypeAccess, server.config.AccessTokenDuration)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create access token %s ", err.Error())
 	}
 
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.RefreshTokenDuration)
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, sessionID, token.TokenTypeRefresh, server.config.RefreshTokenDuration)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create refresh token %s ", err.Error())
 	}
 
 	metaData := server.extractMetaData(ctx)
 	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
-		ID:           refreshPayload.ID,
+		ID:           sessionID,
 		Username:     user.Username,
 		RefreshToken: refreshToken,
 		UserAgent:    metaData.UserAgent,
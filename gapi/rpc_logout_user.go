@@ -0,0 +1,40 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LogoutUser revokes the session the access token belongs to
+func (server *Server) LogoutUser(ctx context.Context, req *pb.LogoutUserRequest) (*pb.LogoutUserResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+	if payload.SessionID == uuid.Nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the access token doesn't belong to a session, log in again to get one that does")
+	}
+
+	session, err := server.store.BlockSession(ctx, db.BlockSessionParams{
+		ID:       payload.SessionID,
+		Username: payload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "session %s not found", payload.SessionID)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke session %s ", err.Error())
+	}
+
+	res := &pb.LogoutUserResponse{
+		Session: ConvertSession(session, payload.SessionID),
+	}
+
+	return res, nil
+}
@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/val"
+	"context"
+	"database/sql"
+	"errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RemoveAccountMember takes a user off an account. Owners can remove any member,
+// the other members can only remove themselves, which is also how an invitation is declined.
+func (server *Server) RemoveAccountMember(ctx context.Context, req *pb.RemoveAccountMemberRequest) (*pb.RemoveAccountMemberResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRemoveAccountMemberRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "account [%d] not found", req.GetAccountId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get account %s ", err.Error())
+	}
+	if req.GetUsername() == account.Owner {
+		return nil, status.Errorf(codes.PermissionDenied, "the holder of account [%d] can't be removed", account.ID)
+	}
+	if req.GetUsername() != payload.Username {
+		if err = server.validAccountRole(ctx, account, payload.Username, db.AccountRoleOwner); err != nil {
+			return nil, err
+		}
+	}
+
+	member, err := server.store.DeleteAccountMember(ctx, db.DeleteAccountMemberParams{
+		AccountID: account.ID,
+		Username:  req.GetUsername(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "%s is not a member of account [%d]", req.GetUsername(), account.ID)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to remove account member %s ", err.Error())
+	}
+
+	res := &pb.RemoveAccountMemberResponse{
+		Member: ConvertAccountMember(member),
+	}
+
+	return res, nil
+}
+
+func validateRemoveAccountMemberRequest(req *pb.RemoveAccountMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,95 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/val"
+	"context"
+	"database/sql"
+	"errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReverseTransfer sends a transfer back to its sender. Only the owner of the account that received
+// the money can reverse it.
+func (server *Server) ReverseTransfer(ctx context.Context, req *pb.ReverseTransferRequest) (*pb.ReverseTransferResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateReverseTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	transfer, err := server.store.GetTransfer(ctx, req.GetTransferId())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "transfer [%d] not found", req.GetTransferId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get transfer %s ", err.Error())
+	}
+
+	toAccount, err := server.store.GetAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get account %s ", err.Error())
+	}
+	// only the receiving side can send the money back
+	if err = server.validAccountRole(ctx, toAccount, payload.Username, db.AccountRoleCanTransfer); err != nil {
+		return nil, err
+	}
+
+	amount := transfer.Amount
+	if req.Amount != nil {
+		amount = req.GetAmount()
+	}
+	if amount > transfer.Amount {
+		return nil, status.Errorf(codes.InvalidArgument, "amount %d is more than the transfer amount %d", amount, transfer.Amount)
+	}
+
+	idempotency, err := server.idempotencyParams(ctx, payload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.ReverseTransferTx(ctx, db.ReverseTransferTxParams{
+		TransferID:  transfer.ID,
+		Amount:      amount,
+		Idempotency: idempotency,
+	})
+	if err != nil {
+		return nil, transferError(err)
+	}
+	if result.Replayed {
+		setReplayedHeader(ctx)
+	}
+
+	res := &pb.ReverseTransferResponse{
+		Transfer:         ConvertTransfer(result.Transfer, result.FromAccount.Currency, result.ToAccount.Currency),
+		OriginalTransfer: ConvertTransfer(result.OriginalTransfer, result.ToAccount.Currency, result.FromAccount.Currency),
+		FromAccount:      ConvertAccount(result.FromAccount),
+		ToAccount:        ConvertAccount(result.ToAccount),
+		FromEntry:        ConvertEntry(result.FromEntry, result.FromAccount.Currency),
+		ToEntry:          ConvertEntry(result.ToEntry, result.ToAccount.Currency),
+	}
+
+	return res, nil
+}
+
+func validateReverseTransferRequest(req *pb.ReverseTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := val.ValidateID(req.GetTransferId())
+	if err != nil {
+		violations = append(violations, fieldViolation("transfer_id", err))
+	}
+	if req.Amount != nil {
+		err = val.ValidateAmount(req.GetAmount())
+		if err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+
+	return violations
+}
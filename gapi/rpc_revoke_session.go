@@ -0,0 +1,75 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeSession blocks a session of the authenticated user, its access and refresh tokens stop working at once
+func (server *Server) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRevokeSessionRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	sessionID := uuid.MustParse(req.GetSessionId())
+	session, err := server.store.BlockSession(ctx, db.BlockSessionParams{
+		ID:       sessionID,
+		Username: payload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "session %s not found", sessionID)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke session %s ", err.Error())
+	}
+
+	res := &pb.RevokeSessionResponse{
+		Session: ConvertSession(session, payload.SessionID),
+	}
+
+	return res, nil
+}
+
+// RevokeOtherSessions blocks every active session of the authenticated user but the one the access token belongs to
+func (server *Server) RevokeOtherSessions(ctx context.Context, req *pb.RevokeOtherSessionsRequest) (*pb.RevokeOtherSessionsResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	sessions, err := server.store.BlockOtherSessions(ctx, db.BlockOtherSessionsParams{
+		Username: payload.Username,
+		KeepID:   payload.SessionID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke sessions: %s", err)
+	}
+
+	res := &pb.RevokeOtherSessionsResponse{}
+	for _, session := range sessions {
+		res.Revoked = append(res.Revoked, ConvertSession(session, payload.SessionID))
+	}
+
+	return res, nil
+}
+
+func validateRevokeSessionRequest(req *pb.RevokeSessionRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if _, err := uuid.Parse(req.GetSessionId()); err != nil {
+		violations = append(violations, fieldViolation("session_id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,90 @@
+package gapi
+
+import (
+	db "bank/db/sqlc"
+	"bank/pb"
+	"bank/val"
+	"context"
+	"database/sql"
+	"errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateAccountStatus lets the owner or an officer freeze an account, unfreeze it or reactivate a dormant account.
+// A frozen account can only be unfrozen by the user who froze it or an officer.
+func (server *Server) UpdateAccountStatus(ctx context.Context, req *pb.UpdateAccountStatusRequest) (*pb.UpdateAccountStatusResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateAccountStatusRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.validStatusAccount(ctx, req.GetAccountId(), payload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := server.store.UpdateAccountStatusTx(ctx, db.UpdateAccountStatusTxParams{
+		AccountID: req.GetAccountId(),
+		Status:    req.GetStatus(),
+		Username:  payload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrAccountUnfreeze) {
+			return nil, status.Errorf(codes.PermissionDenied, "%s ", err.Error())
+		}
+		return nil, transferError(err)
+	}
+
+	res := &pb.UpdateAccountStatusResponse{
+		Account: ConvertAccount(account),
+	}
+
+	return res, nil
+}
+
+// validStatusAccount loads an account whose status username can change,
+// an officer can change any account and a customer only the accounts they own
+func (server *Server) validStatusAccount(ctx context.Context, accountID int64, username string) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account %s ", err.Error())
+	}
+
+	role, err := db.AccountRole(ctx, server.store, account, username)
+	if err != nil {
+		return account, status.Errorf(codes.Internal, "failed to get account member %s ", err.Error())
+	}
+	if db.AccountRoleGrants(role, db.AccountRoleOwner) {
+		return account, nil
+	}
+	user, err := server.store.GetUser(ctx, username)
+	if err != nil {
+		return account, status.Errorf(codes.Internal, "failed to get user %s ", err.Error())
+	}
+	if user.Role == db.RoleOfficer {
+		return account, nil
+	}
+
+	return account, server.validAccountRole(ctx, account, username, db.AccountRoleOwner)
+}
+
+func validateUpdateAccountStatusRequest(req *pb.UpdateAccountStatusRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidateAccountStatus(req.GetStatus()); err != nil {
+		violations = append(violations, fieldViolation("status", err))
+	}
+
+	return violations
+}
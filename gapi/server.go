@@ -20,7 +20,7 @@ type Server struct {
 
 // NewServer creates a new gRPC server.
 func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
-	tokenMaker, err := token.NewJWTMaker(config.TokenSymmetricKey)
+	tokenMaker, err := token.NewMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("can not create token maker: %w", err)
 	}
@@ -10,6 +10,7 @@ import (
 	"bank/worker"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -30,6 +31,9 @@ import (
 	"time"
 )
 
+// currencyRefreshInterval is how often the currency registry is reloaded, to pick up changes made through other instances
+const currencyRefreshInterval = time.Minute
+
 var interruptSignals = []os.Signal{
 	os.Interrupt,
 	syscall.SIGTERM,
@@ -60,16 +64,33 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("can not connect to the db")
 	}
-	store := db.NewStore(conn)
+	txRetry := db.DefaultTxRetryPolicy()
+	if config.DBTxMaxAttempts > 0 {
+		txRetry.MaxAttempts = config.DBTxMaxAttempts
+	}
+	store := db.NewStore(conn, db.WithTxRetry(txRetry))
 
 	redisOpt := asynq.RedisClientOpt{
 		Addr: config.RedisAddress,
 	}
 	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
 
+	// `main reconcile` checks the ledger once and exits, non-zero when drift is found
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		runReconciliation(ctx, config, store, taskDistributor)
+		return
+	}
+	// `main set-role <username> <role>` makes a user a bank officer or an admin, or a customer again
+	if len(os.Args) > 1 && os.Args[1] == "set-role" {
+		runSetRole(ctx, store, os.Args[2:])
+		return
+	}
+
 	waitGroup, ctx := errgroup.WithContext(ctx)
 
-	runTaskProcessor(waitGroup, ctx, redisOpt, store)
+	runCurrencyRegistry(waitGroup, ctx, store)
+	runTaskProcessor(waitGroup, ctx, redisOpt, store, taskDistributor)
+	runTaskScheduler(waitGroup, ctx, redisOpt, config)
 	runGatewayServer(waitGroup, ctx, config, store, taskDistributor)
 	runGRPCServer(waitGroup, ctx, config, store, taskDistributor)
 
@@ -79,12 +100,35 @@ func main() {
 	}
 }
 
+func runCurrencyRegistry(wg *errgroup.Group, ctx context.Context, store db.Store) {
+	err := db.LoadCurrencies(ctx, store)
+	if err != nil {
+		log.Fatal().Err(err).Msg("can not load currencies")
+	}
+
+	wg.Go(func() error {
+		ticker := time.NewTicker(currencyRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				if err := db.LoadCurrencies(ctx, store); err != nil {
+					log.Error().Err(err).Msg("failed to reload currencies")
+				}
+			}
+		}
+	})
+}
+
 func runTaskProcessor(
 	wg *errgroup.Group,
 	ctx context.Context,
 	opt asynq.RedisClientOpt,
-	store db.Store) {
-	task := worker.NewRedisTaskProcessor(opt, store)
+	store db.Store,
+	taskDistributor worker.TaskDistributor) {
+	task := worker.NewRedisTaskProcessor(opt, store, taskDistributor)
 	log.Info().Msg("start task processor")
 	err := task.Start()
 	if err != nil {
@@ -101,6 +145,78 @@ func runTaskProcessor(
 	})
 }
 
+func runTaskScheduler(
+	wg *errgroup.Group,
+	ctx context.Context,
+	opt asynq.RedisClientOpt,
+	config util.Config) {
+	scheduler, err := worker.NewRedisTaskScheduler(opt, config.ReconciliationAlertEmail)
+	if err != nil {
+		log.Fatal().Err(err).Msg("can not create task scheduler")
+	}
+	log.Info().Msg("start task scheduler")
+	err = scheduler.Start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to start task scheduler")
+	}
+
+	wg.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("graceful shutdown of task scheduler")
+
+		scheduler.ShutDown()
+		log.Info().Msg("task scheduler is stopped")
+		return nil
+	})
+}
+
+func runReconciliation(
+	ctx context.Context,
+	config util.Config,
+	store db.Store,
+	taskDistributor worker.TaskDistributor) {
+	result, err := store.ReconcileTx(ctx)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to reconcile ledger")
+	}
+
+	report, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to marshal reconciliation report")
+	}
+	fmt.Println(string(report))
+
+	if result.Run.Status != db.ReconciliationDrift {
+		return
+	}
+	if config.ReconciliationAlertEmail != "" {
+		alert := &worker.PayloadSendReconciliationAlert{
+			RunID: result.Run.ID,
+			Email: config.ReconciliationAlertEmail,
+		}
+		err = taskDistributor.DistributeTaskSendReconciliationAlert(ctx, alert, asynq.Queue(worker.QueueCritical))
+		if err != nil {
+			log.Error().Err(err).Msg("failed to distribute reconciliation alert")
+		}
+	}
+	os.Exit(1)
+}
+
+func runSetRole(ctx context.Context, store db.Store, args []string) {
+	if len(args) != 2 || (args[1] != db.RoleCustomer && args[1] != db.RoleOfficer && args[1] != db.RoleAdmin) {
+		log.Fatal().Msgf("usage: main set-role <username> %s|%s|%s", db.RoleCustomer, db.RoleOfficer, db.RoleAdmin)
+	}
+
+	user, err := store.UpdateUserRole(ctx, db.UpdateUserRoleParams{
+		Username: args[0],
+		Role:     args[1],
+	})
+	if err != nil {
+		log.Fatal().Err(err).Str("username", args[0]).Msg("failed to set user role")
+	}
+	log.Info().Str("username", user.Username).Str("role", user.Role).Msg("user role set")
+}
+
 func runGRPCServer(
 	wg *errgroup.Group,
 	ctx context.Context,
@@ -167,6 +283,7 @@ func runGatewayServer(
 				DiscardUnknown: true,
 			},
 		}),
+		runtime.WithIncomingHeaderMatcher(gapi.HeaderMatcher),
 	)
 
 	err = pb.RegisterBankHandlerServer(ctx, grpcMux, server)
@@ -175,6 +292,7 @@ func runGatewayServer(
 	}
 	mux := http.NewServeMux()
 	mux.Handle("/", grpcMux)
+	mux.Handle("/.well-known/jwks.json", server.JWKSHandler())
 
 	//swagger
 	statikFS, err := fs.New()
@@ -0,0 +1,222 @@
+package statement
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+const camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"
+
+// ISO 20022 codes used in camt.053
+const (
+	camtCredit         = "CRDT"
+	camtDebit          = "DBIT"
+	camtOpeningBooked  = "OPBD"
+	camtClosingBooked  = "CLBD"
+	camtBooked         = "BOOK"
+	camtTransactionCd  = "TRANSFER"
+	camtNotProvidedRef = "NOTPROVIDED"
+)
+
+type camtDocument struct {
+	XMLName   xml.Name `xml:"Document"`
+	Namespace string   `xml:"xmlns,attr"`
+	Statement struct {
+		GroupHeader struct {
+			MsgID   string `xml:"MsgId"`
+			CreDtTm string `xml:"CreDtTm"`
+		} `xml:"GrpHdr"`
+		Stmt camtStatement `xml:"Stmt"`
+	} `xml:"BkToCstmrStmt"`
+}
+
+type camtStatement struct {
+	ID      string `xml:"Id"`
+	CreDtTm string `xml:"CreDtTm"`
+	FrToDt  struct {
+		FrDtTm string `xml:"FrDtTm"`
+		ToDtTm string `xml:"ToDtTm"`
+	} `xml:"FrToDt"`
+	Account struct {
+		ID    camtAccountID `xml:"Id"`
+		Ccy   string        `xml:"Ccy"`
+		Owner camtParty     `xml:"Ownr"`
+		Svcr  struct {
+			FinInstnID struct {
+				Othr struct {
+					ID string `xml:"Id"`
+				} `xml:"Othr"`
+			} `xml:"FinInstnId"`
+		} `xml:"Svcr"`
+	} `xml:"Acct"`
+	Balances []camtBalance `xml:"Bal"`
+	Entries  []camtEntry   `xml:"Ntry"`
+}
+
+type camtAccountID struct {
+	Othr struct {
+		ID string `xml:"Id"`
+	} `xml:"Othr"`
+}
+
+type camtParty struct {
+	Name string `xml:"Nm"`
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtDate struct {
+	DtTm string `xml:"DtTm"`
+}
+
+type camtBalance struct {
+	Type struct {
+		CdOrPrtry struct {
+			Cd string `xml:"Cd"`
+		} `xml:"CdOrPrtry"`
+	} `xml:"Tp"`
+	Amt       camtAmount `xml:"Amt"`
+	CdtDbtInd string     `xml:"CdtDbtInd"`
+	Dt        camtDate   `xml:"Dt"`
+}
+
+type camtEntry struct {
+	NtryRef   string     `xml:"NtryRef"`
+	Amt       camtAmount `xml:"Amt"`
+	CdtDbtInd string     `xml:"CdtDbtInd"`
+	Sts       struct {
+		Cd string `xml:"Cd"`
+	} `xml:"Sts"`
+	BookgDt     camtDate `xml:"BookgDt"`
+	ValDt       camtDate `xml:"ValDt"`
+	AcctSvcrRef string   `xml:"AcctSvcrRef,omitempty"`
+	BkTxCd      struct {
+		Prtry struct {
+			Cd string `xml:"Cd"`
+		} `xml:"Prtry"`
+	} `xml:"BkTxCd"`
+	Details *camtEntryDetails `xml:"NtryDtls,omitempty"`
+}
+
+type camtEntryDetails struct {
+	TxDtls struct {
+		Refs struct {
+			AcctSvcrRef string `xml:"AcctSvcrRef"`
+			EndToEndID  string `xml:"EndToEndId"`
+		} `xml:"Refs"`
+		RltdPties struct {
+			Dbtr     *camtRelatedParty `xml:"Dbtr,omitempty"`
+			DbtrAcct *camtAccount      `xml:"DbtrAcct,omitempty"`
+			Cdtr     *camtRelatedParty `xml:"Cdtr,omitempty"`
+			CdtrAcct *camtAccount      `xml:"CdtrAcct,omitempty"`
+		} `xml:"RltdPties"`
+		AddtlTxInf string `xml:"AddtlTxInf"`
+	} `xml:"TxDtls"`
+}
+
+type camtRelatedParty struct {
+	Pty camtParty `xml:"Pty"`
+}
+
+type camtAccount struct {
+	ID camtAccountID `xml:"Id"`
+}
+
+func camtTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+func camtIndicator(amount int64) string {
+	if amount < 0 {
+		return camtDebit
+	}
+	return camtCredit
+}
+
+func camtAccountOf(id int64) camtAccountID {
+	var account camtAccountID
+	account.Othr.ID = strconv.FormatInt(id, 10)
+	return account
+}
+
+// WriteCamt053 writes the statement as an ISO 20022 camt.053.001.08 bank to customer statement
+func WriteCamt053(w io.Writer, statement Statement) error {
+	doc := camtDocument{Namespace: camt053Namespace}
+	doc.Statement.GroupHeader.MsgID = statement.ID
+	doc.Statement.GroupHeader.CreDtTm = camtTime(statement.CreatedAt)
+
+	stmt := &doc.Statement.Stmt
+	stmt.ID = statement.ID
+	stmt.CreDtTm = camtTime(statement.CreatedAt)
+	stmt.FrToDt.FrDtTm = camtTime(statement.From)
+	stmt.FrToDt.ToDtTm = camtTime(statement.To)
+	stmt.Account.ID = camtAccountOf(statement.Account.ID)
+	stmt.Account.Ccy = statement.Account.Currency
+	stmt.Account.Owner.Name = statement.Account.Owner
+	stmt.Account.Svcr.FinInstnID.Othr.ID = bankID
+
+	balance := func(code string, amount int64, at time.Time) camtBalance {
+		var bal camtBalance
+		bal.Type.CdOrPrtry.Cd = code
+		bal.Amt = camtAmount{Currency: statement.Account.Currency, Value: absAmount(amount, statement.Exponent)}
+		bal.CdtDbtInd = camtIndicator(amount)
+		bal.Dt.DtTm = camtTime(at)
+		return bal
+	}
+	stmt.Balances = []camtBalance{
+		balance(camtOpeningBooked, statement.OpeningBalance, statement.From),
+		balance(camtClosingBooked, statement.ClosingBalance, statement.To),
+	}
+
+	for _, line := range statement.Lines {
+		entry := camtEntry{
+			NtryRef:   strconv.FormatInt(line.EntryID, 10),
+			Amt:       camtAmount{Currency: statement.Account.Currency, Value: absAmount(line.Amount, statement.Exponent)},
+			CdtDbtInd: camtIndicator(line.Amount),
+			BookgDt:   camtDate{DtTm: camtTime(line.BookedAt)},
+			ValDt:     camtDate{DtTm: camtTime(line.BookedAt)},
+		}
+		entry.Sts.Cd = camtBooked
+		entry.BkTxCd.Prtry.Cd = camtTransactionCd
+		if line.TransferID != 0 {
+			entry.AcctSvcrRef = strconv.FormatInt(line.TransferID, 10)
+			entry.Details = camtDetails(statement.Account, line)
+		}
+		stmt.Entries = append(stmt.Entries, entry)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// camtDetails names the debtor and creditor of a transfer, one of which is the statement account
+func camtDetails(account Account, line Line) *camtEntryDetails {
+	details := &camtEntryDetails{}
+	details.TxDtls.Refs.AcctSvcrRef = strconv.FormatInt(line.TransferID, 10)
+	details.TxDtls.Refs.EndToEndID = camtNotProvidedRef
+	details.TxDtls.AddtlTxInf = line.Description()
+
+	debtor, creditor := line.Counterparty, account
+	if line.Amount < 0 {
+		debtor, creditor = account, line.Counterparty
+	}
+	parties := &details.TxDtls.RltdPties
+	parties.Dbtr = &camtRelatedParty{Pty: camtParty{Name: debtor.Owner}}
+	parties.DbtrAcct = &camtAccount{ID: camtAccountOf(debtor.ID)}
+	parties.Cdtr = &camtRelatedParty{Pty: camtParty{Name: creditor.Owner}}
+	parties.CdtrAcct = &camtAccount{ID: camtAccountOf(creditor.ID)}
+	return details
+}
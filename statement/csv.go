@@ -0,0 +1,64 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteCSV writes one row per entry with the running balance,
+// between an opening balance row and a closing balance row
+func WriteCSV(w io.Writer, statement Statement) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{
+		"type", "booked_at", "entry_id", "transfer_id",
+		"counterparty_account_id", "counterparty_owner", "description", "amount", "balance", "currency",
+	})
+	if err != nil {
+		return err
+	}
+
+	balanceRow := func(kind string, at time.Time, balance int64) error {
+		return writer.Write([]string{
+			kind, at.UTC().Format(time.RFC3339), "", "", "", "", "", "",
+			FormatAmount(balance, statement.Exponent), statement.Account.Currency,
+		})
+	}
+
+	if err = balanceRow("opening_balance", statement.From, statement.OpeningBalance); err != nil {
+		return err
+	}
+
+	balance := statement.OpeningBalance
+	for _, line := range statement.Lines {
+		balance += line.Amount
+		row := []string{
+			"entry",
+			line.BookedAt.UTC().Format(time.RFC3339),
+			strconv.FormatInt(line.EntryID, 10),
+			"", "", "",
+			line.Description(),
+			FormatAmount(line.Amount, statement.Exponent),
+			FormatAmount(balance, statement.Exponent),
+			statement.Account.Currency,
+		}
+		if line.TransferID != 0 {
+			row[3] = strconv.FormatInt(line.TransferID, 10)
+		}
+		if line.Counterparty.ID != 0 {
+			row[4] = strconv.FormatInt(line.Counterparty.ID, 10)
+			row[5] = line.Counterparty.Owner
+		}
+		if err = writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	if err = balanceRow("closing_balance", statement.To, statement.ClosingBalance); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,153 @@
+package statement
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+// bankID identifies this bank in OFX and camt.053 documents
+const bankID = "BANK"
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n" +
+	`<?OFX OFXHEADER="200" VERSION="211" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+
+// ofxNameLength is the maximum length of the NAME element
+const ofxNameLength = 32
+
+type ofxDocument struct {
+	XMLName xml.Name `xml:"OFX"`
+	SignOn  struct {
+		Response struct {
+			Status   ofxStatus `xml:"STATUS"`
+			DTServer string    `xml:"DTSERVER"`
+			Language string    `xml:"LANGUAGE"`
+		} `xml:"SONRS"`
+	} `xml:"SIGNONMSGSRSV1"`
+	Bank struct {
+		Transaction struct {
+			TrnUID    string          `xml:"TRNUID"`
+			Status    ofxStatus       `xml:"STATUS"`
+			Statement ofxStatementRes `xml:"STMTRS"`
+		} `xml:"STMTTRNRS"`
+	} `xml:"BANKMSGSRSV1"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxStatementRes struct {
+	CurDef  string `xml:"CURDEF"`
+	Account struct {
+		BankID   string `xml:"BANKID"`
+		AcctID   string `xml:"ACCTID"`
+		AcctType string `xml:"ACCTTYPE"`
+	} `xml:"BANKACCTFROM"`
+	TranList struct {
+		DTStart      string           `xml:"DTSTART"`
+		DTEnd        string           `xml:"DTEND"`
+		Transactions []ofxTransaction `xml:"STMTTRN"`
+	} `xml:"BANKTRANLIST"`
+	LedgerBal ofxBalance `xml:"LEDGERBAL"`
+	// OFX has no opening balance element, it goes into the balance list instead
+	BalList struct {
+		Balances []ofxListBalance `xml:"BAL"`
+	} `xml:"BALLIST"`
+}
+
+type ofxTransaction struct {
+	TrnType  string `xml:"TRNTYPE"`
+	DTPosted string `xml:"DTPOSTED"`
+	TrnAmt   string `xml:"TRNAMT"`
+	FitID    string `xml:"FITID"`
+	Name     string `xml:"NAME,omitempty"`
+	Memo     string `xml:"MEMO"`
+}
+
+type ofxBalance struct {
+	BalAmt string `xml:"BALAMT"`
+	DTAsOf string `xml:"DTASOF"`
+}
+
+type ofxListBalance struct {
+	Name    string `xml:"NAME"`
+	Desc    string `xml:"DESC"`
+	BalType string `xml:"BALTYPE"`
+	Value   string `xml:"VALUE"`
+	DTAsOf  string `xml:"DTASOF"`
+}
+
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:UTC]"
+}
+
+// WriteOFX writes the statement as an OFX 2.1.1 bank statement response
+func WriteOFX(w io.Writer, statement Statement) error {
+	var doc ofxDocument
+	ok := ofxStatus{Code: 0, Severity: "INFO"}
+	doc.SignOn.Response.Status = ok
+	doc.SignOn.Response.DTServer = ofxTime(statement.CreatedAt)
+	doc.SignOn.Response.Language = "ENG"
+
+	doc.Bank.Transaction.TrnUID = statement.ID
+	doc.Bank.Transaction.Status = ok
+
+	res := &doc.Bank.Transaction.Statement
+	res.CurDef = statement.Account.Currency
+	res.Account.BankID = bankID
+	res.Account.AcctID = strconv.FormatInt(statement.Account.ID, 10)
+	res.Account.AcctType = "CHECKING"
+	res.TranList.DTStart = ofxTime(statement.From)
+	res.TranList.DTEnd = ofxTime(statement.To)
+	for _, line := range statement.Lines {
+		transaction := ofxTransaction{
+			TrnType:  "CREDIT",
+			DTPosted: ofxTime(line.BookedAt),
+			TrnAmt:   FormatAmount(line.Amount, statement.Exponent),
+			FitID:    strconv.FormatInt(line.EntryID, 10),
+			Name:     line.Counterparty.Owner,
+			Memo:     line.Description(),
+		}
+		if line.Amount < 0 {
+			transaction.TrnType = "DEBIT"
+		}
+		if len(transaction.Name) > ofxNameLength {
+			transaction.Name = transaction.Name[:ofxNameLength]
+		}
+		res.TranList.Transactions = append(res.TranList.Transactions, transaction)
+	}
+	res.LedgerBal = ofxBalance{
+		BalAmt: FormatAmount(statement.ClosingBalance, statement.Exponent),
+		DTAsOf: ofxTime(statement.To),
+	}
+	res.BalList.Balances = []ofxListBalance{
+		{
+			Name:    "OPENING",
+			Desc:    "Opening balance",
+			BalType: "DOLLAR",
+			Value:   FormatAmount(statement.OpeningBalance, statement.Exponent),
+			DTAsOf:  ofxTime(statement.From),
+		},
+		{
+			Name:    "CLOSING",
+			Desc:    "Closing balance",
+			BalType: "DOLLAR",
+			Value:   FormatAmount(statement.ClosingBalance, statement.Exponent),
+			DTAsOf:  ofxTime(statement.To),
+		},
+	}
+
+	if _, err := io.WriteString(w, ofxHeader); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
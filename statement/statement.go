@@ -0,0 +1,102 @@
+// Package statement renders account statements as CSV, OFX 2.x and ISO 20022 camt.053.
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"bank/util"
+)
+
+// Supported statement formats
+const (
+	FormatCSV     = "csv"
+	FormatOFX     = "ofx"
+	FormatCamt053 = "camt053"
+)
+
+// DefaultExponent is the number of minor units digits used when formatting amounts
+const DefaultExponent = 2
+
+// Statement is the content of an account statement, independent of its output format
+type Statement struct {
+	ID       string
+	Account  Account
+	From     time.Time
+	To       time.Time
+	Exponent int
+	// OpeningBalance is the balance at From and ClosingBalance the balance at To
+	OpeningBalance int64
+	ClosingBalance int64
+	Lines          []Line
+	CreatedAt      time.Time
+}
+
+type Account struct {
+	ID       int64
+	Owner    string
+	Currency string
+}
+
+// Line is one ledger entry of the statement
+type Line struct {
+	EntryID    int64
+	TransferID int64
+	// Counterparty is empty when the entry isn't linked to a transfer
+	Counterparty Account
+	// Amount is positive for credits and negative for debits
+	Amount   int64
+	BookedAt time.Time
+}
+
+// Description names the counterparty of the line, the way it is shown on statements
+func (line Line) Description() string {
+	if line.Counterparty.ID == 0 {
+		return "entry " + strconv.FormatInt(line.EntryID, 10)
+	}
+	direction := "from"
+	if line.Amount < 0 {
+		direction = "to"
+	}
+	return fmt.Sprintf("transfer %s %s (account %d)", direction, line.Counterparty.Owner, line.Counterparty.ID)
+}
+
+// Write renders the statement in the given format
+func Write(w io.Writer, format string, statement Statement) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, statement)
+	case FormatOFX:
+		return WriteOFX(w, statement)
+	case FormatCamt053:
+		return WriteCamt053(w, statement)
+	}
+	return fmt.Errorf("unsupported statement format %q", format)
+}
+
+// ContentType returns the MIME type and file extension of a format
+func ContentType(format string) (string, string) {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8", "csv"
+	case FormatOFX:
+		return "application/x-ofx", "ofx"
+	default:
+		return "application/xml; charset=utf-8", "xml"
+	}
+}
+
+// FormatAmount writes an amount of minor units as a decimal, e.g. -1234 with exponent 2 is "-12.34"
+func FormatAmount(amount int64, exponent int) string {
+	return util.FormatAmount(amount, exponent)
+}
+
+// absAmount formats the size of an amount for formats that carry the sign separately
+func absAmount(amount int64, exponent int) string {
+	if amount < 0 {
+		return FormatAmount(-amount, exponent)
+	}
+	return FormatAmount(amount, exponent)
+}
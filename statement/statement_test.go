@@ -0,0 +1,120 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testStatement() Statement {
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	return Statement{
+		ID:       "stmt-1",
+		Account:  Account{ID: 7, Owner: "alice", Currency: "USD"},
+		From:     from,
+		To:       from.AddDate(0, 1, 0),
+		Exponent: DefaultExponent,
+		Lines: []Line{
+			{
+				EntryID:      11,
+				TransferID:   5,
+				Counterparty: Account{ID: 8, Owner: "bob", Currency: "USD"},
+				Amount:       2550,
+				BookedAt:     from.Add(time.Hour),
+			},
+			{
+				EntryID:      12,
+				TransferID:   6,
+				Counterparty: Account{ID: 9, Owner: "carol", Currency: "USD"},
+				Amount:       -10000,
+				BookedAt:     from.Add(2 * time.Hour),
+			},
+		},
+		OpeningBalance: 5000,
+		ClosingBalance: -2450,
+		CreatedAt:      from.AddDate(0, 1, 1),
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "12.34", FormatAmount(1234, 2))
+	require.Equal(t, "-0.05", FormatAmount(-5, 2))
+	require.Equal(t, "0.00", FormatAmount(0, 2))
+	require.Equal(t, "1000", FormatAmount(1000, 0))
+	require.Equal(t, "0.001", FormatAmount(1, 3))
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatCSV, testStatement()))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+	require.Equal(t, []string{"opening_balance", "2024-03-01T00:00:00Z", "", "", "", "", "", "", "50.00", "USD"}, rows[1])
+	require.Equal(t, []string{"entry", "2024-03-01T01:00:00Z", "11", "5", "8", "bob",
+		"transfer from bob (account 8)", "25.50", "75.50", "USD"}, rows[2])
+	require.Equal(t, []string{"entry", "2024-03-01T02:00:00Z", "12", "6", "9", "carol",
+		"transfer to carol (account 9)", "-100.00", "-24.50", "USD"}, rows[3])
+	require.Equal(t, "closing_balance", rows[4][0])
+	require.Equal(t, "-24.50", rows[4][8])
+}
+
+func TestWriteOFX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatOFX, testStatement()))
+	require.True(t, strings.HasPrefix(buf.String(), `<?xml version="1.0"`))
+	require.Contains(t, buf.String(), `<?OFX OFXHEADER="200" VERSION="211"`)
+
+	var doc ofxDocument
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	res := doc.Bank.Transaction.Statement
+	require.Equal(t, "USD", res.CurDef)
+	require.Equal(t, "7", res.Account.AcctID)
+	require.Len(t, res.TranList.Transactions, 2)
+	require.Equal(t, "CREDIT", res.TranList.Transactions[0].TrnType)
+	require.Equal(t, "25.50", res.TranList.Transactions[0].TrnAmt)
+	require.Equal(t, "DEBIT", res.TranList.Transactions[1].TrnType)
+	require.Equal(t, "-100.00", res.TranList.Transactions[1].TrnAmt)
+	require.Equal(t, "20240301020000.000[0:UTC]", res.TranList.Transactions[1].DTPosted)
+	require.Equal(t, "-24.50", res.LedgerBal.BalAmt)
+	require.Equal(t, "50.00", res.BalList.Balances[0].Value)
+}
+
+func TestWriteCamt053(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatCamt053, testStatement()))
+
+	var doc camtDocument
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	stmt := doc.Statement.Stmt
+	require.Equal(t, "7", stmt.Account.ID.Othr.ID)
+	require.Equal(t, "alice", stmt.Account.Owner.Name)
+
+	require.Len(t, stmt.Balances, 2)
+	require.Equal(t, camtOpeningBooked, stmt.Balances[0].Type.CdOrPrtry.Cd)
+	require.Equal(t, "50.00", stmt.Balances[0].Amt.Value)
+	require.Equal(t, camtCredit, stmt.Balances[0].CdtDbtInd)
+	require.Equal(t, camtClosingBooked, stmt.Balances[1].Type.CdOrPrtry.Cd)
+	require.Equal(t, "24.50", stmt.Balances[1].Amt.Value)
+	require.Equal(t, camtDebit, stmt.Balances[1].CdtDbtInd)
+
+	require.Len(t, stmt.Entries, 2)
+	debit := stmt.Entries[1]
+	require.Equal(t, "100.00", debit.Amt.Value)
+	require.Equal(t, "USD", debit.Amt.Currency)
+	require.Equal(t, camtDebit, debit.CdtDbtInd)
+	require.Equal(t, "6", debit.AcctSvcrRef)
+	require.Equal(t, "alice", debit.Details.TxDtls.RltdPties.Dbtr.Pty.Name)
+	require.Equal(t, "carol", debit.Details.TxDtls.RltdPties.Cdtr.Pty.Name)
+	require.Equal(t, "9", debit.Details.TxDtls.RltdPties.CdtrAcct.ID.Othr.ID)
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	require.Error(t, Write(&buf, "pdf", testStatement()))
+}
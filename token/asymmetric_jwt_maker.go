@@ -0,0 +1,116 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+)
+
+// AsymmetricJWTMaker is a JSON Web Token maker that signs with the current key of a KeyRing,
+// EdDSA for Ed25519 keys and RS256 for RSA keys, and names the key in the kid header
+type AsymmetricJWTMaker struct {
+	keyRing *KeyRing
+}
+
+// NewAsymmetricJWTMaker creates a new AsymmetricJWTMaker
+func NewAsymmetricJWTMaker(keyRing *KeyRing) (Maker, error) {
+	return &AsymmetricJWTMaker{keyRing}, nil
+}
+
+// CreateToken creates a new token for a specific username, session, type and duration
+func (maker *AsymmetricJWTMaker) CreateToken(username string, sessionID uuid.UUID, tokenType TokenType, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, sessionID, tokenType, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	signer := maker.keyRing.signer
+	jwtToken := jwt.NewWithClaims(jwtSigningMethod(signer.Public()), payload)
+	jwtToken.Header["kid"] = maker.keyRing.CurrentKeyID()
+	token, err := jwtToken.SignedString(signer)
+	return token, payload, err
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *AsymmetricJWTMaker) VerifyToken(token string) (*Payload, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header["kid"].(string)
+		publicKey, ok := maker.keyRing.PublicKey(keyID)
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+		// the algorithm comes from the key, never from the token, so an RSA public key can't be used as an HMAC secret
+		if token.Method.Alg() != jwtAlgorithm(publicKey) {
+			return nil, ErrInvalidToken
+		}
+		return publicKey, nil
+	}
+	return parseJWT(token, keyFunc)
+}
+
+// JWKS returns the public keys tokens are verified against
+func (maker *AsymmetricJWTMaker) JWKS() JWKS {
+	return maker.keyRing.JWKS()
+}
+
+func jwtSigningMethod(publicKey crypto.PublicKey) jwt.SigningMethod {
+	switch publicKey.(type) {
+	case ed25519.PublicKey:
+		return SigningMethodEdDSA
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256
+	}
+	return nil
+}
+
+func jwtAlgorithm(publicKey crypto.PublicKey) string {
+	method := jwtSigningMethod(publicKey)
+	if method == nil {
+		return ""
+	}
+	return method.Alg()
+}
+
+// SigningMethodEdDSA signs tokens with Ed25519 as described by RFC 8037, jwt-go v3 doesn't have it
+var SigningMethodEdDSA = &signingMethodEdDSA{}
+
+type signingMethodEdDSA struct{}
+
+func init() {
+	jwt.RegisterSigningMethod(SigningMethodEdDSA.Alg(), func() jwt.SigningMethod {
+		return SigningMethodEdDSA
+	})
+}
+
+func (method *signingMethodEdDSA) Alg() string {
+	return "EdDSA"
+}
+
+// Sign signs with an ed25519.PrivateKey
+func (method *signingMethodEdDSA) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok || len(privateKey) != ed25519.PrivateKeySize {
+		return "", jwt.ErrInvalidKeyType
+	}
+	return jwt.EncodeSegment(ed25519.Sign(privateKey, []byte(signingString))), nil
+}
+
+// Verify checks a signature with an ed25519.PublicKey
+func (method *signingMethodEdDSA) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok || len(publicKey) != ed25519.PublicKeySize {
+		return jwt.ErrInvalidKeyType
+	}
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, []byte(signingString), sig) {
+		return jwt.ErrSignatureInvalid
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package token
+
+import (
+	"bank/util"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func mustDecodeSegment(t *testing.T, segment string) []byte {
+	data, err := jwt.DecodeSegment(segment)
+	require.NoError(t, err)
+	return data
+}
+
+func testAsymmetricJWTMaker(t *testing.T, signer crypto.Signer, alg string) {
+	maker, err := NewAsymmetricJWTMaker(randomKeyRing(t, signer))
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+	sessionID := uuid.New()
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(username, sessionID, TokenTypeAccess, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	jwtToken, _, err := new(jwt.Parser).ParseUnverified(token, &Payload{})
+	require.NoError(t, err)
+	require.Equal(t, alg, jwtToken.Header["alg"])
+	require.Equal(t, thumbprint(signer.Public()), jwtToken.Header["kid"])
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+
+	require.NotZero(t, payload.ID)
+	require.Equal(t, username, payload.Username)
+	require.Equal(t, sessionID, payload.SessionID)
+	require.Equal(t, TokenTypeAccess, payload.Type)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+}
+
+func TestAsymmetricJWTMakerEd25519(t *testing.T) {
+	testAsymmetricJWTMaker(t, randomEd25519Key(t), "EdDSA")
+}
+
+func TestAsymmetricJWTMakerRS256(t *testing.T) {
+	testAsymmetricJWTMaker(t, randomRSAKey(t), "RS256")
+}
+
+func TestExpiredAsymmetricJWTToken(t *testing.T) {
+	maker, err := NewAsymmetricJWTMaker(randomKeyRing(t, randomEd25519Key(t)))
+	require.NoError(t, err)
+
+	token, payload, err := maker.CreateToken(util.RandomOwner(), uuid.New(), TokenTypeAccess, -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestAsymmetricJWTMakerRotation(t *testing.T) {
+	oldKey := randomEd25519Key(t)
+	newKey := randomRSAKey(t)
+
+	oldMaker, err := NewAsymmetricJWTMaker(randomKeyRing(t, oldKey))
+	require.NoError(t, err)
+	oldToken, _, err := oldMaker.CreateToken(util.RandomOwner(), uuid.New(), TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	// after the rotation the old key only verifies
+	rotatedMaker, err := NewAsymmetricJWTMaker(randomKeyRing(t, newKey, oldKey.Public()))
+	require.NoError(t, err)
+	payload, err := rotatedMaker.VerifyToken(oldToken)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	newToken, _, err := rotatedMaker.CreateToken(util.RandomOwner(), uuid.New(), TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+	_, err = oldMaker.VerifyToken(newToken)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+
+	// once the old key is dropped its tokens are rejected
+	droppedMaker, err := NewAsymmetricJWTMaker(randomKeyRing(t, newKey))
+	require.NoError(t, err)
+	_, err = droppedMaker.VerifyToken(oldToken)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+}
+
+func TestAsymmetricJWTTokenAlgConfusion(t *testing.T) {
+	signer := randomRSAKey(t)
+	maker, err := NewAsymmetricJWTMaker(randomKeyRing(t, signer))
+	require.NoError(t, err)
+
+	payload, err := NewPayload(util.RandomOwner(), uuid.New(), TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	// an HMAC token keyed with the published public key must not pass for one signed with the private key
+	publicDER := x509.MarshalPKCS1PublicKey(signer.Public().(*rsa.PublicKey))
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	jwtToken.Header["kid"] = thumbprint(signer.Public())
+	token, err := jwtToken.SignedString(publicDER)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	jwtToken = jwt.NewWithClaims(jwt.SigningMethodNone, payload)
+	jwtToken.Header["kid"] = thumbprint(signer.Public())
+	token, err = jwtToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
@@ -0,0 +1,231 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+const minRSAKeyBits = 2048
+
+// KeyRing holds the private key new tokens are signed with and the public keys tokens are verified against.
+// The previous keys stay on the ring after a rotation so the tokens they signed remain valid until they expire.
+type KeyRing struct {
+	signer       crypto.Signer
+	currentKeyID string
+	publicKeys   map[string]crypto.PublicKey
+	// keyIDs lists the keys in the order they were added, the current key first
+	keyIDs []string
+}
+
+// NewKeyRing creates a KeyRing that signs with the current key and also verifies with the previous ones.
+// Only Ed25519 and RSA keys of at least 2048 bits are supported.
+func NewKeyRing(current crypto.Signer, previous ...crypto.PublicKey) (*KeyRing, error) {
+	ring := &KeyRing{
+		signer:     current,
+		publicKeys: make(map[string]crypto.PublicKey),
+	}
+
+	keyID, err := ring.add(current.Public())
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key: %w", err)
+	}
+	ring.currentKeyID = keyID
+
+	for _, publicKey := range previous {
+		if _, err = ring.add(publicKey); err != nil {
+			return nil, fmt.Errorf("invalid verification key: %w", err)
+		}
+	}
+	return ring, nil
+}
+
+// LoadKeyRing reads a KeyRing from PEM files, the signing key is a PKCS #8 or PKCS #1 private key
+// and each verification key is a PKIX public key or a private key whose public half is used
+func LoadKeyRing(signingKeyFile string, verificationKeyFiles ...string) (*KeyRing, error) {
+	data, err := os.ReadFile(signingKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("can not read signing key: %w", err)
+	}
+	signer, err := parsePrivateKeyPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("can not parse signing key %s: %w", signingKeyFile, err)
+	}
+
+	previous := make([]crypto.PublicKey, 0, len(verificationKeyFiles))
+	for _, file := range verificationKeyFiles {
+		data, err = os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("can not read verification key: %w", err)
+		}
+		publicKey, err := parsePublicKeyPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("can not parse verification key %s: %w", file, err)
+		}
+		previous = append(previous, publicKey)
+	}
+
+	return NewKeyRing(signer, previous...)
+}
+
+// CurrentKeyID returns the id of the key new tokens are signed with
+func (ring *KeyRing) CurrentKeyID() string {
+	return ring.currentKeyID
+}
+
+// PublicKey returns the public key with the given id
+func (ring *KeyRing) PublicKey(keyID string) (crypto.PublicKey, bool) {
+	publicKey, ok := ring.publicKeys[keyID]
+	return publicKey, ok
+}
+
+// JWKS returns the public keys of the ring as a JSON Web Key Set, the current key first
+func (ring *KeyRing) JWKS() JWKS {
+	set := JWKS{Keys: make([]JWK, len(ring.keyIDs))}
+	for i, keyID := range ring.keyIDs {
+		set.Keys[i] = newJWK(keyID, ring.publicKeys[keyID])
+	}
+	return set
+}
+
+// add puts a public key on the ring, a key that is already there is skipped
+func (ring *KeyRing) add(publicKey crypto.PublicKey) (string, error) {
+	if err := checkPublicKey(publicKey); err != nil {
+		return "", err
+	}
+	keyID := thumbprint(publicKey)
+	if _, ok := ring.publicKeys[keyID]; !ok {
+		ring.publicKeys[keyID] = publicKey
+		ring.keyIDs = append(ring.keyIDs, keyID)
+	}
+	return keyID, nil
+}
+
+func checkPublicKey(publicKey crypto.PublicKey) error {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		if len(key) != ed25519.PublicKeySize {
+			return fmt.Errorf("ed25519 public key must be %d bytes", ed25519.PublicKeySize)
+		}
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return fmt.Errorf("rsa key must be at least %d bits", minRSAKeyBits)
+		}
+	default:
+		return fmt.Errorf("unsupported key type %T", publicKey)
+	}
+	return nil
+}
+
+// JWK is the public half of a signing key as described by RFC 7517
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// Curve and X are set for Ed25519 keys
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	// N and E are set for RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set, the document other services fetch to verify tokens without calling us
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeySet is implemented by the makers that sign with a KeyRing and can publish its public keys
+type KeySet interface {
+	JWKS() JWKS
+}
+
+func newJWK(keyID string, publicKey crypto.PublicKey) JWK {
+	jwk := JWK{
+		KeyID:     keyID,
+		Use:       "sig",
+		Algorithm: jwtAlgorithm(publicKey),
+	}
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(key)
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	}
+	return jwk
+}
+
+// thumbprint returns the RFC 7638 thumbprint of a public key, used as its key id.
+// It only depends on the key so every service loading the same key agrees on the id.
+func thumbprint(publicKey crypto.PublicKey) string {
+	// the required members of the JWK in lexicographic order, without whitespace
+	var members string
+	jwk := newJWK("", publicKey)
+	switch jwk.KeyType {
+	case "OKP":
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, jwk.Curve, jwk.KeyType, jwk.X)
+	case "RSA":
+		members = fmt.Sprintf(`{"e":%q,"kty":%q,"n":%q}`, jwk.E, jwk.KeyType, jwk.N)
+	}
+	sum := sha256.Sum256([]byte(members))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func parsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	return signer, nil
+}
+
+func parsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	signer, err := parsePrivateKeyPEM(data)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Public(), nil
+}
@@ -0,0 +1,114 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomEd25519Key(t *testing.T) ed25519.PrivateKey {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return privateKey
+}
+
+func randomRSAKey(t *testing.T) *rsa.PrivateKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, minRSAKeyBits)
+	require.NoError(t, err)
+	return privateKey
+}
+
+func randomKeyRing(t *testing.T, current crypto.Signer, previous ...crypto.PublicKey) *KeyRing {
+	keyRing, err := NewKeyRing(current, previous...)
+	require.NoError(t, err)
+	return keyRing
+}
+
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	file := filepath.Join(t.TempDir(), "key.pem")
+	err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	require.NoError(t, err)
+	return file
+}
+
+func TestNewKeyRing(t *testing.T) {
+	current := randomEd25519Key(t)
+	previous := randomRSAKey(t)
+
+	keyRing := randomKeyRing(t, current, previous.Public(), current.Public())
+	require.Equal(t, thumbprint(current.Public()), keyRing.CurrentKeyID())
+
+	publicKey, ok := keyRing.PublicKey(thumbprint(previous.Public()))
+	require.True(t, ok)
+	require.Equal(t, previous.Public(), publicKey)
+
+	// the current key listed again as a verification key is only kept once
+	jwks := keyRing.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	require.Equal(t, JWK{
+		KeyType:   "OKP",
+		KeyID:     keyRing.CurrentKeyID(),
+		Use:       "sig",
+		Algorithm: "EdDSA",
+		Curve:     "Ed25519",
+		X:         jwks.Keys[0].X,
+	}, jwks.Keys[0])
+	require.Equal(t, "RSA", jwks.Keys[1].KeyType)
+	require.Equal(t, "RS256", jwks.Keys[1].Algorithm)
+	require.Equal(t, "AQAB", jwks.Keys[1].E)
+}
+
+func TestNewKeyRingWeakRSAKey(t *testing.T) {
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	_, err = NewKeyRing(weak)
+	require.ErrorContains(t, err, "at least 2048 bits")
+
+	_, err = NewKeyRing(randomEd25519Key(t), weak.Public())
+	require.ErrorContains(t, err, "invalid verification key")
+}
+
+func TestThumbprint(t *testing.T) {
+	// the Ed25519 key of RFC 8037 appendix A.3
+	x := "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"
+	publicKey := ed25519.PublicKey(mustDecodeSegment(t, x))
+	require.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", thumbprint(publicKey))
+}
+
+func TestLoadKeyRing(t *testing.T) {
+	current := randomEd25519Key(t)
+	currentDER, err := x509.MarshalPKCS8PrivateKey(current)
+	require.NoError(t, err)
+	currentFile := writePEM(t, "PRIVATE KEY", currentDER)
+
+	previous := randomRSAKey(t)
+	previousDER, err := x509.MarshalPKIXPublicKey(previous.Public())
+	require.NoError(t, err)
+	previousFile := writePEM(t, "PUBLIC KEY", previousDER)
+
+	oldest := randomRSAKey(t)
+	oldestFile := writePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(oldest))
+
+	keyRing, err := LoadKeyRing(currentFile, previousFile, oldestFile)
+	require.NoError(t, err)
+	require.Equal(t, thumbprint(current.Public()), keyRing.CurrentKeyID())
+	require.Len(t, keyRing.JWKS().Keys, 3)
+
+	_, ok := keyRing.PublicKey(thumbprint(oldest.Public()))
+	require.True(t, ok)
+
+	_, err = LoadKeyRing(previousFile)
+	require.ErrorContains(t, err, "can not parse signing key")
+
+	_, err = LoadKeyRing(filepath.Join(t.TempDir(), "missing.pem"))
+	require.ErrorContains(t, err, "can not read signing key")
+}
@@ -1,14 +1,41 @@
 package token
 
 import (
+	"bank/util"
+	"fmt"
+	"github.com/google/uuid"
 	"time"
 )
 
 // Maker is an interface for managing tokens
 type Maker interface {
-	// CreateToken creates a new token for a specific username and duration
-	CreateToken(username string, duration time.Duration) (string, *Payload, error)
+	// CreateToken creates a new token for a specific username, session, type and duration
+	CreateToken(username string, sessionID uuid.UUID, tokenType TokenType, duration time.Duration) (string, *Payload, error)
 
 	// VerifyToken checks if the token is valid or not
 	VerifyToken(token string) (*Payload, error)
 }
+
+// NewMaker creates the Maker the config asks for, it signs with the symmetric key
+// unless a signing key file is set
+func NewMaker(config util.Config) (Maker, error) {
+	if config.TokenType != "" && config.TokenType != "jwt" && config.TokenType != "paseto" {
+		return nil, fmt.Errorf("unsupported token type %q", config.TokenType)
+	}
+
+	if config.TokenSigningKeyFile == "" {
+		if config.TokenType == "paseto" {
+			return NewPasetoMaker(config.TokenSymmetricKey)
+		}
+		return NewJWTMaker(config.TokenSymmetricKey)
+	}
+
+	keyRing, err := LoadKeyRing(config.TokenSigningKeyFile, config.TokenVerificationKeyFiles...)
+	if err != nil {
+		return nil, err
+	}
+	if config.TokenType == "paseto" {
+		return NewPasetoPublicMaker(keyRing)
+	}
+	return NewAsymmetricJWTMaker(keyRing)
+}
@@ -0,0 +1,70 @@
+package token
+
+import (
+	"bank/util"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMaker(t *testing.T) {
+	der, err := x509.MarshalPKCS8PrivateKey(randomEd25519Key(t))
+	require.NoError(t, err)
+	signingKeyFile := writePEM(t, "PRIVATE KEY", der)
+
+	testCases := []struct {
+		name   string
+		config util.Config
+		check  func(t *testing.T, maker Maker, err error)
+	}{
+		{
+			name:   "SymmetricJWT",
+			config: util.Config{TokenSymmetricKey: util.RandomString(32)},
+			check: func(t *testing.T, maker Maker, err error) {
+				require.NoError(t, err)
+				require.IsType(t, &JWTMaker{}, maker)
+			},
+		},
+		{
+			name:   "SymmetricPaseto",
+			config: util.Config{TokenType: "paseto", TokenSymmetricKey: util.RandomString(32)},
+			check: func(t *testing.T, maker Maker, err error) {
+				require.NoError(t, err)
+				require.IsType(t, &PasetoMaker{}, maker)
+			},
+		},
+		{
+			name:   "AsymmetricJWT",
+			config: util.Config{TokenType: "jwt", TokenSigningKeyFile: signingKeyFile},
+			check: func(t *testing.T, maker Maker, err error) {
+				require.NoError(t, err)
+				require.IsType(t, &AsymmetricJWTMaker{}, maker)
+				require.Implements(t, (*KeySet)(nil), maker)
+			},
+		},
+		{
+			name:   "AsymmetricPaseto",
+			config: util.Config{TokenType: "paseto", TokenSigningKeyFile: signingKeyFile},
+			check: func(t *testing.T, maker Maker, err error) {
+				require.NoError(t, err)
+				require.IsType(t, &PasetoPublicMaker{}, maker)
+			},
+		},
+		{
+			name:   "UnsupportedType",
+			config: util.Config{TokenType: "saml", TokenSymmetricKey: util.RandomString(32)},
+			check: func(t *testing.T, maker Maker, err error) {
+				require.ErrorContains(t, err, "unsupported token type")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			maker, err := NewMaker(tc.config)
+			tc.check(t, maker, err)
+		})
+	}
+}
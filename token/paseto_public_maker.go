@@ -0,0 +1,152 @@
+package token
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const pasetoV4PublicHeader = "v4.public."
+
+// PasetoPublicMaker is a PASETO v4.public token maker, it signs with the current Ed25519 key of a KeyRing
+// and puts the key id in the footer of the token
+type PasetoPublicMaker struct {
+	keyRing *KeyRing
+}
+
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// NewPasetoPublicMaker creates a new PasetoPublicMaker, v4.public only signs with Ed25519 so every key of the ring must be one
+func NewPasetoPublicMaker(keyRing *KeyRing) (Maker, error) {
+	for _, keyID := range keyRing.keyIDs {
+		if _, ok := keyRing.publicKeys[keyID].(ed25519.PublicKey); !ok {
+			return nil, fmt.Errorf("key %s is not an ed25519 key", keyID)
+		}
+	}
+	return &PasetoPublicMaker{keyRing}, nil
+}
+
+// CreateToken creates a new token for a specific username, session, type and duration
+func (maker *PasetoPublicMaker) CreateToken(username string, sessionID uuid.UUID, tokenType TokenType, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, sessionID, tokenType, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", nil, err
+	}
+	footer, err := json.Marshal(pasetoFooter{KeyID: maker.keyRing.CurrentKeyID()})
+	if err != nil {
+		return "", nil, err
+	}
+
+	privateKey, ok := maker.keyRing.signer.(ed25519.PrivateKey)
+	if !ok {
+		return "", nil, errors.New("signing key is not an ed25519 key")
+	}
+	signature := ed25519.Sign(privateKey, pasetoPreAuthEncode([]byte(pasetoV4PublicHeader), message, footer, nil))
+
+	token := pasetoV4PublicHeader +
+		base64.RawURLEncoding.EncodeToString(append(message, signature...)) + "." +
+		base64.RawURLEncoding.EncodeToString(footer)
+	return token, payload, nil
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *PasetoPublicMaker) VerifyToken(token string) (*Payload, error) {
+	message, err := maker.verify(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{}
+	if err = json.Unmarshal(message, payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	err = payload.Valid()
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// JWKS returns the public keys tokens are verified against
+func (maker *PasetoPublicMaker) JWKS() JWKS {
+	return maker.keyRing.JWKS()
+}
+
+// verify checks the signature of a token with the key its footer names and returns the signed message
+func (maker *PasetoPublicMaker) verify(token string) ([]byte, error) {
+	if !strings.HasPrefix(token, pasetoV4PublicHeader) {
+		return nil, errors.New("not a v4.public token")
+	}
+	parts := strings.Split(strings.TrimPrefix(token, pasetoV4PublicHeader), ".")
+	if len(parts) != 2 {
+		return nil, errors.New("token has no footer")
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(body) < ed25519.SignatureSize {
+		return nil, errors.New("token is too short")
+	}
+	footer, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var keyFooter pasetoFooter
+	if err = json.Unmarshal(footer, &keyFooter); err != nil {
+		return nil, err
+	}
+	publicKey, ok := maker.keyRing.PublicKey(keyFooter.KeyID)
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", keyFooter.KeyID)
+	}
+
+	return verifyPasetoV4Public(publicKey.(ed25519.PublicKey), body, footer)
+}
+
+// verifyPasetoV4Public checks the signature at the end of the decoded body of a v4.public token
+// and returns the message before it
+func verifyPasetoV4Public(publicKey ed25519.PublicKey, body, footer []byte) ([]byte, error) {
+	split := len(body) - ed25519.SignatureSize
+	message, signature := body[:split], body[split:]
+	if !ed25519.Verify(publicKey, pasetoPreAuthEncode([]byte(pasetoV4PublicHeader), message, footer, nil), signature) {
+		return nil, errors.New("invalid signature")
+	}
+	return message, nil
+}
+
+// pasetoPreAuthEncode is PAE from the PASETO spec, it packs the pieces into one unambiguous string to sign:
+// the number of pieces, then each piece after its length, as unsigned 64 bit little endian integers with the top bit cleared
+func pasetoPreAuthEncode(pieces ...[]byte) []byte {
+	var buf bytes.Buffer
+	le64 := func(n int) {
+		_ = binary.Write(&buf, binary.LittleEndian, uint64(n)&math.MaxInt64)
+	}
+
+	le64(len(pieces))
+	for _, piece := range pieces {
+		le64(len(piece))
+		buf.Write(piece)
+	}
+	return buf.Bytes()
+}
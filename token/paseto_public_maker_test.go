@@ -0,0 +1,127 @@
+package token
+
+import (
+	"bank/util"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasetoPublicMaker(t *testing.T) {
+	signer := randomEd25519Key(t)
+	maker, err := NewPasetoPublicMaker(randomKeyRing(t, signer))
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+	sessionID := uuid.New()
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(username, sessionID, TokenTypeAccess, duration)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(token, "v4.public."))
+	require.NotEmpty(t, payload)
+
+	footer, err := base64.RawURLEncoding.DecodeString(token[strings.LastIndex(token, ".")+1:])
+	require.NoError(t, err)
+	require.JSONEq(t, `{"kid":"`+thumbprint(signer.Public())+`"}`, string(footer))
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+
+	require.NotZero(t, payload.ID)
+	require.Equal(t, username, payload.Username)
+	require.Equal(t, sessionID, payload.SessionID)
+	require.Equal(t, TokenTypeAccess, payload.Type)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+}
+
+func TestExpiredPasetoPublicToken(t *testing.T) {
+	maker, err := NewPasetoPublicMaker(randomKeyRing(t, randomEd25519Key(t)))
+	require.NoError(t, err)
+
+	token, payload, err := maker.CreateToken(util.RandomOwner(), uuid.New(), TokenTypeAccess, -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestPasetoPublicMakerRotation(t *testing.T) {
+	oldKey := randomEd25519Key(t)
+	newKey := randomEd25519Key(t)
+
+	oldMaker, err := NewPasetoPublicMaker(randomKeyRing(t, oldKey))
+	require.NoError(t, err)
+	oldToken, _, err := oldMaker.CreateToken(util.RandomOwner(), uuid.New(), TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	rotatedMaker, err := NewPasetoPublicMaker(randomKeyRing(t, newKey, oldKey.Public()))
+	require.NoError(t, err)
+	payload, err := rotatedMaker.VerifyToken(oldToken)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	droppedMaker, err := NewPasetoPublicMaker(randomKeyRing(t, newKey))
+	require.NoError(t, err)
+	_, err = droppedMaker.VerifyToken(oldToken)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+}
+
+func TestTamperedPasetoPublicToken(t *testing.T) {
+	maker, err := NewPasetoPublicMaker(randomKeyRing(t, randomEd25519Key(t)))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), uuid.New(), TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	parts := strings.Split(strings.TrimPrefix(token, pasetoV4PublicHeader), ".")
+	body, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+	body[0] ^= 1
+	tampered := pasetoV4PublicHeader + base64.RawURLEncoding.EncodeToString(body) + "." + parts[1]
+
+	payload, err := maker.VerifyToken(tampered)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	payload, err = maker.VerifyToken(strings.Replace(token, "v4.public.", "v4.local.", 1))
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestNewPasetoPublicMakerRSAKey(t *testing.T) {
+	_, err := NewPasetoPublicMaker(randomKeyRing(t, randomEd25519Key(t), randomRSAKey(t).Public()))
+	require.ErrorContains(t, err, "is not an ed25519 key")
+}
+
+func TestPasetoPreAuthEncode(t *testing.T) {
+	require.Equal(t, "\x00\x00\x00\x00\x00\x00\x00\x00", string(pasetoPreAuthEncode()))
+	require.Equal(t, "\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00", string(pasetoPreAuthEncode([]byte{})))
+	require.Equal(t, "\x01\x00\x00\x00\x00\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00test", string(pasetoPreAuthEncode([]byte("test"))))
+}
+
+func TestVerifyPasetoV4PublicVector(t *testing.T) {
+	// test vector 4-S-1 of the PASETO spec
+	publicKey, err := hex.DecodeString("1eb9dbbbbc047c03fd70604e0071f0987e16b28b757225c11f00415d0e20b1a2")
+	require.NoError(t, err)
+	token := "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9bg_XBBzds8lTZShVlwwKSgeKpLT3yukTw6JUz3W4h_ExsQV-P0V54zemZDcAxFaSeef1QlXEFtkqxT1ciiQEDA"
+
+	body, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, pasetoV4PublicHeader))
+	require.NoError(t, err)
+	message, err := verifyPasetoV4Public(ed25519.PublicKey(publicKey), body, nil)
+	require.NoError(t, err)
+	require.Equal(t, `{"data":"this is a signed message","exp":"2022-01-01T00:00:00+00:00"}`, string(message))
+}
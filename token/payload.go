@@ -12,9 +12,22 @@ var (
 	ErrExpiredToken = errors.New("token has expired")
 )
 
+// TokenType tells access tokens from refresh tokens, a token of one type is never accepted for the other
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"
+	TokenTypeRefresh TokenType = "refresh"
+)
+
 type Payload struct {
-	ID        uuid.UUID `json:"id"`
-	Username  string    `json:"username"`
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	// SessionID is the login session the token belongs to, tokens of a revoked session are rejected.
+	// It is zero for the tokens issued before sessions were tracked.
+	SessionID uuid.UUID `json:"session_id"`
+	// Type is empty for the tokens issued before it was added
+	Type      TokenType `json:"token_type"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
@@ -27,8 +40,8 @@ func (payload *Payload) Valid() error {
 	return nil
 }
 
-// NewPayload creates a new token payload with a specific username and duration
-func NewPayload(username string, duration time.Duration) (*Payload, error) {
+// NewPayload creates a new token payload with a specific username, session, type and duration
+func NewPayload(username string, sessionID uuid.UUID, tokenType TokenType, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -37,6 +50,8 @@ func NewPayload(username string, duration time.Duration) (*Payload, error) {
 	payload := &Payload{
 		ID:        tokenID,
 		Username:  username,
+		SessionID: sessionID,
+		Type:      tokenType,
 		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
 	}
@@ -2,13 +2,17 @@ package util
 
 import (
 	"github.com/spf13/viper"
+	"math"
 	"time"
 )
 
 // Config stores all configurations in this application
 type Config struct {
-	DBDriver             string        `mapstructure:"DB_DRIVER"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
+	DBDriver string `mapstructure:"DB_DRIVER"`
+	DBSource string `mapstructure:"DB_SOURCE"`
+	// DBTxMaxAttempts is how many times a transaction runs when it fails on a serialization failure or a deadlock,
+	// zero keeps the store default
+	DBTxMaxAttempts      int           `mapstructure:"DB_TX_MAX_ATTEMPTS"`
 	HTTPServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
 	GRPCServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS"`
 	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
@@ -16,6 +20,21 @@ type Config struct {
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
 	Environment          string        `mapstructure:"ENVIRONMENT"`
 	RedisAddress         string        `mapstructure:"REDIS_ADDRESS"`
+	// TokenType is jwt or paseto, empty is jwt
+	TokenType string `mapstructure:"TOKEN_TYPE"`
+	// TokenSigningKeyFile is a PEM Ed25519 or RSA private key tokens are signed with, empty signs with TokenSymmetricKey.
+	// To rotate it, move the old key to TokenVerificationKeyFiles and keep it there until the refresh tokens it signed expire.
+	TokenSigningKeyFile string `mapstructure:"TOKEN_SIGNING_KEY_FILE"`
+	// TokenVerificationKeyFiles is a comma separated list of PEM keys the tokens signed before a rotation are verified against
+	TokenVerificationKeyFiles []string `mapstructure:"TOKEN_VERIFICATION_KEY_FILES"`
+	// ReconciliationAlertEmail is optional, drift is only logged when it is empty
+	ReconciliationAlertEmail string `mapstructure:"RECONCILIATION_ALERT_EMAIL"`
+	// TransferApprovalThreshold is the amount in major units, e.g. dollars, above which a transfer waits for
+	// a second user to approve it. It is scaled to the minor units of the currency of the from account,
+	// zero turns approval off
+	TransferApprovalThreshold int64 `mapstructure:"TRANSFER_APPROVAL_THRESHOLD"`
+	// TransferApprovalTTL is how long a transfer request can wait for a decision before it expires
+	TransferApprovalTTL time.Duration `mapstructure:"TRANSFER_APPROVAL_TTL"`
 }
 
 // LoadConfig reads configuration from file or environment variables.
@@ -34,3 +53,21 @@ func LoadConfig(path string) (config Config, err error) {
 	err = viper.Unmarshal(&config)
 	return
 }
+
+// RequiresApproval reports whether a transfer of amount minor units of currency has to be approved
+// by a second user before it runs
+func (config Config) RequiresApproval(amount int64, currency string) bool {
+	return config.TransferApprovalThreshold > 0 && amount > config.ApprovalThreshold(currency)
+}
+
+// ApprovalThreshold is TransferApprovalThreshold in minor units of currency
+func (config Config) ApprovalThreshold(currency string) int64 {
+	threshold := config.TransferApprovalThreshold
+	for i := 0; i < CurrencyExponent(currency); i++ {
+		if threshold > math.MaxInt64/10 {
+			return math.MaxInt64
+		}
+		threshold *= 10
+	}
+	return threshold
+}
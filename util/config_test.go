@@ -0,0 +1,35 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestRequiresApproval(t *testing.T) {
+	SetCurrency(Currency{Code: "XAA", Exponent: 0, Enabled: true})
+	SetCurrency(Currency{Code: "XAC", Exponent: 3, Enabled: true})
+
+	testCases := []struct {
+		name      string
+		threshold int64
+		amount    int64
+		currency  string
+		requires  bool
+	}{
+		{name: "Off", threshold: 0, amount: math.MaxInt64, currency: USD},
+		{name: "AtThreshold", threshold: 1000, amount: 100000, currency: USD},
+		{name: "AboveThreshold", threshold: 1000, amount: 100001, currency: USD, requires: true},
+		{name: "NoMinorUnits", threshold: 1000, amount: 1001, currency: "XAA", requires: true},
+		{name: "ThreeMinorUnits", threshold: 1000, amount: 1000000, currency: "XAC"},
+		{name: "ThreeMinorUnitsAbove", threshold: 1000, amount: 1000001, currency: "XAC", requires: true},
+		{name: "ScaledPastMaxInt64", threshold: math.MaxInt64 / 10, amount: math.MaxInt64, currency: USD},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := Config{TransferApprovalThreshold: tc.threshold}
+			require.Equal(t, tc.requires, config.RequiresApproval(tc.amount, tc.currency))
+		})
+	}
+}
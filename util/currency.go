@@ -1,16 +1,100 @@
 package util
 
+import (
+	"strconv"
+	"sync"
+)
+
 const (
 	USD = "USD"
 	EUR = "EUR"
 	CAD = "CAD"
 )
 
+// DefaultCurrencyExponent is the number of minor unit digits assumed for a currency missing from the registry
+const DefaultCurrencyExponent = 2
+
+// Currency is an entry of the currency registry
+type Currency struct {
+	Code     string
+	Exponent int
+	Enabled  bool
+}
+
+// currencies is the in-memory copy of the currencies table, it starts with the currencies
+// the first migration supports so the registry works before it is loaded from the database
+var currencies = struct {
+	sync.RWMutex
+	byCode map[string]Currency
+}{
+	byCode: map[string]Currency{
+		USD: {Code: USD, Exponent: 2, Enabled: true},
+		EUR: {Code: EUR, Exponent: 2, Enabled: true},
+		CAD: {Code: CAD, Exponent: 2, Enabled: true},
+	},
+}
+
+// SetCurrencies replaces the content of the currency registry
+func SetCurrencies(list []Currency) {
+	byCode := make(map[string]Currency, len(list))
+	for _, currency := range list {
+		byCode[currency.Code] = currency
+	}
+
+	currencies.Lock()
+	defer currencies.Unlock()
+	currencies.byCode = byCode
+}
+
+// SetCurrency adds a currency to the registry or replaces it
+func SetCurrency(currency Currency) {
+	currencies.Lock()
+	defer currencies.Unlock()
+	currencies.byCode[currency.Code] = currency
+}
+
+// LookupCurrency returns a currency of the registry, enabled or not
+func LookupCurrency(code string) (Currency, bool) {
+	currencies.RLock()
+	defer currencies.RUnlock()
+	currency, ok := currencies.byCode[code]
+	return currency, ok
+}
+
+// IsSupportCurrency reports whether the currency is in the registry and enabled
 func IsSupportCurrency(currency string) bool {
-	switch currency {
-	case USD, CAD, EUR:
-		return true
+	c, ok := LookupCurrency(currency)
+	return ok && c.Enabled
+}
+
+// CurrencyExponent returns the number of minor unit digits of a currency,
+// disabled currencies keep their exponent so existing balances still format correctly
+func CurrencyExponent(currency string) int {
+	if c, ok := LookupCurrency(currency); ok {
+		return c.Exponent
 	}
+	return DefaultCurrencyExponent
+}
 
-	return false
+// FormatCurrencyAmount writes an amount of minor units of a currency as a decimal, e.g. 100 USD is "1.00" and 100 JPY is "100"
+func FormatCurrencyAmount(amount int64, currency string) string {
+	return FormatAmount(amount, CurrencyExponent(currency))
+}
+
+// FormatAmount writes an amount of minor units as a decimal, e.g. -1234 with exponent 2 is "-12.34"
+func FormatAmount(amount int64, exponent int) string {
+	sign := ""
+	abs := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		abs = uint64(-amount)
+	}
+	digits := strconv.FormatUint(abs, 10)
+	if exponent <= 0 {
+		return sign + digits
+	}
+	for len(digits) <= exponent {
+		digits = "0" + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
 }
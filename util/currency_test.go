@@ -0,0 +1,33 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCurrencyRegistry(t *testing.T) {
+	require.True(t, IsSupportCurrency(USD))
+	require.Equal(t, 2, CurrencyExponent(USD))
+	require.False(t, IsSupportCurrency("JPY"))
+
+	SetCurrency(Currency{Code: "JPY", Exponent: 0, Enabled: true})
+	SetCurrency(Currency{Code: "KWD", Exponent: 3, Enabled: false})
+
+	require.True(t, IsSupportCurrency("JPY"))
+	require.Equal(t, "100", FormatCurrencyAmount(100, "JPY"))
+	require.Equal(t, "1.00", FormatCurrencyAmount(100, USD))
+
+	// disabled currencies can't be used but their amounts still format with the right exponent
+	require.False(t, IsSupportCurrency("KWD"))
+	require.Equal(t, "1.234", FormatCurrencyAmount(1234, "KWD"))
+
+	// unknown currencies fall back to the default exponent
+	require.Equal(t, "0.05", FormatCurrencyAmount(5, "XXX"))
+
+	SetCurrencies([]Currency{
+		{Code: USD, Exponent: 2, Enabled: true},
+		{Code: EUR, Exponent: 2, Enabled: true},
+		{Code: CAD, Exponent: 2, Enabled: true},
+	})
+	require.False(t, IsSupportCurrency("JPY"))
+}
@@ -0,0 +1,12 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// IsJSONObject reports whether data is a valid JSON object, arrays and scalars are not
+func IsJSONObject(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{' && json.Valid(data)
+}
@@ -0,0 +1,17 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIsJSONObject(t *testing.T) {
+	require.True(t, IsJSONObject([]byte(`{}`)))
+	require.True(t, IsJSONObject([]byte(` {"order": {"id": 42}} `)))
+
+	require.False(t, IsJSONObject(nil))
+	require.False(t, IsJSONObject([]byte(`[]`)))
+	require.False(t, IsJSONObject([]byte(`"text"`)))
+	require.False(t, IsJSONObject([]byte(`null`)))
+	require.False(t, IsJSONObject([]byte(`{"order":`)))
+}
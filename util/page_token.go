@@ -0,0 +1,48 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidPageToken is returned for a page token that wasn't made by EncodePageToken
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// pageToken is the content of an opaque page token, the id of the last row of the previous page
+type pageToken struct {
+	AfterID int64 `json:"after_id"`
+}
+
+// EncodePageToken makes the token of the page that starts after the row with the given id
+func EncodePageToken(afterID int64) string {
+	data, _ := json.Marshal(pageToken{AfterID: afterID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageToken returns the id the page starts after, an empty token is the first page
+func DecodePageToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidPageToken
+	}
+	var page pageToken
+	if err = json.Unmarshal(data, &page); err != nil || page.AfterID <= 0 {
+		return 0, ErrInvalidPageToken
+	}
+	return page.AfterID, nil
+}
+
+// NextPage trims the extra row a page query fetches past the page size to know whether there is a
+// following page, and returns the token of that page, empty on the last page
+func NextPage[T any](rows []T, pageSize int32, id func(T) int64) ([]T, string) {
+	if len(rows) <= int(pageSize) {
+		return rows, ""
+	}
+	rows = rows[:pageSize]
+	return rows, EncodePageToken(id(rows[len(rows)-1]))
+}
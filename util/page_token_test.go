@@ -0,0 +1,36 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPageToken(t *testing.T) {
+	afterID, err := DecodePageToken("")
+	require.NoError(t, err)
+	require.Zero(t, afterID)
+
+	id := RandomInt(1, 1000000)
+	afterID, err = DecodePageToken(EncodePageToken(id))
+	require.NoError(t, err)
+	require.Equal(t, id, afterID)
+
+	for _, token := range []string{"not a token!", "bm90IGpzb24", EncodePageToken(0), EncodePageToken(-1)} {
+		_, err = DecodePageToken(token)
+		require.ErrorIs(t, err, ErrInvalidPageToken)
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	rows := []int64{1, 2, 3, 4, 5, 6}
+
+	page, token := NextPage(rows, 5, func(id int64) int64 { return id })
+	require.Equal(t, rows[:5], page)
+	afterID, err := DecodePageToken(token)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), afterID)
+
+	page, token = NextPage(rows[:5], 5, func(id int64) int64 { return id })
+	require.Equal(t, rows[:5], page)
+	require.Empty(t, token)
+}
@@ -0,0 +1,79 @@
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Schedules supported by standing orders
+const (
+	ScheduleOnce    = "once"
+	ScheduleDaily   = "daily"
+	ScheduleWeekly  = "weekly"
+	ScheduleMonthly = "monthly"
+	ScheduleCron    = "cron"
+)
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+func IsSupportSchedule(schedule string) bool {
+	switch schedule {
+	case ScheduleOnce, ScheduleDaily, ScheduleWeekly, ScheduleMonthly, ScheduleCron:
+		return true
+	}
+
+	return false
+}
+
+// ValidateCronExpression checks a standard 5 field cron expression
+func ValidateCronExpression(expression string) error {
+	_, err := cronParser.Parse(expression)
+	return err
+}
+
+// FirstRunAt returns the first time a schedule is due at or after start
+func FirstRunAt(schedule string, cronExpression string, start time.Time) (time.Time, error) {
+	if schedule != ScheduleCron {
+		return start, nil
+	}
+
+	sched, err := cronParser.Parse(cronExpression)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression: %w", err)
+	}
+	// cron schedules return the next time strictly after the given one
+	return sched.Next(start.Add(-time.Second)), nil
+}
+
+// NextRunAt returns the run following previous. It reports false when the schedule has no further runs.
+// Monthly runs keep the day of month of start, moving to the last day of shorter months.
+func NextRunAt(schedule string, cronExpression string, start time.Time, previous time.Time) (time.Time, bool, error) {
+	switch schedule {
+	case ScheduleOnce:
+		return time.Time{}, false, nil
+	case ScheduleDaily:
+		return previous.AddDate(0, 0, 1), true, nil
+	case ScheduleWeekly:
+		return previous.AddDate(0, 0, 7), true, nil
+	case ScheduleMonthly:
+		year, month, _ := previous.Date()
+		firstOfNext := time.Date(year, month+1, 1, 0, 0, 0, 0, previous.Location())
+		day := start.Day()
+		if lastDay := firstOfNext.AddDate(0, 1, -1).Day(); day > lastDay {
+			day = lastDay
+		}
+		hour, min, sec := previous.Clock()
+		next := time.Date(firstOfNext.Year(), firstOfNext.Month(), day, hour, min, sec, previous.Nanosecond(), previous.Location())
+		return next, true, nil
+	case ScheduleCron:
+		sched, err := cronParser.Parse(cronExpression)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid cron expression: %w", err)
+		}
+		return sched.Next(previous), true, nil
+	}
+
+	return time.Time{}, false, fmt.Errorf("unsupported schedule %s", schedule)
+}
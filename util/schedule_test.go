@@ -0,0 +1,89 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNextRunAt(t *testing.T) {
+	start := time.Date(2024, time.January, 31, 9, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		schedule       string
+		cronExpression string
+		previous       time.Time
+		next           time.Time
+		more           bool
+	}{
+		{
+			name:     "Once",
+			schedule: ScheduleOnce,
+			previous: start,
+			more:     false,
+		},
+		{
+			name:     "Daily",
+			schedule: ScheduleDaily,
+			previous: start,
+			next:     time.Date(2024, time.February, 1, 9, 30, 0, 0, time.UTC),
+			more:     true,
+		},
+		{
+			name:     "Weekly",
+			schedule: ScheduleWeekly,
+			previous: start,
+			next:     time.Date(2024, time.February, 7, 9, 30, 0, 0, time.UTC),
+			more:     true,
+		},
+		{
+			name:     "MonthlyShortMonth",
+			schedule: ScheduleMonthly,
+			previous: start,
+			next:     time.Date(2024, time.February, 29, 9, 30, 0, 0, time.UTC),
+			more:     true,
+		},
+		{
+			name:     "MonthlyBackToStartDay",
+			schedule: ScheduleMonthly,
+			previous: time.Date(2024, time.February, 29, 9, 30, 0, 0, time.UTC),
+			next:     time.Date(2024, time.March, 31, 9, 30, 0, 0, time.UTC),
+			more:     true,
+		},
+		{
+			name:           "Cron",
+			schedule:       ScheduleCron,
+			cronExpression: "0 8 * * 1",
+			previous:       start,
+			next:           time.Date(2024, time.February, 5, 8, 0, 0, 0, time.UTC),
+			more:           true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, more, err := NextRunAt(tc.schedule, tc.cronExpression, start, tc.previous)
+			require.NoError(t, err)
+			require.Equal(t, tc.more, more)
+			if more {
+				require.Equal(t, tc.next, next)
+			}
+		})
+	}
+}
+
+func TestFirstRunAt(t *testing.T) {
+	start := time.Date(2024, time.January, 31, 9, 30, 0, 0, time.UTC)
+
+	first, err := FirstRunAt(ScheduleDaily, "", start)
+	require.NoError(t, err)
+	require.Equal(t, start, first)
+
+	first, err = FirstRunAt(ScheduleCron, "0 8 * * *", start)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.February, 1, 8, 0, 0, 0, time.UTC), first)
+
+	_, err = FirstRunAt(ScheduleCron, "not a cron", start)
+	require.Error(t, err)
+}
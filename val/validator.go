@@ -1,6 +1,7 @@
 package val
 
 import (
+	"bank/util"
 	"fmt"
 	"net/mail"
 	"regexp"
@@ -9,6 +10,8 @@ import (
 var (
 	isValidUsername = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
 	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	// printable ASCII, the same set the HTTP API accepts
+	isValidClientReference = regexp.MustCompile(`^[\x20-\x7e]*$`).MatchString
 )
 
 func ValidateString(value string, minLength int, maxLength int) error {
@@ -56,3 +59,93 @@ func ValidateFullName(value string) error {
 
 	return nil
 }
+
+func ValidateID(value int64) error {
+	if value < 1 {
+		return fmt.Errorf("must be a positive integer")
+	}
+
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+
+	return nil
+}
+
+func ValidateCurrency(value string) error {
+	if !util.IsSupportCurrency(value) {
+		return fmt.Errorf("unsupported currency %s", value)
+	}
+
+	return nil
+}
+
+func ValidateAccountStatus(value string) error {
+	// the statuses an owner can ask for, accounts are closed separately and become dormant on their own
+	if value != "active" && value != "frozen" {
+		return fmt.Errorf("must be active or frozen")
+	}
+
+	return nil
+}
+
+func ValidateAccountRole(value string) error {
+	if value != "owner" && value != "can_transfer" && value != "view_only" {
+		return fmt.Errorf("must be owner, can_transfer or view_only")
+	}
+
+	return nil
+}
+
+func ValidateIdempotencyKey(value string) error {
+	return ValidateString(value, 1, 255)
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 5 || value > 10 {
+		return fmt.Errorf("must be between 5 and 10")
+	}
+
+	return nil
+}
+
+func ValidatePageToken(value string) error {
+	_, err := util.DecodePageToken(value)
+	return err
+}
+
+func ValidateHistoryDirection(value string) error {
+	if value != "" && value != "in" && value != "out" {
+		return fmt.Errorf("must be in or out")
+	}
+
+	return nil
+}
+
+func ValidateMemo(value string) error {
+	return ValidateString(value, 0, 140)
+}
+
+func ValidateClientReference(value string) error {
+	if err := ValidateString(value, 0, 64); err != nil {
+		return err
+	}
+	if !isValidClientReference(value) {
+		return fmt.Errorf("must contain only printable ASCII characters")
+	}
+
+	return nil
+}
+
+// ValidateTransferMetadata checks the size of the metadata of a transfer once it is encoded as JSON
+func ValidateTransferMetadata(data []byte) error {
+	if len(data) > 4096 {
+		return fmt.Errorf("must not be larger than 4096 bytes")
+	}
+
+	return nil
+}
@@ -7,6 +7,9 @@ import (
 
 type TaskDistributor interface {
 	DistributeTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail, opt ...asynq.Option) error
+	DistributeTaskExecuteStandingOrder(ctx context.Context, payload *PayloadExecuteStandingOrder, opt ...asynq.Option) error
+	DistributeTaskSendReconciliationAlert(ctx context.Context, payload *PayloadSendReconciliationAlert, opt ...asynq.Option) error
+	DistributeTaskPostInterest(ctx context.Context, payload *PayloadPostInterest, opt ...asynq.Option) error
 }
 
 type RedisTaskDistributor struct {
@@ -7,25 +7,42 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+const (
+	QueueCritical = "critical"
+	QueueDefault  = "default"
+	QueueLow      = "low"
+)
+
 type TaskProcessor interface {
 	Start() error
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskDispatchStandingOrders(ctx context.Context, task *asynq.Task) error
+	ProcessTaskExecuteStandingOrder(ctx context.Context, task *asynq.Task) error
+	ProcessTaskExpireHolds(ctx context.Context, task *asynq.Task) error
+	ProcessTaskMarkDormantAccounts(ctx context.Context, task *asynq.Task) error
+	ProcessTaskExpireTransferRequests(ctx context.Context, task *asynq.Task) error
+	ProcessTaskReconcileLedger(ctx context.Context, task *asynq.Task) error
+	ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskDispatchInterestPostings(ctx context.Context, task *asynq.Task) error
+	ProcessTaskPostInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendReconciliationAlert(ctx context.Context, task *asynq.Task) error
 	ShutDown()
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  db.Store
+	server      *asynq.Server
+	store       db.Store
+	distributor TaskDistributor
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) TaskProcessor {
+func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, distributor TaskDistributor) TaskProcessor {
 	server := asynq.NewServer(
 		redisOpt,
 		asynq.Config{
 			Queues: map[string]int{
-				"critical": 6,
-				"default":  3,
-				"low":      1,
+				QueueCritical: 6,
+				QueueDefault:  3,
+				QueueLow:      1,
 			},
 			ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, task *asynq.Task, err error) {
 				log.Error().Err(err).Str("type", task.Type()).
@@ -37,14 +54,25 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) TaskPr
 	)
 
 	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
+		server:      server,
+		store:       store,
+		distributor: distributor,
 	}
 }
 
 func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskDispatchStandingOrders, processor.ProcessTaskDispatchStandingOrders)
+	mux.HandleFunc(TaskExecuteStandingOrder, processor.ProcessTaskExecuteStandingOrder)
+	mux.HandleFunc(TaskExpireHolds, processor.ProcessTaskExpireHolds)
+	mux.HandleFunc(TaskMarkDormantAccounts, processor.ProcessTaskMarkDormantAccounts)
+	mux.HandleFunc(TaskExpireTransferRequests, processor.ProcessTaskExpireTransferRequests)
+	mux.HandleFunc(TaskReconcileLedger, processor.ProcessTaskReconcileLedger)
+	mux.HandleFunc(TaskSendReconciliationAlert, processor.ProcessTaskSendReconciliationAlert)
+	mux.HandleFunc(TaskAccrueInterest, processor.ProcessTaskAccrueInterest)
+	mux.HandleFunc(TaskDispatchInterestPostings, processor.ProcessTaskDispatchInterestPostings)
+	mux.HandleFunc(TaskPostInterest, processor.ProcessTaskPostInterest)
 
 	return processor.server.Start(mux)
 }
@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"time"
+)
+
+const (
+	// dispatchStandingOrdersInterval is how often due standing orders are picked up
+	dispatchStandingOrdersInterval = "@every 1m"
+	// expireHoldsInterval is how often holds past their expiry are released
+	expireHoldsInterval = "@every 5m"
+	// expireTransferRequestsInterval is how often transfer requests past their expiry are closed
+	expireTransferRequestsInterval = "@every 5m"
+	// reconcileLedgerInterval is how often the ledger invariants are checked
+	reconcileLedgerInterval = "@hourly"
+	// accrueInterestInterval runs shortly after midnight UTC, once the previous day has ended
+	accrueInterestInterval = "5 0 * * *"
+	// postInterestInterval runs on the first of the month, after the last day of the previous month was accrued
+	postInterestInterval = "0 2 1 * *"
+	// markDormantAccountsInterval runs once a day, dormancy is measured in months
+	markDormantAccountsInterval = "30 1 * * *"
+)
+
+type TaskScheduler interface {
+	Start() error
+	ShutDown()
+}
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+}
+
+// NewRedisTaskScheduler registers the periodic tasks, reconciliation drift is reported to alertEmail when it is set
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt, alertEmail string) (TaskScheduler, error) {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger:   NewLogger(),
+		Location: time.UTC,
+	})
+
+	reconcilePayload, err := json.Marshal(&PayloadReconcileLedger{AlertEmail: alertEmail})
+	if err != nil {
+		return nil, fmt.Errorf("fail to marshal task payload: %w", err)
+	}
+
+	periodicTasks := []struct {
+		interval string
+		taskType string
+		payload  []byte
+		// maxRetry is zero for tasks that run often enough for the next run to catch up
+		maxRetry int
+	}{
+		{dispatchStandingOrdersInterval, TaskDispatchStandingOrders, nil, 0},
+		{expireHoldsInterval, TaskExpireHolds, nil, 0},
+		{expireTransferRequestsInterval, TaskExpireTransferRequests, nil, 0},
+		{reconcileLedgerInterval, TaskReconcileLedger, reconcilePayload, 0},
+		{accrueInterestInterval, TaskAccrueInterest, nil, 5},
+		{postInterestInterval, TaskDispatchInterestPostings, nil, 5},
+		{markDormantAccountsInterval, TaskMarkDormantAccounts, nil, 5},
+	}
+	for _, periodic := range periodicTasks {
+		task := asynq.NewTask(periodic.taskType, periodic.payload)
+		_, err := scheduler.Register(periodic.interval, task, asynq.Queue(QueueCritical), asynq.MaxRetry(periodic.maxRetry))
+		if err != nil {
+			return nil, fmt.Errorf("fail to register periodic task %s: %w", periodic.taskType, err)
+		}
+	}
+
+	return &RedisTaskScheduler{
+		scheduler: scheduler,
+	}, nil
+}
+
+func (scheduler *RedisTaskScheduler) Start() error {
+	return scheduler.scheduler.Start()
+}
+
+func (scheduler *RedisTaskScheduler) ShutDown() {
+	scheduler.scheduler.Shutdown()
+}
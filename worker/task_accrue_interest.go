@@ -0,0 +1,31 @@
+package worker
+
+import (
+	db "bank/db/sqlc"
+	"context"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// TaskAccrueInterest is enqueued daily by the scheduler and accrues the previous day's interest
+const TaskAccrueInterest = "task:accrue_interest"
+
+func (processor *RedisTaskProcessor) ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	// accruals that already exist for the day are left alone, so a rerun is harmless
+	accruals, err := processor.store.AccrueInterest(ctx, db.AccrueInterestParams{
+		AccrualDate: today.AddDate(0, 0, -1),
+		DayEnd:      today,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to accrue interest: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Time("accrual_date", today.AddDate(0, 0, -1)).
+		Int("count", len(accruals)).Msg("process task")
+	return nil
+}
@@ -0,0 +1,48 @@
+package worker
+
+import (
+	db "bank/db/sqlc"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// TaskDispatchStandingOrders is enqueued periodically by the scheduler
+// and enqueues a TaskExecuteStandingOrder for every due standing order
+const TaskDispatchStandingOrders = "task:dispatch_standing_orders"
+
+const dispatchStandingOrdersBatchSize = 100
+
+func (processor *RedisTaskProcessor) ProcessTaskDispatchStandingOrders(ctx context.Context, task *asynq.Task) error {
+	orders, err := processor.store.ListDueStandingOrders(ctx, db.ListDueStandingOrdersParams{
+		Now:        time.Now(),
+		LimitCount: dispatchStandingOrdersBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list due standing orders: %w", err)
+	}
+
+	for _, order := range orders {
+		payload := &PayloadExecuteStandingOrder{
+			StandingOrderID: order.ID,
+			ScheduledAt:     order.NextRunAt,
+		}
+		opts := []asynq.Option{
+			asynq.MaxRetry(int(order.MaxRetries)),
+			asynq.Queue(QueueCritical),
+			// one task per run, an order that is still due on the next dispatch is not enqueued twice
+			asynq.TaskID(fmt.Sprintf("standing_order:%d:%d", order.ID, order.NextRunAt.Unix())),
+		}
+
+		err = processor.distributor.DistributeTaskExecuteStandingOrder(ctx, payload, opts...)
+		if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			return fmt.Errorf("failed to distribute task: %w", err)
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Int("count", len(orders)).Msg("process task")
+	return nil
+}
@@ -0,0 +1,149 @@
+package worker
+
+import (
+	db "bank/db/sqlc"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+const TaskExecuteStandingOrder = "task:execute_standing_order"
+
+type PayloadExecuteStandingOrder struct {
+	StandingOrderID int64     `json:"standing_order_id"`
+	ScheduledAt     time.Time `json:"scheduled_at"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskExecuteStandingOrder(ctx context.Context, payload *PayloadExecuteStandingOrder, opt ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("fail to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskExecuteStandingOrder, jsonPayload, opt...)
+	taskInfo, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("fail to enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", taskInfo.Queue).
+		Str("id", taskInfo.ID).
+		Bytes("payload", task.Payload()).
+		Msg("enqueue task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskExecuteStandingOrder(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadExecuteStandingOrder
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	order, err := processor.store.GetStandingOrder(ctx, payload.StandingOrderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("standing order doesn't exist: %v: %w", err, asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get standing order: %w", err)
+	}
+	// the order was paused, cancelled or already ran since the task was enqueued
+	if order.Status != db.StandingOrderActive || !order.NextRunAt.Equal(payload.ScheduledAt) {
+		log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+			Str("status", order.Status).Time("next_run_at", order.NextRunAt).
+			Msg("standing order run is stale, skip it")
+		return nil
+	}
+
+	retryCount, _ := asynq.GetRetryCount(ctx)
+	run := db.RecordStandingOrderRunTxParams{
+		StandingOrderID: order.ID,
+		ScheduledAt:     payload.ScheduledAt,
+		Attempt:         int32(retryCount + 1),
+		Status:          db.StandingOrderRunSucceeded,
+		Advance:         true,
+	}
+
+	// the creator of the order may have left the joint account or lost the right to move money from it
+	allowed, err := processor.canTransferFrom(ctx, order)
+	if err != nil {
+		return fmt.Errorf("failed to check standing order owner: %w", err)
+	}
+	if !allowed {
+		run.Status = db.StandingOrderRunSkipped
+		run.Error = fmt.Sprintf("%s can no longer transfer from account [%d]", order.Owner, order.FromAccountID)
+		if _, err = processor.store.RecordStandingOrderRunTx(ctx, run); err != nil && !errors.Is(err, db.ErrStaleStandingOrderRun) {
+			return fmt.Errorf("failed to record standing order run: %w", err)
+		}
+		log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+			Str("status", run.Status).Msg("standing order owner lost access to the account, skip the run")
+		return nil
+	}
+
+	result, transferErr := processor.store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID: order.FromAccountID,
+		ToAccountID:   order.ToAccountID,
+		Amount:        order.Amount,
+		TransferType:  db.TransferTypeStandingOrder,
+		InitiatedBy:   order.Owner,
+		// a retried or re-enqueued run must not move the money twice
+		Idempotency: &db.IdempotencyParams{
+			Username: order.Owner,
+			Key:      fmt.Sprintf("standing_order:%d:%d", order.ID, payload.ScheduledAt.Unix()),
+		},
+	})
+	switch {
+	case transferErr == nil:
+		run.TransferID = sql.NullInt64{Int64: result.Transfer.ID, Valid: true}
+	case errors.Is(transferErr, db.ErrInsufficientFunds):
+		run.Error = transferErr.Error()
+		if order.FailurePolicy == db.StandingOrderRetry && retryCount < int(order.MaxRetries) {
+			run.Status = db.StandingOrderRunFailed
+			run.Advance = false
+		} else {
+			run.Status = db.StandingOrderRunSkipped
+			transferErr = nil
+		}
+	case errors.Is(transferErr, db.ErrAccountFrozen), errors.Is(transferErr, db.ErrAccountClosed),
+		errors.Is(transferErr, db.ErrAccountDormant):
+		// retrying won't help until the account is active again
+		run.Error = transferErr.Error()
+		run.Status = db.StandingOrderRunSkipped
+		transferErr = nil
+	default:
+		return fmt.Errorf("failed to transfer: %w", transferErr)
+	}
+
+	_, err = processor.store.RecordStandingOrderRunTx(ctx, run)
+	if err != nil {
+		if errors.Is(err, db.ErrStaleStandingOrderRun) {
+			log.Info().Err(err).Str("type", task.Type()).Msg("standing order run is stale, skip it")
+			return nil
+		}
+		return fmt.Errorf("failed to record standing order run: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("status", run.Status).Int32("attempt", run.Attempt).Msg("process task")
+	// returning the error lets asynq retry the run later
+	return transferErr
+}
+
+// canTransferFrom tells if the user who set up the order can still move money out of its from account
+func (processor *RedisTaskProcessor) canTransferFrom(ctx context.Context, order db.StandingOrder) (bool, error) {
+	account, err := processor.store.GetAccount(ctx, order.FromAccountID)
+	if err != nil {
+		return false, err
+	}
+	role, err := db.AccountRole(ctx, processor.store, account, order.Owner)
+	if err != nil {
+		return false, err
+	}
+	return db.AccountRoleGrants(role, db.AccountRoleCanTransfer), nil
+}
@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// TaskExpireHolds is enqueued periodically by the scheduler and releases holds past their expiry
+const TaskExpireHolds = "task:expire_holds"
+
+func (processor *RedisTaskProcessor) ProcessTaskExpireHolds(ctx context.Context, task *asynq.Task) error {
+	holds, err := processor.store.ExpireHolds(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to expire holds: %w", err)
+	}
+
+	for _, hold := range holds {
+		log.Info().Int64("hold_id", hold.ID).Int64("account_id", hold.AccountID).
+			Int64("amount", hold.Amount).Msg("hold expired")
+	}
+
+	log.Info().Str("type", task.Type()).Int("count", len(holds)).Msg("process task")
+	return nil
+}
@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// TaskExpireTransferRequests is enqueued periodically by the scheduler and expires transfer requests nobody decided on
+const TaskExpireTransferRequests = "task:expire_transfer_requests"
+
+func (processor *RedisTaskProcessor) ProcessTaskExpireTransferRequests(ctx context.Context, task *asynq.Task) error {
+	requests, err := processor.store.ExpireTransferRequests(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to expire transfer requests: %w", err)
+	}
+
+	for _, request := range requests {
+		log.Info().Int64("transfer_request_id", request.ID).Str("requested_by", request.RequestedBy).
+			Int64("amount", request.Amount).Msg("transfer request expired")
+	}
+
+	log.Info().Str("type", task.Type()).Int("count", len(requests)).Msg("process task")
+	return nil
+}
@@ -0,0 +1,34 @@
+package worker
+
+import (
+	db "bank/db/sqlc"
+	"context"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// TaskMarkDormantAccounts is enqueued daily by the scheduler and marks accounts without activity as dormant
+const TaskMarkDormantAccounts = "task:mark_dormant_accounts"
+
+// dormancyPeriod is how long an active account can go without any entry before it becomes dormant
+const dormancyPeriod = 365 * 24 * time.Hour
+
+func (processor *RedisTaskProcessor) ProcessTaskMarkDormantAccounts(ctx context.Context, task *asynq.Task) error {
+	accounts, err := processor.store.MarkDormantAccounts(ctx, db.MarkDormantAccountsParams{
+		InactiveSince: time.Now().Add(-dormancyPeriod),
+		FeeOwner:      db.FeeIncomeOwner,
+		ExpenseOwner:  db.InterestExpenseOwner,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark dormant accounts: %w", err)
+	}
+
+	for _, account := range accounts {
+		log.Info().Int64("account_id", account.ID).Str("owner", account.Owner).Msg("account is dormant")
+	}
+
+	log.Info().Str("type", task.Type()).Int("count", len(accounts)).Msg("process task")
+	return nil
+}
@@ -0,0 +1,99 @@
+package worker
+
+import (
+	db "bank/db/sqlc"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// TaskDispatchInterestPostings is enqueued monthly by the scheduler
+// and enqueues a TaskPostInterest for every account with interest accrued in the previous month
+const TaskDispatchInterestPostings = "task:dispatch_interest_postings"
+
+func (processor *RedisTaskProcessor) ProcessTaskDispatchInterestPostings(ctx context.Context, task *asynq.Task) error {
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	period := month.AddDate(0, -1, 0)
+
+	accountIDs, err := processor.store.ListAccountsWithUnpostedInterest(ctx, month)
+	if err != nil {
+		return fmt.Errorf("failed to list accounts with unposted interest: %w", err)
+	}
+
+	for _, accountID := range accountIDs {
+		payload := &PayloadPostInterest{
+			AccountID: accountID,
+			Period:    period,
+		}
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.Queue(QueueDefault),
+			asynq.TaskID(fmt.Sprintf("post_interest:%d:%s", accountID, period.Format("2006-01"))),
+		}
+
+		err = processor.distributor.DistributeTaskPostInterest(ctx, payload, opts...)
+		if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			return fmt.Errorf("failed to distribute task: %w", err)
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Int("count", len(accountIDs)).Msg("process task")
+	return nil
+}
+
+const TaskPostInterest = "task:post_interest"
+
+type PayloadPostInterest struct {
+	AccountID int64     `json:"account_id"`
+	Period    time.Time `json:"period"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskPostInterest(ctx context.Context, payload *PayloadPostInterest, opt ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("fail to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskPostInterest, jsonPayload, opt...)
+	taskInfo, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("fail to enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", taskInfo.Queue).
+		Str("id", taskInfo.ID).
+		Bytes("payload", task.Payload()).
+		Msg("enqueue task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskPostInterest(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadPostInterest
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	result, err := processor.store.PostInterestTx(ctx, db.PostInterestTxParams{
+		AccountID: payload.AccountID,
+		Period:    payload.Period,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInterestAlreadyPosted) {
+			log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).Msg("interest already posted, skip it")
+			return nil
+		}
+		return fmt.Errorf("failed to post interest: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Int64("amount", result.Posting.Amount).Str("carried", result.Posting.Carried).
+		Msg("process task")
+	return nil
+}
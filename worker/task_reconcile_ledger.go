@@ -0,0 +1,92 @@
+package worker
+
+import (
+	db "bank/db/sqlc"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+// TaskReconcileLedger is enqueued periodically by the scheduler and checks the ledger invariants
+const TaskReconcileLedger = "task:reconcile_ledger"
+
+type PayloadReconcileLedger struct {
+	// AlertEmail receives an alert when drift is found, no alert is sent when it is empty
+	AlertEmail string `json:"alert_email"`
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskReconcileLedger(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadReconcileLedger
+	if len(task.Payload()) > 0 {
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+		}
+	}
+
+	result, err := processor.store.ReconcileTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile ledger: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Int64("run_id", result.Run.ID).Str("status", result.Run.Status).
+		Int32("drifted_accounts", result.Run.DriftedAccounts).
+		Int32("orphaned_entries", result.Run.OrphanedEntries).
+		Int32("unbalanced_transfers", result.Run.UnbalancedTransfers).
+		Msg("process task")
+
+	if result.Run.Status != db.ReconciliationDrift || payload.AlertEmail == "" {
+		return nil
+	}
+	alert := &PayloadSendReconciliationAlert{
+		RunID: result.Run.ID,
+		Email: payload.AlertEmail,
+	}
+	err = processor.distributor.DistributeTaskSendReconciliationAlert(ctx, alert, asynq.Queue(QueueCritical))
+	if err != nil {
+		return fmt.Errorf("failed to distribute reconciliation alert: %v: %w", err, asynq.SkipRetry)
+	}
+	return nil
+}
+
+const TaskSendReconciliationAlert = "task:send_reconciliation_alert"
+
+type PayloadSendReconciliationAlert struct {
+	RunID int64  `json:"run_id"`
+	Email string `json:"email"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendReconciliationAlert(ctx context.Context, payload *PayloadSendReconciliationAlert, opt ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("fail to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendReconciliationAlert, jsonPayload, opt...)
+	taskInfo, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("fail to enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", taskInfo.Queue).
+		Str("id", taskInfo.ID).
+		Bytes("payload", task.Payload()).
+		Msg("enqueue task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendReconciliationAlert(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendReconciliationAlert
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	log.Warn().Str("type", task.Type()).Int64("run_id", payload.RunID).Str("email", payload.Email).
+		Msg("ledger drift detected")
+
+	//TODO: Send alert email
+	return nil
+}